@@ -0,0 +1,362 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	v2 "github.com/backwoods-devops/archimedes/api/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// OutputsAnnotation holds the v2 outputs after the first when an ArchimedesProperty is
+// converted to v1, so they survive converting back
+const OutputsAnnotation = "archimedes.backwoods-devops.io/v2-outputs"
+
+var _ conversion.Convertible = &ArchimedesProperty{}
+
+// ConvertTo converts this ArchimedesProperty to the Hub version (v2).
+func (src *ArchimedesProperty) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v2.ArchimedesProperty)
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	outputs, err := src.AdditionalOutputs()
+	if err != nil {
+		return err
+	}
+	delete(dst.Annotations, OutputsAnnotation)
+	if len(dst.Annotations) == 0 {
+		dst.Annotations = nil
+	}
+
+	s := src.Spec
+	dst.Spec = v2.ArchimedesPropertySpec{
+		Source: v2.PropertySource{
+			Type:     s.SourceType,
+			RepoUrl:  s.RepoUrl,
+			Revision: s.Revision,
+			CAPath:   s.CAPath,
+			Path:     s.LocalPath,
+		},
+		Template: v2.PropertyTemplate{
+			Path:         s.PropertiesPath,
+			IncludePaths: append([]string(nil), s.IncludePaths...),
+		},
+		Values: v2.PropertyValues{
+			Inline: s.SourceConfig,
+		},
+		Outputs: append([]v2.PropertyOutput{{
+			ConfigMapName:  s.ConfigMapName,
+			Type:           s.PropertyType,
+			KeyName:        s.KeyName,
+			Immutable:      s.Immutable,
+			RetainVersions: copyInt32(s.RetainVersions),
+		}}, outputs...),
+		Sync: v2.PropertySync{
+			Suspend:        s.Suspend,
+			ApprovalPolicy: s.ApprovalPolicy,
+			HistoryLimit:   copyInt32(s.HistoryLimit),
+			DryRun:         s.DryRun,
+		},
+	}
+	for _, ref := range s.ValuesFrom {
+		dst.Spec.Values.From = append(dst.Spec.Values.From, v2.ValuesReference{Kind: ref.Kind, Name: ref.Name})
+	}
+	if s.SourceRef != nil {
+		dst.Spec.Source.SourceRef = &v2.SourceReference{Name: s.SourceRef.Name}
+	}
+	if s.HTTP != nil {
+		dst.Spec.Source.HTTP = &v2.HTTPSource{
+			Url:      s.HTTP.Url,
+			CAPath:   s.HTTP.CAPath,
+			Interval: copyDuration(s.HTTP.Interval),
+		}
+	}
+	if s.TemplateConfigMap != nil {
+		dst.Spec.Source.ConfigMap = &v2.ConfigMapSource{Name: s.TemplateConfigMap.Name}
+	}
+	if s.Library != nil {
+		dst.Spec.Template.Library = &v2.TemplateLibrary{
+			RepoUrl:  s.Library.RepoUrl,
+			Revision: s.Library.Revision,
+			CAPath:   s.Library.CAPath,
+			Paths:    append([]string(nil), s.Library.Paths...),
+		}
+	}
+	if s.Provenance != nil {
+		dst.Spec.Outputs[0].Provenance = &v2.Provenance{
+			Placement: s.Provenance.Placement,
+			KeyPrefix: s.Provenance.KeyPrefix,
+		}
+	}
+	for _, t := range s.RolloutTargets {
+		dst.Spec.Outputs[0].RolloutTargets = append(dst.Spec.Outputs[0].RolloutTargets, v2.RolloutTarget{
+			Kind:     t.Kind,
+			Name:     t.Name,
+			Selector: t.Selector.DeepCopy(),
+		})
+	}
+	for _, w := range s.SyncWindows {
+		dst.Spec.Sync.Windows = append(dst.Spec.Sync.Windows, v2.SyncWindow{
+			Kind:     w.Kind,
+			Schedule: w.Schedule,
+			Duration: w.Duration,
+		})
+	}
+	if s.Rollback != nil {
+		dst.Spec.Sync.Rollback = &v2.Rollback{
+			Index:  copyInt32(s.Rollback.Index),
+			Commit: s.Rollback.Commit,
+		}
+	}
+
+	st := src.Status
+	dst.Status = v2.ArchimedesPropertyStatus{
+		Conditions:             append(dst.Status.Conditions[:0:0], st.Conditions...),
+		ContentHash:            st.ContentHash,
+		RestartedWorkloads:     append([]string(nil), st.RestartedWorkloads...),
+		ConfigMapName:          st.ConfigMapName,
+		LastHandledReconcileAt: st.LastHandledReconcileAt,
+		Commit:                 st.Commit,
+		NextSyncWindow:         st.NextSyncWindow.DeepCopy(),
+		Values:                 append([]string(nil), st.Values...),
+	}
+	for _, o := range st.Outputs {
+		dst.Status.Outputs = append(dst.Status.Outputs, v2.OutputStatus{
+			Name:               o.Name,
+			ContentHash:        o.ContentHash,
+			RestartedWorkloads: append([]string(nil), o.RestartedWorkloads...),
+			ConfigMapName:      o.ConfigMapName,
+		})
+	}
+	for _, h := range st.History {
+		dst.Status.History = append(dst.Status.History, v2.RenderHistoryEntry{
+			Commit:     h.Commit,
+			ValuesHash: h.ValuesHash,
+			DataHash:   h.DataHash,
+			Timestamp:  h.Timestamp,
+			Snapshot:   h.Snapshot,
+		})
+	}
+	if st.PendingChange != nil {
+		dst.Status.PendingChange = &v2.PendingChange{
+			Commit:  st.PendingChange.Commit,
+			Added:   append([]string(nil), st.PendingChange.Added...),
+			Removed: append([]string(nil), st.PendingChange.Removed...),
+			Changed: append([]string(nil), st.PendingChange.Changed...),
+		}
+	}
+
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v2) to this version.
+func (dst *ArchimedesProperty) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v2.ArchimedesProperty)
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+
+	s := src.Spec
+	dst.Spec = ArchimedesPropertySpec{
+		SourceType:     s.Source.Type,
+		RepoUrl:        s.Source.RepoUrl,
+		Revision:       s.Source.Revision,
+		CAPath:         s.Source.CAPath,
+		LocalPath:      s.Source.Path,
+		PropertiesPath: s.Template.Path,
+		IncludePaths:   append([]string(nil), s.Template.IncludePaths...),
+		SourceConfig:   s.Values.Inline,
+		Suspend:        s.Sync.Suspend,
+		ApprovalPolicy: s.Sync.ApprovalPolicy,
+		HistoryLimit:   copyInt32(s.Sync.HistoryLimit),
+		DryRun:         s.Sync.DryRun,
+	}
+	for _, ref := range s.Values.From {
+		dst.Spec.ValuesFrom = append(dst.Spec.ValuesFrom, ValuesReference{Kind: ref.Kind, Name: ref.Name})
+	}
+	if s.Source.SourceRef != nil {
+		dst.Spec.SourceRef = &SourceReference{Name: s.Source.SourceRef.Name}
+	}
+	if s.Source.HTTP != nil {
+		dst.Spec.HTTP = &HTTPSource{
+			Url:      s.Source.HTTP.Url,
+			CAPath:   s.Source.HTTP.CAPath,
+			Interval: copyDuration(s.Source.HTTP.Interval),
+		}
+	}
+	if s.Source.ConfigMap != nil {
+		dst.Spec.TemplateConfigMap = &ConfigMapSource{Name: s.Source.ConfigMap.Name}
+	}
+	if s.Template.Library != nil {
+		dst.Spec.Library = &TemplateLibrary{
+			RepoUrl:  s.Template.Library.RepoUrl,
+			Revision: s.Template.Library.Revision,
+			CAPath:   s.Template.Library.CAPath,
+			Paths:    append([]string(nil), s.Template.Library.Paths...),
+		}
+	}
+	for _, w := range s.Sync.Windows {
+		dst.Spec.SyncWindows = append(dst.Spec.SyncWindows, SyncWindow{
+			Kind:     w.Kind,
+			Schedule: w.Schedule,
+			Duration: w.Duration,
+		})
+	}
+	if s.Sync.Rollback != nil {
+		dst.Spec.Rollback = &Rollback{
+			Index:  copyInt32(s.Sync.Rollback.Index),
+			Commit: s.Sync.Rollback.Commit,
+		}
+	}
+
+	if len(s.Outputs) > 0 {
+		setOutput(&dst.Spec, s.Outputs[0])
+	}
+	if len(s.Outputs) > 1 {
+		if err := dst.setAdditionalOutputs(s.Outputs[1:]); err != nil {
+			return err
+		}
+	}
+
+	st := src.Status
+	dst.Status = ArchimedesPropertyStatus{
+		Conditions:             append(dst.Status.Conditions[:0:0], st.Conditions...),
+		ContentHash:            st.ContentHash,
+		RestartedWorkloads:     append([]string(nil), st.RestartedWorkloads...),
+		ConfigMapName:          st.ConfigMapName,
+		LastHandledReconcileAt: st.LastHandledReconcileAt,
+		Commit:                 st.Commit,
+		NextSyncWindow:         st.NextSyncWindow.DeepCopy(),
+		Values:                 append([]string(nil), st.Values...),
+	}
+	for _, o := range st.Outputs {
+		dst.Status.Outputs = append(dst.Status.Outputs, OutputStatus{
+			Name:               o.Name,
+			ContentHash:        o.ContentHash,
+			RestartedWorkloads: append([]string(nil), o.RestartedWorkloads...),
+			ConfigMapName:      o.ConfigMapName,
+		})
+	}
+	for _, h := range st.History {
+		dst.Status.History = append(dst.Status.History, RenderHistoryEntry{
+			Commit:     h.Commit,
+			ValuesHash: h.ValuesHash,
+			DataHash:   h.DataHash,
+			Timestamp:  h.Timestamp,
+			Snapshot:   h.Snapshot,
+		})
+	}
+	if st.PendingChange != nil {
+		dst.Status.PendingChange = &PendingChange{
+			Commit:  st.PendingChange.Commit,
+			Added:   append([]string(nil), st.PendingChange.Added...),
+			Removed: append([]string(nil), st.PendingChange.Removed...),
+			Changed: append([]string(nil), st.PendingChange.Changed...),
+		}
+	}
+
+	return nil
+}
+
+// AdditionalOutputs returns the v2 outputs after the first kept in the OutputsAnnotation
+func (r *ArchimedesProperty) AdditionalOutputs() ([]v2.PropertyOutput, error) {
+	raw, ok := r.Annotations[OutputsAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	outputs := []v2.PropertyOutput{}
+	if err := json.Unmarshal([]byte(raw), &outputs); err != nil {
+		return nil, fmt.Errorf("annotation %s: %w", OutputsAnnotation, err)
+	}
+	return outputs, nil
+}
+
+// setAdditionalOutputs keeps the v2 outputs after the first in the OutputsAnnotation
+func (r *ArchimedesProperty) setAdditionalOutputs(outputs []v2.PropertyOutput) error {
+	raw, err := json.Marshal(outputs)
+	if err != nil {
+		return err
+	}
+	if r.Annotations == nil {
+		r.Annotations = map[string]string{}
+	}
+	r.Annotations[OutputsAnnotation] = string(raw)
+	return nil
+}
+
+// ForOutput returns a copy of the property writing a v2 output after the first in place of
+// its own ConfigMap, with the status last recorded for the output
+func (r *ArchimedesProperty) ForOutput(out v2.PropertyOutput) *ArchimedesProperty {
+	c := r.DeepCopy()
+	delete(c.Annotations, OutputsAnnotation)
+	setOutput(&c.Spec, out)
+
+	c.Status.ContentHash = ""
+	c.Status.RestartedWorkloads = nil
+	c.Status.ConfigMapName = ""
+	for _, o := range r.Status.Outputs {
+		if o.Name == out.ConfigMapName {
+			c.Status.ContentHash = o.ContentHash
+			c.Status.RestartedWorkloads = append([]string(nil), o.RestartedWorkloads...)
+			c.Status.ConfigMapName = o.ConfigMapName
+		}
+	}
+	c.Status.Outputs = nil
+	return c
+}
+
+// setOutput sets the fields of a v1 spec written to by a v2 output
+func setOutput(s *ArchimedesPropertySpec, out v2.PropertyOutput) {
+	s.ConfigMapName = out.ConfigMapName
+	s.PropertyType = out.Type
+	s.KeyName = out.KeyName
+	s.Immutable = out.Immutable
+	s.RetainVersions = copyInt32(out.RetainVersions)
+	s.Provenance = nil
+	if out.Provenance != nil {
+		s.Provenance = &Provenance{
+			Placement: out.Provenance.Placement,
+			KeyPrefix: out.Provenance.KeyPrefix,
+		}
+	}
+	s.RolloutTargets = nil
+	for _, t := range out.RolloutTargets {
+		s.RolloutTargets = append(s.RolloutTargets, RolloutTarget{
+			Kind:     t.Kind,
+			Name:     t.Name,
+			Selector: t.Selector.DeepCopy(),
+		})
+	}
+}
+
+func copyInt32(i *int32) *int32 {
+	if i == nil {
+		return nil
+	}
+	c := *i
+	return &c
+}
+
+func copyDuration(d *metav1.Duration) *metav1.Duration {
+	if d == nil {
+		return nil
+	}
+	c := *d
+	return &c
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	v2 "github.com/backwoods-devops/archimedes/api/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestConvertRoundTrip(t *testing.T) {
+	retain := int32(2)
+	index := int32(1)
+	src := &ArchimedesProperty{
+		ObjectMeta: metav1.ObjectMeta{Name: "sample", Namespace: "default"},
+		Spec: ArchimedesPropertySpec{
+			ConfigMapName:     "app-properties",
+			RepoUrl:           "https://example.com/app.git",
+			Revision:          "main",
+			SourceRef:         &SourceReference{Name: "app"},
+			SourceType:        "http",
+			HTTP:              &HTTPSource{Url: "https://example.com/app.tpl", Interval: &metav1.Duration{Duration: time.Minute}},
+			TemplateConfigMap: &ConfigMapSource{Name: "app-template"},
+			LocalPath:         "/templates/app",
+			PropertiesPath:    "config/properties.tpl",
+			IncludePaths:      []string{"config/partials/*.tpl"},
+			Library:           &TemplateLibrary{RepoUrl: "https://example.com/lib.git", Revision: "main", Paths: []string{"*.tpl"}},
+			SourceConfig:      "env: dev",
+			ValuesFrom:        []ValuesReference{{Kind: "ClusterArchimedesValues", Name: "platform"}},
+			PropertyType:      "key",
+			KeyName:           "app.properties",
+			Provenance:        &Provenance{Placement: "annotations"},
+			RolloutTargets:    []RolloutTarget{{Kind: "Deployment", Name: "app"}},
+			Immutable:         true,
+			RetainVersions:    &retain,
+			Rollback:          &Rollback{Index: &index},
+			Suspend:           true,
+			ApprovalPolicy:    "Manual",
+			SyncWindows:       []SyncWindow{{Kind: "allow", Schedule: "0 9 * * *", Duration: metav1.Duration{Duration: time.Hour}}},
+			DryRun:            true,
+		},
+		Status: ArchimedesPropertyStatus{
+			ContentHash:   "abc",
+			ConfigMapName: "app-properties-abc",
+			Commit:        "0123456",
+			Values:        []string{"ClusterArchimedesValues/platform"},
+			PendingChange: &PendingChange{Commit: "89abcde", Changed: []string{"db.url"}},
+			Outputs:       []OutputStatus{{Name: "app-file", ContentHash: "def", ConfigMapName: "app-file", RestartedWorkloads: []string{"Deployment/app"}}},
+		},
+	}
+
+	hub := &v2.ArchimedesProperty{}
+	if err := src.ConvertTo(hub); err != nil {
+		t.Fatalf("ConvertTo() error = %v", err)
+	}
+	if len(hub.Spec.Outputs) != 1 || hub.Spec.Outputs[0].KeyName != "app.properties" {
+		t.Errorf("ConvertTo() outputs = %+v", hub.Spec.Outputs)
+	}
+	if hub.Spec.Template.Path != "config/properties.tpl" || !hub.Spec.Sync.Suspend {
+		t.Errorf("ConvertTo() spec = %+v", hub.Spec)
+	}
+
+	dst := &ArchimedesProperty{}
+	if err := dst.ConvertFrom(hub); err != nil {
+		t.Fatalf("ConvertFrom() error = %v", err)
+	}
+	if !reflect.DeepEqual(src, dst) {
+		t.Errorf("round trip = %+v, want %+v", dst, src)
+	}
+}
+
+func TestConvertExtraOutputs(t *testing.T) {
+	hub := &v2.ArchimedesProperty{
+		ObjectMeta: metav1.ObjectMeta{Name: "sample"},
+		Spec: v2.ArchimedesPropertySpec{
+			Outputs: []v2.PropertyOutput{
+				{ConfigMapName: "first", Type: "kvp"},
+				{ConfigMapName: "second", Type: "key", KeyName: "app.properties"},
+			},
+		},
+		Status: v2.ArchimedesPropertyStatus{
+			ConfigMapName: "first",
+			ContentHash:   "abc",
+			Outputs:       []v2.OutputStatus{{Name: "second", ConfigMapName: "second", ContentHash: "def"}},
+		},
+	}
+
+	spoke := &ArchimedesProperty{}
+	if err := spoke.ConvertFrom(hub); err != nil {
+		t.Fatalf("ConvertFrom() error = %v", err)
+	}
+	if spoke.Spec.ConfigMapName != "first" || spoke.Annotations[OutputsAnnotation] == "" {
+		t.Fatalf("ConvertFrom() = %+v", spoke)
+	}
+
+	back := &v2.ArchimedesProperty{}
+	if err := spoke.ConvertTo(back); err != nil {
+		t.Fatalf("ConvertTo() error = %v", err)
+	}
+	if !reflect.DeepEqual(hub, back) {
+		t.Errorf("round trip = %+v, want %+v", back, hub)
+	}
+}
+
+func TestForOutput(t *testing.T) {
+	retain := int32(1)
+	r := &ArchimedesProperty{
+		ObjectMeta: metav1.ObjectMeta{Name: "sample", Annotations: map[string]string{
+			"team":            "trees",
+			OutputsAnnotation: `[{"configMapName":"second","type":"key","keyName":"app.properties","immutable":true,"retainVersions":1}]`,
+		}},
+		Spec: ArchimedesPropertySpec{
+			ConfigMapName:  "first",
+			PropertyType:   "kvp",
+			PropertiesPath: "config/properties.tpl",
+			Provenance:     &Provenance{Placement: "annotations"},
+			RolloutTargets: []RolloutTarget{{Kind: "Deployment", Name: "app"}},
+		},
+		Status: ArchimedesPropertyStatus{
+			ConfigMapName: "first",
+			ContentHash:   "abc",
+			Commit:        "0123456",
+			Outputs:       []OutputStatus{{Name: "second", ConfigMapName: "second-0123456789", ContentHash: "def"}},
+		},
+	}
+	outputs, err := r.AdditionalOutputs()
+	if err != nil || len(outputs) != 1 {
+		t.Fatalf("AdditionalOutputs() = %+v, %v", outputs, err)
+	}
+
+	output := r.ForOutput(outputs[0])
+	want := ArchimedesPropertySpec{
+		ConfigMapName:  "second",
+		PropertyType:   "key",
+		KeyName:        "app.properties",
+		PropertiesPath: "config/properties.tpl",
+		Immutable:      true,
+		RetainVersions: &retain,
+	}
+	if !reflect.DeepEqual(output.Spec, want) {
+		t.Errorf("ForOutput() spec = %+v, want %+v", output.Spec, want)
+	}
+	if output.Status.ConfigMapName != "second-0123456789" || output.Status.ContentHash != "def" || output.Status.Commit != "0123456" || output.Status.Outputs != nil {
+		t.Errorf("ForOutput() status = %+v", output.Status)
+	}
+	if _, ok := output.Annotations[OutputsAnnotation]; ok || output.Annotations["team"] != "trees" {
+		t.Errorf("ForOutput() annotations = %v", output.Annotations)
+	}
+	if r.Spec.ConfigMapName != "first" || r.Annotations[OutputsAnnotation] == "" {
+		t.Errorf("ForOutput() modified the property: %+v", r)
+	}
+}
@@ -0,0 +1,396 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"gopkg.in/yaml.v2"
+	admissionv1 "k8s.io/api/admission/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	// DefaultPropertyType is the property type used when none is set
+	DefaultPropertyType = "kvp"
+	// DefaultRevision is the revision used when none is set
+	DefaultRevision = "main"
+
+	// SourceTypeGit clones the template from repoUrl or the repo of sourceRef
+	SourceTypeGit = "git"
+	// SourceTypeHTTP downloads the template from a url
+	SourceTypeHTTP = "http"
+	// SourceTypeConfigMap reads the template from a ConfigMap in the namespace of the property
+	SourceTypeConfigMap = "configMap"
+	// SourceTypePath reads the template from a directory mounted in the operator
+	SourceTypePath = "path"
+)
+
+// log is for logging in this package.
+var archimedespropertylog = logf.Log.WithName("archimedesproperty-resource")
+
+// validatingWebhookPath is the path the validating webhook of ArchimedesProperty is served at
+const validatingWebhookPath = "/validate-archimedes-backwoods-devops-io-v1-archimedesproperty"
+
+// PropertyWebhook serves the validating webhook of ArchimedesProperty with its options
+// +kubebuilder:object:generate=false
+type PropertyWebhook struct {
+	// ValidateSourceConfig enables parsing the sourceConfig YAML at admission
+	ValidateSourceConfig bool
+
+	decoder *admission.Decoder
+}
+
+// SetupWebhookWithManager registers the webhooks of ArchimedesProperty with the default options
+func (r *ArchimedesProperty) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return (&PropertyWebhook{ValidateSourceConfig: true}).SetupWebhookWithManager(mgr)
+}
+
+// SetupWebhookWithManager registers the defaulting, validating and conversion webhooks of
+// ArchimedesProperty. The validating webhook is served by w, the builder skips its path.
+func (w *PropertyWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	mgr.GetWebhookServer().Register(validatingWebhookPath, &webhook.Admission{Handler: w})
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&ArchimedesProperty{}).
+		Complete()
+}
+
+// InjectDecoder implements admission.DecoderInjector
+func (w *PropertyWebhook) InjectDecoder(d *admission.Decoder) error {
+	w.decoder = d
+	return nil
+}
+
+// Handle validates the property of a create or update request
+func (w *PropertyWebhook) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
+		return admission.Allowed("")
+	}
+	r := &ArchimedesProperty{}
+	if err := w.decoder.DecodeRaw(req.Object, r); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	archimedespropertylog.Info("validate", "name", r.Name, "operation", req.Operation)
+
+	err := r.validate(w)
+	var apiStatus apierrors.APIStatus
+	if errors.As(err, &apiStatus) {
+		status := apiStatus.Status()
+		return admission.Response{AdmissionResponse: admissionv1.AdmissionResponse{Allowed: false, Result: &status}}
+	}
+	if err != nil {
+		return admission.Denied(err.Error())
+	}
+	return admission.Allowed("")
+}
+
+//+kubebuilder:webhook:path=/mutate-archimedes-backwoods-devops-io-v1-archimedesproperty,mutating=true,failurePolicy=fail,sideEffects=None,groups=archimedes.backwoods-devops.io,resources=archimedesproperties,verbs=create;update,versions=v1,name=marchimedesproperty.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Defaulter = &ArchimedesProperty{}
+
+// Default implements webhook.Defaulter so a webhook will be registered for the type
+func (r *ArchimedesProperty) Default() {
+	archimedespropertylog.Info("default", "name", r.Name)
+
+	if r.Spec.PropertyType == "" {
+		r.Spec.PropertyType = DefaultPropertyType
+	}
+	if r.Spec.SourceType == "" {
+		r.Spec.SourceType = SourceTypeGit
+	}
+	if r.Spec.Revision == "" && r.Spec.SourceRef == nil && r.Spec.SourceType == SourceTypeGit {
+		r.Spec.Revision = DefaultRevision
+	}
+	if r.Spec.ConfigMapName == "" {
+		r.Spec.ConfigMapName = r.Name
+	}
+	// the v2 outputs after the first are defaulted where they are kept, invalid outputs
+	// are left to validation
+	if outputs, err := r.AdditionalOutputs(); err == nil && len(outputs) > 0 {
+		for i := range outputs {
+			if outputs[i].Type == "" {
+				outputs[i].Type = DefaultPropertyType
+			}
+		}
+		if err := r.setAdditionalOutputs(outputs); err != nil {
+			archimedespropertylog.Error(err, "could not default outputs", "name", r.Name)
+		}
+	}
+}
+
+//+kubebuilder:webhook:path=/validate-archimedes-backwoods-devops-io-v1-archimedesproperty,mutating=false,failurePolicy=fail,sideEffects=None,groups=archimedes.backwoods-devops.io,resources=archimedesproperties,verbs=create;update,versions=v1,name=varchimedesproperty.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &ArchimedesProperty{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *ArchimedesProperty) ValidateCreate() error {
+	archimedespropertylog.Info("validate create", "name", r.Name)
+
+	return r.validate(&PropertyWebhook{ValidateSourceConfig: true})
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *ArchimedesProperty) ValidateUpdate(old runtime.Object) error {
+	archimedespropertylog.Info("validate update", "name", r.Name)
+
+	return r.validate(&PropertyWebhook{ValidateSourceConfig: true})
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *ArchimedesProperty) ValidateDelete() error {
+	return nil
+}
+
+func (r *ArchimedesProperty) validate(w *PropertyWebhook) error {
+	errs := r.Spec.validate(field.NewPath("spec"), w)
+	errs = append(errs, r.validateOutputs()...)
+	if len(errs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(GroupVersion.WithKind("ArchimedesProperty").GroupKind(), r.Name, errs)
+}
+
+func (s *ArchimedesPropertySpec) validate(path *field.Path, w *PropertyWebhook) field.ErrorList {
+	var errs field.ErrorList
+
+	errs = append(errs, s.validateSource(path)...)
+	for _, msg := range validation.IsDNS1123Subdomain(s.ConfigMapName) {
+		errs = append(errs, field.Invalid(path.Child("configMapName"), s.ConfigMapName, msg))
+	}
+
+	switch s.PropertyType {
+	case "kvp":
+	case "key":
+		if s.KeyName == "" {
+			errs = append(errs, field.Required(path.Child("keyName"), "keyName is required when propertyType is key"))
+		}
+	default:
+		errs = append(errs, field.NotSupported(path.Child("propertyType"), s.PropertyType, []string{"kvp", "key"}))
+	}
+
+	if w.ValidateSourceConfig && s.SourceConfig != "" {
+		values := map[string]interface{}{}
+		if err := yaml.Unmarshal([]byte(s.SourceConfig), &values); err != nil {
+			errs = append(errs, field.Invalid(path.Child("sourceConfig"), s.SourceConfig, err.Error()))
+		}
+	}
+
+	for i, ref := range s.ValuesFrom {
+		refPath := path.Child("valuesFrom").Index(i)
+		if ref.Kind != "ArchimedesValues" && ref.Kind != "ClusterArchimedesValues" {
+			errs = append(errs, field.NotSupported(refPath.Child("kind"), ref.Kind, []string{"ArchimedesValues", "ClusterArchimedesValues"}))
+		}
+		if ref.Name == "" {
+			errs = append(errs, field.Required(refPath.Child("name"), "the name of the values is required"))
+		}
+	}
+
+	if s.Library != nil {
+		libPath := path.Child("library")
+		if s.Library.RepoUrl == "" {
+			errs = append(errs, field.Required(libPath.Child("repoUrl"), "the url of the library repo is required"))
+		}
+		if s.Library.Revision == "" {
+			errs = append(errs, field.Required(libPath.Child("revision"), "the revision of the library repo is required"))
+		}
+		if len(s.Library.Paths) == 0 {
+			errs = append(errs, field.Required(libPath.Child("paths"), "at least one library path is required"))
+		}
+		for i, p := range s.Library.Paths {
+			if !insideSource(p) {
+				errs = append(errs, field.Invalid(libPath.Child("paths").Index(i), p, "must be a relative path inside the library repo"))
+			}
+		}
+	}
+
+	for i, target := range s.RolloutTargets {
+		errs = append(errs, validateRolloutTarget(path.Child("rolloutTargets").Index(i), target.Name, target.Selector)...)
+	}
+
+	if s.Rollback != nil && (s.Rollback.Index == nil) == (s.Rollback.Commit == "") {
+		errs = append(errs, field.Invalid(path.Child("rollback"), s.Rollback, "exactly one of index or commit is required"))
+	}
+	if s.Rollback != nil && s.DryRun {
+		errs = append(errs, field.Forbidden(path.Child("dryRun"), "a rollback cannot be a dry run"))
+	}
+
+	for i, window := range s.SyncWindows {
+		if schedule, err := cron.ParseStandard(window.Schedule); err != nil {
+			errs = append(errs, field.Invalid(path.Child("syncWindows").Index(i).Child("schedule"), window.Schedule, err.Error()))
+		} else if schedule.Next(time.Now()).IsZero() {
+			errs = append(errs, field.Invalid(path.Child("syncWindows").Index(i).Child("schedule"), window.Schedule, "the schedule never fires"))
+		}
+		if window.Duration.Duration <= 0 {
+			errs = append(errs, field.Invalid(path.Child("syncWindows").Index(i).Child("duration"), window.Duration.String(), "duration must be positive"))
+		}
+	}
+
+	return errs
+}
+
+// validateOutputs checks the v2 outputs after the first kept in the OutputsAnnotation. They
+// are reported at their path in the v2 spec, the first output being the configMapName,
+// propertyType and keyName of the v1 spec.
+func (r *ArchimedesProperty) validateOutputs() field.ErrorList {
+	var errs field.ErrorList
+
+	outputs, err := r.AdditionalOutputs()
+	if err != nil {
+		return append(errs, field.Invalid(field.NewPath("metadata", "annotations").Key(OutputsAnnotation), r.Annotations[OutputsAnnotation], err.Error()))
+	}
+	names := map[string]bool{r.Spec.ConfigMapName: true}
+	for i, out := range outputs {
+		path := field.NewPath("spec", "outputs").Index(i + 1)
+		if names[out.ConfigMapName] {
+			errs = append(errs, field.Duplicate(path.Child("configMapName"), out.ConfigMapName))
+		}
+		names[out.ConfigMapName] = true
+		for _, msg := range validation.IsDNS1123Subdomain(out.ConfigMapName) {
+			errs = append(errs, field.Invalid(path.Child("configMapName"), out.ConfigMapName, msg))
+		}
+		switch out.Type {
+		case "kvp":
+		case "key":
+			if out.KeyName == "" {
+				errs = append(errs, field.Required(path.Child("keyName"), "keyName is required when type is key"))
+			}
+		default:
+			errs = append(errs, field.NotSupported(path.Child("type"), out.Type, []string{"kvp", "key"}))
+		}
+		for j, target := range out.RolloutTargets {
+			errs = append(errs, validateRolloutTarget(path.Child("rolloutTargets").Index(j), target.Name, target.Selector)...)
+		}
+	}
+	return errs
+}
+
+// validateRolloutTarget checks a rollout target selects workloads by exactly one of name or selector
+func validateRolloutTarget(path *field.Path, name string, selector *metav1.LabelSelector) field.ErrorList {
+	var errs field.ErrorList
+	if (name == "") == (selector == nil) {
+		errs = append(errs, field.Invalid(path, name, "exactly one of name or selector is required"))
+	}
+	if selector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(selector); err != nil {
+			errs = append(errs, field.Invalid(path.Child("selector"), selector, err.Error()))
+		}
+	}
+	return errs
+}
+
+// validateSource checks the fields of the source type of a property are set and the fields
+// of the other source types are not
+func (s *ArchimedesPropertySpec) validateSource(path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	sourceType := s.SourceType
+	if sourceType == "" {
+		sourceType = SourceTypeGit
+	}
+	forbid := func(name string, set bool) {
+		if set {
+			errs = append(errs, field.Forbidden(path.Child(name), fmt.Sprintf("%s may not be set when sourceType is %s", name, sourceType)))
+		}
+	}
+
+	switch sourceType {
+	case SourceTypeGit:
+		if s.SourceRef != nil {
+			if s.SourceRef.Name == "" {
+				errs = append(errs, field.Required(path.Child("sourceRef", "name"), "the name of the ArchimedesSource is required"))
+			}
+			if s.RepoUrl != "" {
+				errs = append(errs, field.Forbidden(path.Child("repoUrl"), "repoUrl may not be set with sourceRef"))
+			}
+		} else if s.RepoUrl == "" {
+			errs = append(errs, field.Required(path.Child("repoUrl"), "the url of the template repo or a sourceRef is required"))
+		}
+	case SourceTypeHTTP:
+		if s.HTTP == nil || s.HTTP.Url == "" {
+			errs = append(errs, field.Required(path.Child("http", "url"), "the url of the template is required when sourceType is http"))
+		} else if u, err := url.Parse(s.HTTP.Url); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, field.Invalid(path.Child("http", "url"), s.HTTP.Url, "must be an http or https url"))
+		}
+		if s.HTTP != nil && s.HTTP.Interval != nil && s.HTTP.Interval.Duration <= 0 {
+			errs = append(errs, field.Invalid(path.Child("http", "interval"), s.HTTP.Interval.String(), "interval must be positive"))
+		}
+		forbid("includePaths", len(s.IncludePaths) > 0)
+	case SourceTypeConfigMap:
+		if s.TemplateConfigMap == nil || s.TemplateConfigMap.Name == "" {
+			errs = append(errs, field.Required(path.Child("templateConfigMap", "name"), "the name of the ConfigMap is required when sourceType is configMap"))
+		}
+	case SourceTypePath:
+		if s.LocalPath == "" {
+			errs = append(errs, field.Required(path.Child("localPath"), "the directory of the template is required when sourceType is path"))
+		} else if !filepath.IsAbs(s.LocalPath) || filepath.Clean(s.LocalPath) != s.LocalPath {
+			errs = append(errs, field.Invalid(path.Child("localPath"), s.LocalPath, "must be a clean absolute path"))
+		}
+	default:
+		errs = append(errs, field.NotSupported(path.Child("sourceType"), s.SourceType, []string{SourceTypeGit, SourceTypeHTTP, SourceTypeConfigMap, SourceTypePath}))
+		return errs
+	}
+
+	if sourceType != SourceTypeGit {
+		forbid("repoUrl", s.RepoUrl != "")
+		forbid("revision", s.Revision != "")
+		forbid("sourceRef", s.SourceRef != nil)
+	}
+	if sourceType != SourceTypeHTTP {
+		forbid("http", s.HTTP != nil)
+	}
+	if sourceType != SourceTypeConfigMap {
+		forbid("templateConfigMap", s.TemplateConfigMap != nil)
+	}
+	if sourceType != SourceTypePath {
+		forbid("localPath", s.LocalPath != "")
+	}
+	if sourceType != SourceTypeHTTP && s.PropertiesPath == "" {
+		errs = append(errs, field.Required(path.Child("propertiesPath"), "the path of the properties template is required"))
+	} else if !insideSource(s.PropertiesPath) {
+		errs = append(errs, field.Invalid(path.Child("propertiesPath"), s.PropertiesPath, "must be a relative path inside the source"))
+	}
+	for i, p := range s.IncludePaths {
+		if !insideSource(p) {
+			errs = append(errs, field.Invalid(path.Child("includePaths").Index(i), p, "must be a relative path inside the source"))
+		}
+	}
+
+	return errs
+}
+
+// insideSource reports whether a path or glob pattern of template files is relative and
+// stays inside the directory it is joined to
+func insideSource(p string) bool {
+	p = filepath.Clean(filepath.FromSlash(p))
+	return !filepath.IsAbs(p) && p != ".." && !strings.HasPrefix(p, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,235 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func validProperty() *ArchimedesProperty {
+	return &ArchimedesProperty{
+		ObjectMeta: metav1.ObjectMeta{Name: "trees-app", Namespace: "default"},
+		Spec: ArchimedesPropertySpec{
+			RepoUrl:        "https://github.com/backwoods-devops/archimedes.git",
+			PropertiesPath: "config/samples/properties.tpl",
+			SourceConfig:   "env:\n  name: staging\n",
+		},
+	}
+}
+
+func TestDefault(t *testing.T) {
+	r := validProperty()
+	r.Default()
+
+	if r.Spec.PropertyType != DefaultPropertyType {
+		t.Errorf("propertyType = %q, want %q", r.Spec.PropertyType, DefaultPropertyType)
+	}
+	if r.Spec.Revision != DefaultRevision {
+		t.Errorf("revision = %q, want %q", r.Spec.Revision, DefaultRevision)
+	}
+	if r.Spec.ConfigMapName != "trees-app" {
+		t.Errorf("configMapName = %q, want %q", r.Spec.ConfigMapName, "trees-app")
+	}
+}
+
+func TestDefaultOutputs(t *testing.T) {
+	r := validProperty()
+	r.Annotations = map[string]string{OutputsAnnotation: `[{"configMapName":"trees-file"},{"configMapName":"trees-key","type":"key","keyName":"app.properties"}]`}
+	r.Default()
+
+	outputs, err := r.AdditionalOutputs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(outputs) != 2 || outputs[0].Type != DefaultPropertyType || outputs[1].Type != "key" {
+		t.Errorf("outputs = %+v", outputs)
+	}
+}
+
+func TestValidateCreate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(r *ArchimedesProperty)
+		wantErr bool
+	}{
+		{name: "valid", mutate: func(r *ArchimedesProperty) {}},
+		{name: "missing repoUrl", mutate: func(r *ArchimedesProperty) { r.Spec.RepoUrl = "" }, wantErr: true},
+		{name: "sourceRef", mutate: func(r *ArchimedesProperty) {
+			r.Spec.RepoUrl = ""
+			r.Spec.SourceRef = &SourceReference{Name: "app"}
+		}},
+		{name: "sourceRef with repoUrl", mutate: func(r *ArchimedesProperty) {
+			r.Spec.SourceRef = &SourceReference{Name: "app"}
+		}, wantErr: true},
+		{name: "valuesFrom without name", mutate: func(r *ArchimedesProperty) {
+			r.Spec.ValuesFrom = []ValuesReference{{Kind: "ClusterArchimedesValues"}}
+		}, wantErr: true},
+		{name: "invalid propertyType", mutate: func(r *ArchimedesProperty) { r.Spec.PropertyType = "file" }, wantErr: true},
+		{name: "key without keyName", mutate: func(r *ArchimedesProperty) { r.Spec.PropertyType = "key" }, wantErr: true},
+		{name: "invalid configMapName", mutate: func(r *ArchimedesProperty) { r.Spec.ConfigMapName = "Trees_App" }, wantErr: true},
+		{name: "invalid sourceConfig", mutate: func(r *ArchimedesProperty) { r.Spec.SourceConfig = "env: [" }, wantErr: true},
+		{name: "invalid sync window", mutate: func(r *ArchimedesProperty) {
+			r.Spec.SyncWindows = []SyncWindow{{Kind: "deny", Schedule: "every day"}}
+		}, wantErr: true},
+		{name: "http", mutate: func(r *ArchimedesProperty) {
+			r.Spec.SourceType, r.Spec.RepoUrl, r.Spec.Revision, r.Spec.PropertiesPath = SourceTypeHTTP, "", "", ""
+			r.Spec.HTTP = &HTTPSource{Url: "https://templates.backwoods-devops.io/trees-app.tpl"}
+		}},
+		{name: "http without url", mutate: func(r *ArchimedesProperty) {
+			r.Spec.SourceType, r.Spec.RepoUrl, r.Spec.Revision = SourceTypeHTTP, "", ""
+		}, wantErr: true},
+		{name: "http with repoUrl", mutate: func(r *ArchimedesProperty) {
+			r.Spec.SourceType, r.Spec.Revision = SourceTypeHTTP, ""
+			r.Spec.HTTP = &HTTPSource{Url: "https://templates.backwoods-devops.io/trees-app.tpl"}
+		}, wantErr: true},
+		{name: "http with invalid url", mutate: func(r *ArchimedesProperty) {
+			r.Spec.SourceType, r.Spec.RepoUrl, r.Spec.Revision = SourceTypeHTTP, "", ""
+			r.Spec.HTTP = &HTTPSource{Url: "ftp://templates.backwoods-devops.io/trees-app.tpl"}
+		}, wantErr: true},
+		{name: "configMap", mutate: func(r *ArchimedesProperty) {
+			r.Spec.SourceType, r.Spec.RepoUrl, r.Spec.Revision = SourceTypeConfigMap, "", ""
+			r.Spec.TemplateConfigMap = &ConfigMapSource{Name: "trees-app-template"}
+		}},
+		{name: "configMap without name", mutate: func(r *ArchimedesProperty) {
+			r.Spec.SourceType, r.Spec.RepoUrl, r.Spec.Revision = SourceTypeConfigMap, "", ""
+		}, wantErr: true},
+		{name: "path", mutate: func(r *ArchimedesProperty) {
+			r.Spec.SourceType, r.Spec.RepoUrl, r.Spec.Revision = SourceTypePath, "", ""
+			r.Spec.LocalPath = "/templates/trees-app"
+		}},
+		{name: "relative path", mutate: func(r *ArchimedesProperty) {
+			r.Spec.SourceType, r.Spec.RepoUrl, r.Spec.Revision = SourceTypePath, "", ""
+			r.Spec.LocalPath = "templates/../trees-app"
+		}, wantErr: true},
+		{name: "propertiesPath outside of the source", mutate: func(r *ArchimedesProperty) {
+			r.Spec.SourceType, r.Spec.RepoUrl, r.Spec.Revision = SourceTypePath, "", ""
+			r.Spec.LocalPath = "/templates/trees-app"
+			r.Spec.PropertiesPath = "../../var/run/secrets/kubernetes.io/serviceaccount/token"
+		}, wantErr: true},
+		{name: "absolute propertiesPath", mutate: func(r *ArchimedesProperty) { r.Spec.PropertiesPath = "/etc/passwd" }, wantErr: true},
+		{name: "propertiesPath with dots", mutate: func(r *ArchimedesProperty) { r.Spec.PropertiesPath = "config/..properties.tpl" }},
+		{name: "includePaths", mutate: func(r *ArchimedesProperty) { r.Spec.IncludePaths = []string{"config/partials/*.tpl"} }},
+		{name: "includePaths outside of the source", mutate: func(r *ArchimedesProperty) {
+			r.Spec.IncludePaths = []string{"config/partials/*.tpl", "config/../../*"}
+		}, wantErr: true},
+		{name: "absolute includePaths", mutate: func(r *ArchimedesProperty) { r.Spec.IncludePaths = []string{"/var/run/secrets/*/*"} }, wantErr: true},
+		{name: "library", mutate: func(r *ArchimedesProperty) {
+			r.Spec.Library = &TemplateLibrary{RepoUrl: "https://github.com/backwoods-devops/templates.git", Revision: "main", Paths: []string{"partials/*.tpl"}}
+		}},
+		{name: "library paths outside of the repo", mutate: func(r *ArchimedesProperty) {
+			r.Spec.Library = &TemplateLibrary{RepoUrl: "https://github.com/backwoods-devops/templates.git", Revision: "main", Paths: []string{"../*"}}
+		}, wantErr: true},
+		{name: "absolute library paths", mutate: func(r *ArchimedesProperty) {
+			r.Spec.Library = &TemplateLibrary{RepoUrl: "https://github.com/backwoods-devops/templates.git", Revision: "main", Paths: []string{"/etc/*"}}
+		}, wantErr: true},
+		{name: "localPath with git", mutate: func(r *ArchimedesProperty) { r.Spec.LocalPath = "/templates/trees-app" }, wantErr: true},
+		{name: "sync window never fires", mutate: func(r *ArchimedesProperty) {
+			r.Spec.SyncWindows = []SyncWindow{{Kind: "allow", Schedule: "0 0 30 2 *", Duration: metav1.Duration{Duration: time.Hour}}}
+		}, wantErr: true},
+		{name: "outputs", mutate: func(r *ArchimedesProperty) {
+			r.Annotations = map[string]string{OutputsAnnotation: `[{"configMapName":"trees-file","type":"key","keyName":"app.properties"}]`}
+		}},
+		{name: "invalid outputs", mutate: func(r *ArchimedesProperty) {
+			r.Annotations = map[string]string{OutputsAnnotation: `{"configMapName":"trees-file"}`}
+		}, wantErr: true},
+		{name: "output without keyName", mutate: func(r *ArchimedesProperty) {
+			r.Annotations = map[string]string{OutputsAnnotation: `[{"configMapName":"trees-file","type":"key"}]`}
+		}, wantErr: true},
+		{name: "output with the configMapName of the first", mutate: func(r *ArchimedesProperty) {
+			r.Annotations = map[string]string{OutputsAnnotation: `[{"configMapName":"trees-app","type":"kvp"}]`}
+		}, wantErr: true},
+		{name: "outputs with the same configMapName", mutate: func(r *ArchimedesProperty) {
+			r.Annotations = map[string]string{OutputsAnnotation: `[{"configMapName":"trees-file","type":"kvp"},{"configMapName":"trees-file","type":"kvp"}]`}
+		}, wantErr: true},
+		{name: "output without configMapName", mutate: func(r *ArchimedesProperty) {
+			r.Annotations = map[string]string{OutputsAnnotation: `[{"type":"kvp"}]`}
+		}, wantErr: true},
+		{name: "output rollout target without name", mutate: func(r *ArchimedesProperty) {
+			r.Annotations = map[string]string{OutputsAnnotation: `[{"configMapName":"trees-file","type":"kvp","rolloutTargets":[{"kind":"Deployment"}]}]`}
+		}, wantErr: true},
+		{name: "dry run rollback", mutate: func(r *ArchimedesProperty) {
+			index := int32(0)
+			r.Spec.DryRun = true
+			r.Spec.Rollback = &Rollback{Index: &index}
+		}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := validProperty()
+			r.Default()
+			tt.mutate(r)
+			err := r.ValidateCreate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCreate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPropertyWebhookHandle(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	decoder, err := admission.NewDecoder(scheme)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name                 string
+		validateSourceConfig bool
+		operation            admissionv1.Operation
+		sourceConfig         string
+		repoUrl              string
+		want                 bool
+	}{
+		{name: "valid", validateSourceConfig: true, operation: admissionv1.Create, sourceConfig: "env: dev", repoUrl: "https://github.com/backwoods-devops/archimedes.git", want: true},
+		{name: "invalid sourceConfig", validateSourceConfig: true, operation: admissionv1.Create, sourceConfig: "env: [", repoUrl: "https://github.com/backwoods-devops/archimedes.git"},
+		{name: "sourceConfig not validated", operation: admissionv1.Update, sourceConfig: "env: [", repoUrl: "https://github.com/backwoods-devops/archimedes.git", want: true},
+		{name: "invalid spec", operation: admissionv1.Create},
+		{name: "delete", operation: admissionv1.Delete, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := validProperty()
+			r.TypeMeta = metav1.TypeMeta{APIVersion: GroupVersion.String(), Kind: "ArchimedesProperty"}
+			r.Spec.SourceConfig, r.Spec.RepoUrl = tt.sourceConfig, tt.repoUrl
+			r.Default()
+			raw, err := json.Marshal(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			w := &PropertyWebhook{ValidateSourceConfig: tt.validateSourceConfig}
+			if err := w.InjectDecoder(decoder); err != nil {
+				t.Fatal(err)
+			}
+			req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{Operation: tt.operation}}
+			req.Object.Raw = raw
+			if got := w.Handle(context.Background(), req); got.Allowed != tt.want {
+				t.Errorf("Handle() allowed = %v, want %v: %v", got.Allowed, tt.want, got.Result)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ArchimedesPropertySetSpec defines the desired state of ArchimedesPropertySet
+type ArchimedesPropertySetSpec struct {
+	//Generators produce the items a property is generated for, the items of all
+	//generators are combined
+	// +kubebuilder:validation:MinItems=1
+	Generators []PropertySetGenerator `json:"generators"`
+	//Template is the ArchimedesProperty generated for every item
+	Template PropertySetTemplate `json:"template"`
+}
+
+// PropertySetGenerator produces items from exactly one of its generators
+type PropertySetGenerator struct {
+	//List generates an item for every element
+	List *ListGenerator `json:"list,omitempty"`
+	//Namespaces generates an item for every namespace matching a selector
+	Namespaces *NamespaceGenerator `json:"namespaces,omitempty"`
+	//Matrix generates an item for every combination of the items of its generators
+	Matrix *MatrixGenerator `json:"matrix,omitempty"`
+}
+
+// NestedGenerator is a generator combined by a MatrixGenerator
+type NestedGenerator struct {
+	//List generates an item for every element
+	List *ListGenerator `json:"list,omitempty"`
+	//Namespaces generates an item for every namespace matching a selector
+	Namespaces *NamespaceGenerator `json:"namespaces,omitempty"`
+}
+
+// ListGenerator generates an item for every element
+type ListGenerator struct {
+	//Elements are the items generated
+	Elements []PropertySetElement `json:"elements"`
+}
+
+// PropertySetElement is an item of a ListGenerator
+type PropertySetElement struct {
+	//Namespace the property is generated in, required unless another generator of a
+	//matrix sets it
+	Namespace string `json:"namespace,omitempty"`
+	//Name of the generated property, defaults to the name of the template
+	Name string `json:"name,omitempty"`
+	//Values is yaml merged over the sourceConfig of the template
+	Values string `json:"values,omitempty"`
+}
+
+// NamespaceGenerator generates an item for every namespace matching a selector
+type NamespaceGenerator struct {
+	//Selector matches the labels of the namespaces
+	Selector metav1.LabelSelector `json:"selector"`
+	//Values is yaml merged over the sourceConfig of the template for every namespace
+	Values string `json:"values,omitempty"`
+}
+
+// MatrixGenerator generates an item for every combination of the items of its generators.
+// Later generators set the namespace and name of the combined item when they have one
+// and their values are merged over the values of earlier generators
+type MatrixGenerator struct {
+	// +kubebuilder:validation:MinItems=2
+	Generators []NestedGenerator `json:"generators"`
+}
+
+// PropertySetTemplate is the ArchimedesProperty generated for every item of a set
+type PropertySetTemplate struct {
+	//Metadata of the generated properties
+	Metadata PropertySetTemplateMeta `json:"metadata,omitempty"`
+	//Spec of the generated properties, the values of the item are merged over its sourceConfig
+	Spec ArchimedesPropertySpec `json:"spec"`
+}
+
+// PropertySetTemplateMeta is the metadata of the properties generated by a set
+type PropertySetTemplateMeta struct {
+	//Name of the generated properties, defaults to the name of the set
+	Name string `json:"name,omitempty"`
+	//Labels of the generated properties
+	Labels map[string]string `json:"labels,omitempty"`
+	//Annotations of the generated properties
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// ArchimedesPropertySetStatus defines the observed state of ArchimedesPropertySet
+type ArchimedesPropertySetStatus struct {
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	//Properties are the generated properties as namespace/name
+	Properties []string `json:"properties,omitempty"`
+}
+
+//+genclient
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Cluster
+// ArchimedesPropertySet is the Schema for the archimedespropertysets API
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`,description="Indicates if every property was generated"
+// +kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].reason`,description="Reason for the current status"
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`,description="Time when this set was created"
+
+type ArchimedesPropertySet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ArchimedesPropertySetSpec   `json:"spec,omitempty"`
+	Status ArchimedesPropertySetStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+// ArchimedesPropertySetList contains a list of ArchimedesPropertySet
+type ArchimedesPropertySetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ArchimedesPropertySet `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ArchimedesPropertySet{}, &ArchimedesPropertySetList{})
+}
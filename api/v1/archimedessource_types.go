@@ -0,0 +1,105 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ArchimedesSourceSpec defines the desired state of ArchimedesSource
+type ArchimedesSourceSpec struct {
+	//RepoUrl is the url of the repo
+	// +kubebuilder:validation:MinLength=1
+	RepoUrl string `json:"repoUrl"`
+	//Revision is the branch of the repo, defaults to main
+	// +kubebuilder:default=main
+	Revision string `json:"revision,omitempty"`
+	//CAPath is the path to a CA certificate for the repo
+	CAPath string `json:"caPath,omitempty"`
+	//SecretRef names a Secret in the namespace of the source with the username and password
+	//keys used to authenticate with the repo. When not set the USER and PASS environment
+	//variables of the operator are used
+	SecretRef *SecretReference `json:"secretRef,omitempty"`
+	//Interval is how often the repo is polled for new commits, defaults to 5m
+	// +kubebuilder:default="5m"
+	Interval metav1.Duration `json:"interval,omitempty"`
+}
+
+// SecretReference names a Secret in the same namespace
+type SecretReference struct {
+	//Name of the Secret
+	Name string `json:"name"`
+}
+
+// SourceReference names an ArchimedesSource in the same namespace
+type SourceReference struct {
+	//Name of the ArchimedesSource
+	Name string `json:"name"`
+}
+
+// SourceCommit describes the commit fetched by an ArchimedesSource
+type SourceCommit struct {
+	//Hash of the commit
+	Hash string `json:"hash"`
+	//Author of the commit
+	Author string `json:"author,omitempty"`
+	//Message of the commit
+	Message string `json:"message,omitempty"`
+	//Timestamp is the commit time
+	Timestamp metav1.Time `json:"timestamp,omitempty"`
+	//Tag pointing at the commit, if any
+	Tag string `json:"tag,omitempty"`
+}
+
+// ArchimedesSourceStatus defines the observed state of ArchimedesSource
+type ArchimedesSourceStatus struct {
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	//Commit is the commit last fetched from the revision
+	Commit *SourceCommit `json:"commit,omitempty"`
+	//LastFetchTime is when the repo was last fetched successfully
+	LastFetchTime *metav1.Time `json:"lastFetchTime,omitempty"`
+}
+
+//+genclient
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+// ArchimedesSource is the Schema for the archimedessources API
+// +kubebuilder:printcolumn:name="Url",type=string,JSONPath=`.spec.repoUrl`,description="Url of the repo"
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`,description="Indicates if the last fetch succeeded"
+// +kubebuilder:printcolumn:name="Commit",type=string,JSONPath=`.status.commit.hash`,description="Commit last fetched"
+// +kubebuilder:printcolumn:name="Last Fetch",type=date,JSONPath=`.status.lastFetchTime`,description="Time of the last successful fetch"
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`,description="Time when this source was created"
+
+type ArchimedesSource struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ArchimedesSourceSpec   `json:"spec,omitempty"`
+	Status ArchimedesSourceStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+// ArchimedesSourceList contains a list of ArchimedesSource
+type ArchimedesSourceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ArchimedesSource `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ArchimedesSource{}, &ArchimedesSourceList{})
+}
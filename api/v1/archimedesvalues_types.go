@@ -0,0 +1,56 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ArchimedesValuesSpec defines the desired state of ArchimedesValues
+type ArchimedesValuesSpec struct {
+	//Values is yaml merged into the sourceConfig of the properties using these values
+	Values string `json:"values,omitempty"`
+	//PropertySelector applies the values to every property in the namespace with matching
+	//labels. When not set the values only apply to properties referencing them in valuesFrom
+	PropertySelector *metav1.LabelSelector `json:"propertySelector,omitempty"`
+}
+
+//+genclient
+//+resourceName=archimedesvalues
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:path=archimedesvalues
+// ArchimedesValues is the Schema for the archimedesvalues API
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`,description="Time when these values were created"
+
+type ArchimedesValues struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ArchimedesValuesSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+// ArchimedesValuesList contains a list of ArchimedesValues
+type ArchimedesValuesList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ArchimedesValues `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ArchimedesValues{}, &ArchimedesValuesList{})
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterArchimedesValuesSpec defines the desired state of ClusterArchimedesValues
+type ClusterArchimedesValuesSpec struct {
+	//Values is yaml merged into the sourceConfig of the properties using these values
+	Values string `json:"values,omitempty"`
+	//NamespaceSelector applies the values to every property in the namespaces with matching
+	//labels. When not set the values only apply to properties referencing them in valuesFrom
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+}
+
+//+genclient
+//+resourceName=clusterarchimedesvalues
+//+genclient:nonNamespaced
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster,path=clusterarchimedesvalues
+// ClusterArchimedesValues is the Schema for the clusterarchimedesvalues API
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`,description="Time when these values were created"
+
+type ClusterArchimedesValues struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ClusterArchimedesValuesSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+// ClusterArchimedesValuesList contains a list of ClusterArchimedesValues
+type ClusterArchimedesValuesList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterArchimedesValues `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterArchimedesValues{}, &ClusterArchimedesValuesList{})
+}
@@ -0,0 +1,321 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ArchimedesPropertySpec defines the desired state of ArchimedesProperty
+type ArchimedesPropertySpec struct {
+	//Source is the repo the properties template is fetched from
+	Source PropertySource `json:"source,omitempty"`
+	//Template locates the properties template and the templates it uses
+	Template PropertyTemplate `json:"template,omitempty"`
+	//Values are merged with the properties template
+	Values PropertyValues `json:"values,omitempty"`
+	//Outputs are the ConfigMaps the merged results are written to, each formatted from the
+	//same render of the template. Approval, dry runs, history and rollback apply to the
+	//first output, the others are written whenever the first is
+	// +kubebuilder:validation:MinItems=1
+	Outputs []PropertyOutput `json:"outputs"`
+	//Sync controls when and how changes are applied
+	Sync PropertySync `json:"sync,omitempty"`
+}
+
+// PropertySource defines where the properties template is fetched from
+type PropertySource struct {
+	//Type selects where the template is fetched from: git clones repoUrl or the repo of
+	//sourceRef, http downloads http.url, configMap reads configMap and path reads path,
+	//defaults to git
+	// +kubebuilder:validation:Enum=git;http;configMap;path
+	Type string `json:"type,omitempty"`
+	//RepoUrl is the application repo url
+	RepoUrl string `json:"repoUrl,omitempty"`
+	//Revision is the branch of the repo
+	Revision string `json:"revision,omitempty"`
+	//CAPath is the path to a CA certificate for the repo
+	CAPath string `json:"caPath,omitempty"`
+	//SourceRef names an ArchimedesSource in the same namespace to fetch the template from
+	//instead of repoUrl, revision and caPath
+	SourceRef *SourceReference `json:"sourceRef,omitempty"`
+	//HTTP is the url the template is downloaded from when type is http
+	HTTP *HTTPSource `json:"http,omitempty"`
+	//ConfigMap names the ConfigMap in the same namespace holding the template when type is
+	//configMap, the template path and includePaths select its keys
+	ConfigMap *ConfigMapSource `json:"configMap,omitempty"`
+	//Path is a directory on a volume mounted in the operator holding the template when type
+	//is path, the template path and includePaths are relative to it
+	//example: /templates/trees
+	Path string `json:"path,omitempty"`
+}
+
+// SourceReference names an ArchimedesSource in the same namespace
+type SourceReference struct {
+	//Name of the ArchimedesSource
+	Name string `json:"name"`
+}
+
+// HTTPSource defines a url a properties template is downloaded from
+type HTTPSource struct {
+	//Url of the properties template
+	// +kubebuilder:validation:Pattern=`^https?://`
+	Url string `json:"url"`
+	//CAPath is the path to a CA certificate for the server
+	CAPath string `json:"caPath,omitempty"`
+	//Interval is how often the url is polled for a new template, it is only fetched when
+	//requested otherwise. The ETag and Last-Modified headers of the server avoid downloading
+	//an unchanged template
+	Interval *metav1.Duration `json:"interval,omitempty"`
+}
+
+// ConfigMapSource names a ConfigMap in the same namespace holding templates
+type ConfigMapSource struct {
+	//Name of the ConfigMap
+	Name string `json:"name"`
+}
+
+// PropertyTemplate locates the properties template and the templates it uses
+type PropertyTemplate struct {
+	//Path is the path to the applications properties template
+	//example: config/properties.tpl
+	Path string `json:"path,omitempty"`
+	//IncludePaths are glob patterns of additional template files in the repo
+	//loaded alongside the properties template
+	//example: config/partials/*.tpl
+	IncludePaths []string `json:"includePaths,omitempty"`
+	//Library is an optional repo contributing shared named templates
+	Library *TemplateLibrary `json:"library,omitempty"`
+}
+
+// PropertyValues defines the data merged with the properties template
+type PropertyValues struct {
+	//Inline is yaml containing data to be merged with the properties template
+	Inline string `json:"inline,omitempty"`
+	//From are ArchimedesValues and ClusterArchimedesValues merged under inline, in order,
+	//after the values inherited by selector
+	From []ValuesReference `json:"from,omitempty"`
+}
+
+// ValuesReference names an ArchimedesValues in the same namespace or a ClusterArchimedesValues
+type ValuesReference struct {
+	//Kind is ArchimedesValues or ClusterArchimedesValues
+	// +kubebuilder:validation:Enum=ArchimedesValues;ClusterArchimedesValues
+	Kind string `json:"kind"`
+	//Name of the values
+	Name string `json:"name"`
+}
+
+// PropertyOutput defines a ConfigMap the merged results are written to
+type PropertyOutput struct {
+	//ConfigMapName is the name of the config map to be created
+	ConfigMapName string `json:"configMapName,omitempty"`
+	//Type is the format the merged results are stored as (kvp or key)
+	// +kubebuilder:validation:Enum=kvp;key
+	Type string `json:"type,omitempty"`
+	//KeyName is the name of the key used if the Type is key
+	KeyName string `json:"keyName,omitempty"`
+	//Provenance controls where the commit, repoUrl, revision and path of the template are recorded
+	Provenance *Provenance `json:"provenance,omitempty"`
+	//Immutable creates a new immutable ConfigMap named <configMapName>-<hash> for every
+	//change in content instead of updating a single ConfigMap
+	Immutable bool `json:"immutable,omitempty"`
+	//RetainVersions is the number of previous immutable ConfigMaps kept for rollback, defaults to 3
+	// +kubebuilder:validation:Minimum=0
+	RetainVersions *int32 `json:"retainVersions,omitempty"`
+	//RolloutTargets are workloads restarted when the content of the ConfigMap changes
+	RolloutTargets []RolloutTarget `json:"rolloutTargets,omitempty"`
+}
+
+// PropertySync controls when and how changes are applied
+type PropertySync struct {
+	//Suspend stops all reconciliation of the property while true
+	Suspend bool `json:"suspend,omitempty"`
+	//ApprovalPolicy is Automatic to apply new commits as they are fetched, or Manual to hold
+	//them until the archimedes.backwoods-devops.io/approved-commit annotation is set to the
+	//pending commit, defaults to Automatic
+	// +kubebuilder:validation:Enum=Automatic;Manual
+	ApprovalPolicy string `json:"approvalPolicy,omitempty"`
+	//Windows limit when changes may be written to the ConfigMap, in addition to the
+	//windows configured for the operator
+	Windows []SyncWindow `json:"windows,omitempty"`
+	//HistoryLimit is the number of successful renders kept in status.history, defaults to 10
+	// +kubebuilder:validation:Minimum=1
+	HistoryLimit *int32 `json:"historyLimit,omitempty"`
+	//Rollback restores the data of an entry in status.history instead of rendering the revision
+	Rollback *Rollback `json:"rollback,omitempty"`
+	//DryRun renders the property and records the keys it would add, remove and change in
+	//status.pendingChange without writing the ConfigMap
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// TemplateLibrary defines a repo of shared templates made available to the properties template
+type TemplateLibrary struct {
+	//RepoUrl is the library repo url
+	RepoUrl string `json:"repoUrl"`
+	//Revision is the branch of the library repo
+	Revision string `json:"revision"`
+	//CAPath is the path to a CA certificate for the library repo
+	CAPath string `json:"caPath,omitempty"`
+	//Paths are glob patterns of the template files to load from the library repo
+	//example: templates/*.tpl
+	Paths []string `json:"paths"`
+}
+
+// Provenance defines where the origin of the template is recorded on the ConfigMap
+type Provenance struct {
+	//Placement is where the provenance keys are written (data or annotations), defaults to data.
+	//In annotations the keys are prefixed with archimedes.backwoods-devops.io/
+	// +kubebuilder:validation:Enum=data;annotations
+	Placement string `json:"placement,omitempty"`
+	//KeyPrefix is prepended to the provenance keys when they are placed in data
+	//example: archimedes.
+	KeyPrefix string `json:"keyPrefix,omitempty"`
+}
+
+// RolloutTarget selects workloads in the namespace of the property consuming its ConfigMap
+type RolloutTarget struct {
+	//Kind of the workload (Deployment, StatefulSet or DaemonSet)
+	// +kubebuilder:validation:Enum=Deployment;StatefulSet;DaemonSet
+	Kind string `json:"kind"`
+	//Name of the workload, either name or selector is required
+	Name string `json:"name,omitempty"`
+	//Selector matches workloads of the kind by label
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+// Rollback selects the entry of status.history to restore, by index or by commit
+type Rollback struct {
+	//Index of the entry in status.history, 0 being the most recent render
+	// +kubebuilder:validation:Minimum=0
+	Index *int32 `json:"index,omitempty"`
+	//Commit of the entry in status.history, the most recent render of the commit is used.
+	//An abbreviated hash may be given
+	Commit string `json:"commit,omitempty"`
+}
+
+// RenderHistoryEntry records a successful render of a property
+type RenderHistoryEntry struct {
+	//Commit is the hash of the commit the template was fetched from
+	Commit string `json:"commit"`
+	//ValuesHash is the hash of the values the template was rendered with
+	ValuesHash string `json:"valuesHash"`
+	//DataHash is the hash of the rendered data
+	DataHash string `json:"dataHash"`
+	//Timestamp is the time of the render
+	Timestamp metav1.Time `json:"timestamp"`
+	//Snapshot is the name of the ConfigMap holding the rendered data
+	Snapshot string `json:"snapshot"`
+}
+
+// SyncWindow is a recurring period during which changes to a ConfigMap are allowed or denied.
+// When any deny window is open changes are held, and when allow windows are defined changes
+// are only written while one of them is open
+type SyncWindow struct {
+	//Kind is allow or deny
+	// +kubebuilder:validation:Enum=allow;deny
+	Kind string `json:"kind"`
+	//Schedule is a cron expression for when the window opens, a CRON_TZ=<zone> prefix sets the time zone
+	//example: 0 9 * * 1-5
+	Schedule string `json:"schedule"`
+	//Duration is how long the window stays open
+	//example: 8h
+	Duration metav1.Duration `json:"duration"`
+}
+
+// PendingChange describes a render waiting for approval or a sync window. Only the names
+// of the keys are recorded so values are never exposed in status
+type PendingChange struct {
+	//Commit is the hash of the commit the pending change was rendered from
+	Commit string `json:"commit"`
+	//Added are the keys the commit adds to the ConfigMap
+	Added []string `json:"added,omitempty"`
+	//Removed are the keys the commit removes from the ConfigMap
+	Removed []string `json:"removed,omitempty"`
+	//Changed are the keys whose values the commit changes
+	Changed []string `json:"changed,omitempty"`
+}
+
+// ArchimedesPropertyStatus defines the observed state of ArchimedesProperty
+type ArchimedesPropertyStatus struct {
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	//ContentHash is the hash of the data last written to the ConfigMap
+	ContentHash string `json:"contentHash,omitempty"`
+	//RestartedWorkloads are the rollout targets restarted when the content last changed
+	RestartedWorkloads []string `json:"restartedWorkloads,omitempty"`
+	//ConfigMapName is the name of the ConfigMap currently holding the properties
+	ConfigMapName string `json:"configMapName,omitempty"`
+	//History lists the most recent successful renders, newest first
+	History []RenderHistoryEntry `json:"history,omitempty"`
+	//LastHandledReconcileAt is the value of the reconcile.archimedes.backwoods-devops.io/requestedAt
+	//annotation last handled by the controller
+	LastHandledReconcileAt string `json:"lastHandledReconcileAt,omitempty"`
+	//Commit is the hash of the commit the applied properties were rendered from
+	Commit string `json:"commit,omitempty"`
+	//PendingChange is the render waiting for approval or a sync window
+	PendingChange *PendingChange `json:"pendingChange,omitempty"`
+	//NextSyncWindow is when the pending change can be written at the earliest
+	NextSyncWindow *metav1.Time `json:"nextSyncWindow,omitempty"`
+	//Values lists the ArchimedesValues and ClusterArchimedesValues merged into the
+	//values of the last render, in the order they were merged
+	Values []string `json:"values,omitempty"`
+	//Outputs are the status of the outputs after the first, in the order of spec.outputs
+	Outputs []OutputStatus `json:"outputs,omitempty"`
+}
+
+// OutputStatus is the observed state of an output after the first
+type OutputStatus struct {
+	//Name is the configMapName of the output
+	Name string `json:"name"`
+	//ContentHash is the hash of the data last written to the ConfigMap of the output
+	ContentHash string `json:"contentHash,omitempty"`
+	//RestartedWorkloads are the rollout targets of the output restarted when the content last changed
+	RestartedWorkloads []string `json:"restartedWorkloads,omitempty"`
+	//ConfigMapName is the name of the ConfigMap currently holding the output
+	ConfigMapName string `json:"configMapName,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+// ArchimedesProperty is the Schema for the archimedesproperties API
+// +kubebuilder:printcolumn:name="Succeeded",type=string,JSONPath=`.status.conditions[?(@.type=="ConfigmapCreated")].status`,description="Indicates if the ConfigMap was created/updated successfully"
+// +kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.conditions[?(@.type=="ConfigmapCreated")].reason`,description="Reason for the current status"
+// +kubebuilder:printcolumn:name="Message",type=string,JSONPath=`.status.conditions[?(@.type=="ConfigmapCreated")].message`,description="Message with more information, regarding the current status"
+// +kubebuilder:printcolumn:name="Suspended",type=boolean,JSONPath=`.spec.sync.suspend`,description="Indicates if reconciliation of the property is suspended"
+// +kubebuilder:printcolumn:name="Last Transition",type=date,JSONPath=`.status.conditions[?(@.type=="ConfigmapCreated")].lastTransitionTime`,description="Time when the condition was updated the last time"
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`,description="Time when this ConfigMap was created"
+
+type ArchimedesProperty struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ArchimedesPropertySpec   `json:"spec,omitempty"`
+	Status ArchimedesPropertyStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+// ArchimedesPropertyList contains a list of ArchimedesProperty
+type ArchimedesPropertyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ArchimedesProperty `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ArchimedesProperty{}, &ArchimedesPropertyList{})
+}
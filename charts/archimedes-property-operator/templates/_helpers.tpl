@@ -102,3 +102,40 @@ Additional containers to add to the deployment
 */}}
 {{- define "archimedes-property-operator.additionalContainers" -}}
 {{- end -}}
+
+{{/*
+A CRD from the crd directory, kept on uninstall. The ArchimedesProperty CRD serves v2 through
+the conversion webhook when the webhooks are enabled, and only v1 otherwise.
+Takes a dict with root, file and caBundle.
+*/}}
+{{- define "archimedes-property-operator.crd" -}}
+{{- $root := .root }}
+{{- $crd := $root.Files.Get .file | fromYaml }}
+{{- $annotations := $crd.metadata.annotations | default dict }}
+{{- $_ := set $annotations "helm.sh/resource-policy" "keep" }}
+{{- if eq $crd.metadata.name "archimedesproperties.archimedes.backwoods-devops.io" }}
+{{- if $root.Values.webhooks.enabled }}
+{{- $fullname := include "archimedes-property-operator.fullname" $root }}
+{{- $clientConfig := dict "service" (dict "name" (printf "%s-webhook" $fullname) "namespace" $root.Release.Namespace "path" "/convert") }}
+{{- with .caBundle }}
+{{- $_ := set $clientConfig "caBundle" . }}
+{{- end }}
+{{- if $root.Values.webhooks.certManager.enabled }}
+{{- $_ := set $annotations "cert-manager.io/inject-ca-from" (printf "%s/%s-webhook" $root.Release.Namespace $fullname) }}
+{{- end }}
+{{- $_ := set $crd.spec "conversion" (dict "strategy" "Webhook" "webhook" (dict "clientConfig" $clientConfig "conversionReviewVersions" (list "v1"))) }}
+{{- else }}
+{{- $versions := list }}
+{{- range $crd.spec.versions }}
+{{- if eq .name "v1" }}
+{{- $_ := set . "storage" true }}
+{{- $versions = append $versions . }}
+{{- end }}
+{{- end }}
+{{- $_ := set $crd.spec "versions" $versions }}
+{{- end }}
+{{- end }}
+{{- $_ := set $crd.metadata "annotations" $annotations }}
+{{- $_ := unset $crd "status" }}
+{{- toYaml $crd }}
+{{- end }}
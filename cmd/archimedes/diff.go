@@ -0,0 +1,293 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	backwoodsv1 "github.com/backwoods-devops/archimedes/api/v1"
+	"github.com/backwoods-devops/archimedes/pkg/render"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// errChanged is returned by diff when the property changes its ConfigMap, so the
+// command exits with status 1 like diff and kubectl diff
+var errChanged = errors.New("the ConfigMap changes")
+
+const (
+	// dryRunPollInterval is how often a server-side dry run is checked for its result
+	dryRunPollInterval = time.Second
+
+	// The operator reports the result of a dry run with these conditions, a source that is
+	// not ready yet is waited for
+	conditionTypeDryRun           = "DryRun"
+	conditionTypeConfigmapCreated = "ConfigmapCreated"
+	conditionReasonSourceNotReady = "SourceNotReady"
+
+	// redacted replaces values in the output of diff with -redact
+	redacted = "(redacted)"
+
+	// dryRunLabel marks the dry run copies of properties created by diff -server
+	dryRunLabel = "archimedes.backwoods-devops.io/dry-run"
+)
+
+func runDiff(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("diff", flag.ContinueOnError)
+	f := &renderFlags{}
+	f.bind(fs)
+	live := fs.String("live", "", "A ConfigMap manifest to compare with instead of the live ConfigMap in the cluster.")
+	redact := fs.Bool("redact", false, "Only show the keys that change, never their values.")
+	server := fs.Bool("server", false, "Render with the operator as a dry run instead of locally, only keys are shown.")
+	timeout := fs.Duration("timeout", 2*time.Minute, "How long to wait for the result of a server-side dry run.")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: archimedes diff [flags]\n\n"+
+			"Renders a proposed property and compares the result with its live ConfigMap. Exits with status 1 when the ConfigMap changes.\n\n")
+		fs.PrintDefaults()
+	}
+	err := fs.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	r, err := f.property()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	if *server {
+		c, err := clusterClient()
+		if err != nil {
+			return err
+		}
+		// an interrupted dry run still deletes its copy
+		ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		pending, err := serverDryRun(ctx, c, r, *timeout)
+		if err != nil {
+			return err
+		}
+		diff := render.Diff{Added: pending.Added, Removed: pending.Removed, Changed: pending.Changed}
+		return printDiff(stdout, fmt.Sprintf("%s/%s", r.Namespace, r.Spec.ConfigMapName), diff, nil, nil, true)
+	}
+
+	configmap, err := f.renderProperty(ctx, r)
+	if err != nil {
+		return err
+	}
+	var current *corev1.ConfigMap
+	if *live != "" {
+		current, err = readConfigMap(*live)
+	} else {
+		current, err = liveConfigMap(ctx, r)
+	}
+	if err != nil {
+		return err
+	}
+	diff := render.DiffData(current.Data, configmap.Data)
+	return printDiff(stdout, fmt.Sprintf("%s/%s", current.Namespace, current.Name), diff, current.Data, configmap.Data, *redact)
+}
+
+// clusterClient returns a client for the cluster of the current kubeconfig
+func clusterClient() (client.Client, error) {
+	config, err := ctrl.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+	return client.New(config, client.Options{Scheme: scheme})
+}
+
+// readConfigMap reads the first ConfigMap of a manifest file
+func readConfigMap(file string) (*corev1.ConfigMap, error) {
+	objects, err := readObjects(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	for _, obj := range objects {
+		if configmap, ok := obj.(*corev1.ConfigMap); ok {
+			return configmap, nil
+		}
+	}
+	return nil, fmt.Errorf("%s has no ConfigMap", file)
+}
+
+// liveConfigMap reads the ConfigMap a property writes from the cluster, an empty
+// ConfigMap is returned when it does not exist yet
+func liveConfigMap(ctx context.Context, r *backwoodsv1.ArchimedesProperty) (*corev1.ConfigMap, error) {
+	c, err := clusterClient()
+	if err != nil {
+		return nil, err
+	}
+	name := r.Spec.ConfigMapName
+	property := &backwoodsv1.ArchimedesProperty{}
+	err = c.Get(ctx, types.NamespacedName{Name: r.Name, Namespace: r.Namespace}, property)
+	if err == nil && property.Status.ConfigMapName != "" {
+		// Immutable ConfigMaps are named by their content
+		name = property.Status.ConfigMapName
+	}
+	configmap := &corev1.ConfigMap{}
+	err = c.Get(ctx, types.NamespacedName{Name: name, Namespace: r.Namespace}, configmap)
+	if apierrors.IsNotFound(err) {
+		return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: r.Namespace}}, nil
+	}
+	return configmap, err
+}
+
+// serverDryRun creates a dry run copy of a property, waits for the operator to render it
+// and returns the changes it would make. The copy is labeled with dryRunLabel and deleted
+// when done.
+func serverDryRun(ctx context.Context, c client.Client, r *backwoodsv1.ArchimedesProperty, timeout time.Duration) (*backwoodsv1.PendingChange, error) {
+	labels := map[string]string{}
+	for k, v := range r.Labels {
+		labels[k] = v
+	}
+	labels[dryRunLabel] = "true"
+	dryRun := &backwoodsv1.ArchimedesProperty{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: r.Name + "-dry-run-",
+			Namespace:    r.Namespace,
+			Labels:       labels,
+		},
+		Spec: *r.Spec.DeepCopy(),
+	}
+	dryRun.Spec.DryRun = true
+	dryRun.Spec.Rollback = nil
+	dryRun.Spec.RolloutTargets = nil
+	// The copy is suspended until its status names the ConfigMap of the property
+	dryRun.Spec.Suspend = true
+	err := c.Create(ctx, dryRun)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err := c.Delete(context.Background(), dryRun)
+		if err != nil && !apierrors.IsNotFound(err) {
+			fmt.Fprintf(os.Stderr, "warning: could not delete dry run %s: %s, delete the leftovers with -l %s\n", dryRun.Name, err, dryRunLabel)
+		}
+	}()
+
+	err = setDryRunConfigMap(ctx, c, r, dryRun)
+	if err != nil {
+		return nil, err
+	}
+	patch := client.MergeFrom(dryRun.DeepCopy())
+	dryRun.Spec.Suspend = false
+	err = c.Patch(ctx, dryRun, patch)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending *backwoodsv1.PendingChange
+	err = wait.PollImmediate(dryRunPollInterval, timeout, func() (bool, error) {
+		err := c.Get(ctx, client.ObjectKeyFromObject(dryRun), dryRun)
+		if err != nil {
+			return false, err
+		}
+		if condition := meta.FindStatusCondition(dryRun.Status.Conditions, conditionTypeDryRun); condition != nil &&
+			condition.ObservedGeneration == dryRun.Generation && dryRun.Status.PendingChange != nil {
+			pending = dryRun.Status.PendingChange
+			return true, nil
+		}
+		if condition := meta.FindStatusCondition(dryRun.Status.Conditions, conditionTypeConfigmapCreated); condition != nil &&
+			condition.ObservedGeneration == dryRun.Generation && condition.Status == metav1.ConditionFalse &&
+			condition.Reason != conditionReasonSourceNotReady {
+			return false, fmt.Errorf("dry run failed with %s: %s", condition.Reason, condition.Message)
+		}
+		return false, nil
+	})
+	if errors.Is(err, wait.ErrWaitTimeout) {
+		return nil, fmt.Errorf("the operator did not render dry run %s within %s", dryRun.Name, timeout)
+	}
+	return pending, err
+}
+
+// setDryRunConfigMap sets the ConfigMap of the live property as the ConfigMap of its dry run
+// copy, so the copy is compared with it. Immutable ConfigMaps are named by their content.
+func setDryRunConfigMap(ctx context.Context, c client.Client, r, dryRun *backwoodsv1.ArchimedesProperty) error {
+	live := &backwoodsv1.ArchimedesProperty{}
+	err := c.Get(ctx, client.ObjectKeyFromObject(r), live)
+	if apierrors.IsNotFound(err) || (err == nil && live.Status.ConfigMapName == "") {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		err := c.Get(ctx, client.ObjectKeyFromObject(dryRun), dryRun)
+		if err != nil {
+			return err
+		}
+		dryRun.Status.ConfigMapName = live.Status.ConfigMapName
+		return c.Status().Update(ctx, dryRun)
+	})
+}
+
+// printDiff prints the keys added, removed and changed in a ConfigMap with their values
+// unless they are redacted. It returns errChanged when anything changed.
+func printDiff(w io.Writer, name string, diff render.Diff, old, new map[string]string, redact bool) error {
+	if diff.Empty() {
+		fmt.Fprintf(w, "ConfigMap %s is unchanged\n", name)
+		return nil
+	}
+	fmt.Fprintf(w, "ConfigMap %s\n", name)
+	for _, k := range diff.Added {
+		fmt.Fprintf(w, "+ %s\n", keyValue(k, new[k], redact))
+	}
+	for _, k := range diff.Removed {
+		fmt.Fprintf(w, "- %s\n", keyValue(k, old[k], redact))
+	}
+	for _, k := range diff.Changed {
+		switch {
+		case redact:
+			fmt.Fprintf(w, "~ %s: %s\n", k, redacted)
+		case !strings.Contains(old[k], "\n") && !strings.Contains(new[k], "\n"):
+			fmt.Fprintf(w, "~ %s: %s -> %s\n", k, old[k], new[k])
+		default:
+			fmt.Fprintf(w, "~ %s:\n%s\n", k, indent(unifiedDiff("live", "proposed", old[k], new[k])))
+		}
+	}
+	fmt.Fprintf(w, "%d added, %d removed, %d changed\n", len(diff.Added), len(diff.Removed), len(diff.Changed))
+	return errChanged
+}
+
+// keyValue formats a key and its value for the output of diff
+func keyValue(k, v string, redact bool) string {
+	if redact {
+		return k + ": " + redacted
+	}
+	if strings.Contains(v, "\n") {
+		return k + ":\n" + indent(v)
+	}
+	return k + ": " + v
+}
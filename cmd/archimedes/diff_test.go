@@ -0,0 +1,131 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	backwoodsv1 "github.com/backwoods-devops/archimedes/api/v1"
+	"github.com/backwoods-devops/archimedes/internal/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRunDiff(t *testing.T) {
+	dir := testutil.WriteFiles(t, map[string]string{
+		"properties.tpl": "name={{ .name }}\npassword={{ .password }}\n",
+		"values.yaml":    "name: trees\npassword: hunter3\n",
+		"live.yaml": `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: app
+  namespace: default
+data:
+  password: hunter2
+  team: forest
+`,
+	})
+	args := []string{
+		"-template", filepath.Join(dir, "properties.tpl"),
+		"-values", filepath.Join(dir, "values.yaml"),
+		"-live", filepath.Join(dir, "live.yaml"),
+		"-name", "app",
+		"-type", "kvp",
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		want     []string
+		dontWant []string
+	}{
+		{
+			name: "values",
+			args: args,
+			want: []string{"ConfigMap default/app\n", "+ name: trees\n", "- team: forest\n", "~ password: hunter2 -> hunter3\n"},
+		},
+		{
+			name:     "redacted",
+			args:     append([]string{"-redact"}, args...),
+			want:     []string{"+ name: (redacted)\n", "~ password: (redacted)\n"},
+			dontWant: []string{"hunter"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			err := runDiff(tt.args, out)
+			if err != errChanged {
+				t.Fatalf("err = %v, want errChanged", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("output does not contain %q\n%s", want, out)
+				}
+			}
+			for _, dontWant := range tt.dontWant {
+				if strings.Contains(out.String(), dontWant) {
+					t.Errorf("output contains %q\n%s", dontWant, out)
+				}
+			}
+		})
+	}
+}
+
+func TestSetDryRunConfigMap(t *testing.T) {
+	property := func(name, configMapName string) *backwoodsv1.ArchimedesProperty {
+		r := &backwoodsv1.ArchimedesProperty{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+		r.Status.ConfigMapName = configMapName
+		return r
+	}
+	tests := []struct {
+		name string
+		live *backwoodsv1.ArchimedesProperty
+		want string
+	}{
+		{name: "immutable", live: property("trees", "trees-5f2c8a"), want: "trees-5f2c8a"},
+		{name: "not rendered yet", live: property("trees", "")},
+		{name: "new property"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			dryRun := property("trees-dry-run-x7k2p", "")
+			objs := []client.Object{dryRun.DeepCopy()}
+			if tt.live != nil {
+				objs = append(objs, tt.live)
+			}
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+
+			if err := setDryRunConfigMap(ctx, c, property("trees", ""), dryRun); err != nil {
+				t.Fatal(err)
+			}
+			got := &backwoodsv1.ArchimedesProperty{}
+			if err := c.Get(ctx, client.ObjectKeyFromObject(dryRun), got); err != nil {
+				t.Fatal(err)
+			}
+			if got.Status.ConfigMapName != tt.want {
+				t.Errorf("status.configMapName = %q, want %q", got.Status.ConfigMapName, tt.want)
+			}
+		})
+	}
+}
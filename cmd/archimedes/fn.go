@@ -0,0 +1,408 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	backwoodsv1 "github.com/backwoods-devops/archimedes/api/v1"
+	"github.com/backwoods-devops/archimedes/pkg/render"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	resourceListKind = "ResourceList"
+
+	// pathAnnotations hold the file a resource is read from and written to by kpt and kustomize
+	pathAnnotation         = "config.kubernetes.io/path"
+	internalPathAnnotation = "internal.config.kubernetes.io/path"
+	indexAnnotation        = "config.kubernetes.io/index"
+	internalAnnotations    = "internal.config.kubernetes.io/"
+)
+
+// stdin is read by the fn command
+var stdin io.Reader = os.Stdin
+
+// resourceList is the input and output of a KRM function
+type resourceList struct {
+	APIVersion     string                   `json:"apiVersion"`
+	Kind           string                   `json:"kind"`
+	Items          []map[string]interface{} `json:"items"`
+	FunctionConfig map[string]interface{}   `json:"functionConfig,omitempty"`
+	Results        []fnResult               `json:"results,omitempty"`
+}
+
+// fnResult reports a property that could not be rendered
+type fnResult struct {
+	Message     string         `json:"message"`
+	Severity    string         `json:"severity"`
+	ResourceRef *fnResourceRef `json:"resourceRef,omitempty"`
+}
+
+type fnResourceRef struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	Namespace  string `json:"namespace,omitempty"`
+}
+
+// fnFlags are the flags of the fn command, also read from the data of a ConfigMap functionConfig
+type fnFlags struct {
+	renderFlags
+	strip         bool
+	clusterValues bool
+	// dirRepo is the url of the repo checked out in dir, only properties of this repo are
+	// read from dir when set and the others are cloned
+	dirRepo string
+	// dirCommit is recorded as the commit of dir when it has no git metadata
+	dirCommit string
+}
+
+// bind binds the flags shared by the commands rendering the properties of a list of manifests
+func (f *fnFlags) bind(fs *flag.FlagSet) {
+	fs.BoolVar(&f.clusterValues, "cluster-values", true, "Merge the ClusterArchimedesValues of the items, as the operator flag of the same name.")
+	fs.StringVar(&f.lookupNamespaces, "lookup-namespaces", "",
+		"Comma separated namespaces lookup functions may read besides the namespace of the property, \"*\" allows all.")
+	fs.StringVar(&f.clusterDomain, "cluster-domain", "cluster.local", "The DNS domain of the cluster used to build service hosts.")
+	f.bindLimits(fs)
+	f.bindGit(fs)
+}
+
+func runFn(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("fn", flag.ContinueOnError)
+	f := &fnFlags{}
+	fs.StringVar(&f.dir, "dir", "", "A local checkout of the template repo, used instead of cloning the repo of every property.")
+	fs.BoolVar(&f.strip, "strip", false, "Remove the ArchimedesProperties and the sources and values they read from the output.")
+	f.bind(fs)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: archimedes fn [flags] < resource-list.yaml\n\n"+
+			"Runs as a KRM function. Renders the ArchimedesProperties of the ResourceList on stdin and writes it with\n"+
+			"their ConfigMaps to stdout. The data of a ConfigMap functionConfig sets the flags by name.\n\n")
+		fs.PrintDefaults()
+	}
+	err := fs.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	content, err := ioutil.ReadAll(stdin)
+	if err != nil {
+		return err
+	}
+	list := &resourceList{}
+	err = yaml.Unmarshal(content, list)
+	if err != nil {
+		return fmt.Errorf("could not parse the ResourceList: %w", err)
+	}
+	if list.Kind != resourceListKind {
+		return fmt.Errorf("expected a %s on stdin, found %q", resourceListKind, list.Kind)
+	}
+	err = applyFunctionConfig(fs, list.FunctionConfig)
+	if err != nil {
+		return err
+	}
+
+	failed, err := f.process(context.Background(), list)
+	if err != nil {
+		return err
+	}
+	out, err := yaml.Marshal(list)
+	if err != nil {
+		return err
+	}
+	_, err = stdout.Write(out)
+	if err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d properties could not be rendered", failed)
+	}
+	return nil
+}
+
+// applyFunctionConfig sets the flags named by the data of a ConfigMap functionConfig
+func applyFunctionConfig(fs *flag.FlagSet, config map[string]interface{}) error {
+	if config == nil {
+		return nil
+	}
+	kind, _ := config["kind"].(string)
+	if kind != "ConfigMap" {
+		return fmt.Errorf("expected a ConfigMap functionConfig, found %q", kind)
+	}
+	data, _ := config["data"].(map[string]interface{})
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		err := fs.Set(key, fmt.Sprint(data[key]))
+		if err != nil {
+			return fmt.Errorf("functionConfig %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// process renders the properties of a ResourceList and adds their ConfigMaps after them,
+// or in place of the ConfigMaps of the same name already in the list. Properties that could
+// not be rendered are reported in the results of the list, and their number returned.
+func (f *fnFlags) process(ctx context.Context, list *resourceList) (int, error) {
+	objects, err := decodeItems(list.Items)
+	if err != nil {
+		return 0, err
+	}
+	reader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+
+	rendered := map[int]map[string]interface{}{}
+	generated := map[string]map[string]interface{}{}
+	failed := 0
+	for i, item := range list.Items {
+		if !isProperty(item) {
+			continue
+		}
+		configmap, err := f.renderItem(ctx, reader, item)
+		if err != nil {
+			failed++
+			list.Results = append(list.Results, fnResult{Message: err.Error(), Severity: "error", ResourceRef: resourceRefOf(item)})
+			continue
+		}
+		rendered[i] = configmap
+		generated[itemKey(configmap)] = configmap
+	}
+
+	existing := map[string]bool{}
+	for _, item := range list.Items {
+		if !isProperty(item) && generated[itemKey(item)] != nil {
+			existing[itemKey(item)] = true
+		}
+	}
+	items := []map[string]interface{}{}
+	for i, item := range list.Items {
+		switch {
+		case isProperty(item):
+			if !f.strip {
+				items = append(items, item)
+			}
+			if configmap, ok := rendered[i]; ok && !existing[itemKey(configmap)] {
+				items = append(items, configmap)
+			}
+		case generated[itemKey(item)] != nil:
+			items = append(items, generated[itemKey(item)])
+		case !f.strip || !isArchimedesItem(item):
+			items = append(items, item)
+		}
+	}
+	list.Items = items
+	return failed, nil
+}
+
+// decodeItems decodes the items the lookup functions and values of properties read, with
+// a Namespace for the namespace of every property that has none in the list
+func decodeItems(items []map[string]interface{}) ([]client.Object, error) {
+	deserializer := serializer.NewCodecFactory(scheme).UniversalDeserializer()
+	objects := []client.Object{}
+	namespaces := map[string]bool{}
+	propertyNamespaces := map[string]bool{}
+	for _, item := range items {
+		if isProperty(item) {
+			propertyNamespaces[namespaceOf(item)] = true
+			continue
+		}
+		content, err := json.Marshal(item)
+		if err != nil {
+			return nil, err
+		}
+		obj, _, err := deserializer.Decode(content, nil, nil)
+		if runtime.IsNotRegisteredError(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s %s: %w", item["kind"], nameOf(item), err)
+		}
+		o, ok := obj.(client.Object)
+		if !ok {
+			continue
+		}
+		switch o.(type) {
+		case *corev1.Namespace:
+			namespaces[o.GetName()] = true
+		case *backwoodsv1.ClusterArchimedesValues:
+			// cluster scoped
+		default:
+			o.SetNamespace(namespaceOf(item))
+		}
+		objects = append(objects, o)
+	}
+	for namespace := range propertyNamespaces {
+		if !namespaces[namespace] {
+			objects = append(objects, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}})
+		}
+	}
+	return objects, nil
+}
+
+// renderItem renders the property of an item with the values and sources of the list
+func (f *fnFlags) renderItem(ctx context.Context, reader client.Reader, item map[string]interface{}) (map[string]interface{}, error) {
+	content, err := json.Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+	r, err := decodeProperty(content)
+	if err != nil {
+		return nil, err
+	}
+	if r.Namespace == "" {
+		r.Namespace = "default"
+	}
+	r.Default()
+
+	git, err := f.gitOptions()
+	if err != nil {
+		return nil, err
+	}
+	sourceOpts := render.SourceOptions{Git: git, Reader: reader}
+	if f.dir != "" {
+		local := render.NewLocalSource(render.LocalOptions{Dir: f.dir, Commit: render.Commit{Hash: f.dirCommit}, Git: git})
+		if f.dirRepo == "" {
+			sourceOpts.SourceRef = func(context.Context, *backwoodsv1.ArchimedesProperty) (render.Source, error) { return local, nil }
+		}
+		sourceOpts.Repo = func(r *backwoodsv1.ArchimedesProperty) render.Source {
+			if f.dirRepo == "" || sameRepo(r.Spec.RepoUrl, f.dirRepo) {
+				return local
+			}
+			return nil
+		}
+	}
+	source, err := render.SourceFor(ctx, r, sourceOpts)
+	if err != nil {
+		return nil, err
+	}
+	src, err := source.Fetch(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	values, _, err := render.ResolveValues(ctx, reader, r, render.ValuesOptions{ClusterValues: f.clusterValues})
+	if err != nil {
+		return nil, err
+	}
+	var allowedNamespaces []string
+	if f.lookupNamespaces != "" {
+		allowedNamespaces = strings.Split(f.lookupNamespaces, ",")
+	}
+	opts := render.RenderOptions{
+		Limits: render.Limits{
+			Timeout:         f.renderTimeout,
+			MaxOutputBytes:  f.maxRenderSize,
+			MaxIncludeDepth: f.maxIncludeDepth,
+		},
+		Funcs: render.NewClusterLookup(ctx, reader, r.Namespace, allowedNamespaces, f.clusterDomain).Funcs(),
+	}
+	result, err := render.Render(r, src, values, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	configmap := render.ConfigMap(r, result)
+	configmap.APIVersion = "v1"
+	configmap.Kind = "ConfigMap"
+	for key := range configmap.Annotations {
+		if key == indexAnnotation || strings.HasPrefix(key, internalAnnotations) || key == pathAnnotation {
+			delete(configmap.Annotations, key)
+		}
+	}
+	// The ConfigMap is written next to the manifest of its property
+	for _, key := range []string{pathAnnotation, internalPathAnnotation} {
+		if p, ok := r.Annotations[key]; ok {
+			configmap.Annotations[key] = path.Join(path.Dir(p), "configmap_"+configmap.Name+".yaml")
+		}
+	}
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(configmap)
+	if err != nil {
+		return nil, err
+	}
+	unstructured.RemoveNestedField(obj, "metadata", "creationTimestamp")
+	return obj, nil
+}
+
+// itemKey identifies an item by its kind, namespace and name
+func itemKey(item map[string]interface{}) string {
+	return fmt.Sprintf("%s/%s/%s", item["kind"], namespaceOf(item), nameOf(item))
+}
+
+// sameRepo reports whether two urls address the same git repo
+func sameRepo(a, b string) bool {
+	normalize := func(url string) string {
+		return strings.TrimSuffix(strings.TrimSuffix(url, "/"), ".git")
+	}
+	return strings.EqualFold(normalize(a), normalize(b))
+}
+
+func isProperty(item map[string]interface{}) bool {
+	return isArchimedesItem(item) && item["kind"] == "ArchimedesProperty"
+}
+
+// isArchimedesItem reports whether an item is one of the kinds read when rendering properties
+func isArchimedesItem(item map[string]interface{}) bool {
+	apiVersion, _ := item["apiVersion"].(string)
+	if !strings.HasPrefix(apiVersion, backwoodsv1.GroupVersion.Group+"/") {
+		return false
+	}
+	switch item["kind"] {
+	case "ArchimedesProperty", "ArchimedesSource", render.ValuesKindNamespaced, render.ValuesKindCluster:
+		return true
+	}
+	return false
+}
+
+func resourceRefOf(item map[string]interface{}) *fnResourceRef {
+	apiVersion, _ := item["apiVersion"].(string)
+	kind, _ := item["kind"].(string)
+	return &fnResourceRef{APIVersion: apiVersion, Kind: kind, Name: nameOf(item), Namespace: namespaceOf(item)}
+}
+
+func nameOf(item map[string]interface{}) string {
+	metadata, _ := item["metadata"].(map[string]interface{})
+	name, _ := metadata["name"].(string)
+	return name
+}
+
+func namespaceOf(item map[string]interface{}) string {
+	metadata, _ := item["metadata"].(map[string]interface{})
+	namespace, _ := metadata["namespace"].(string)
+	if namespace == "" {
+		return "default"
+	}
+	return namespace
+}
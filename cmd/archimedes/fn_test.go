@@ -0,0 +1,165 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/backwoods-devops/archimedes/internal/testutil"
+	"sigs.k8s.io/yaml"
+)
+
+const fnInput = `apiVersion: config.kubernetes.io/v1
+kind: ResourceList
+items:
+- apiVersion: archimedes.backwoods-devops.io/v1
+  kind: ArchimedesProperty
+  metadata:
+    name: trees
+    namespace: forest
+    labels:
+      team: rangers
+    annotations:
+      config.kubernetes.io/path: apps/trees/property.yaml
+      config.kubernetes.io/index: "0"
+  spec:
+    configMapName: trees-config
+    repoUrl: https://example.com/trees.git
+    propertiesPath: properties.tpl
+    propertyType: kvp
+    sourceConfig: |
+      env:
+        name: staging
+- apiVersion: archimedes.backwoods-devops.io/v1
+  kind: ArchimedesValues
+  metadata:
+    name: shared
+    namespace: forest
+  spec:
+    propertySelector:
+      matchLabels:
+        team: rangers
+    values: |
+      env:
+        name: shared
+        dbname: forest-data
+- apiVersion: v1
+  kind: Service
+  metadata:
+    name: postgres
+    namespace: forest
+  spec:
+    ports:
+    - port: 5432
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: trees-config
+    namespace: forest
+  data:
+    stale: "true"
+- apiVersion: apps/v1
+  kind: Deployment
+  metadata:
+    name: trees
+    namespace: forest
+`
+
+func TestRunFn(t *testing.T) {
+	dir := testutil.WriteFiles(t, map[string]string{
+		"properties.tpl": "env={{ .env.name }}\ndbname={{ .env.dbname }}\ndbhost={{ serviceHost \"postgres\" }}\n",
+	})
+
+	tests := []struct {
+		name           string
+		functionConfig string
+		wantKinds      []string
+	}{
+		{
+			name:      "keep properties",
+			wantKinds: []string{"ArchimedesProperty", "ArchimedesValues", "Service", "ConfigMap", "Deployment"},
+		},
+		{
+			name:           "strip properties",
+			functionConfig: "functionConfig:\n  apiVersion: v1\n  kind: ConfigMap\n  data:\n    strip: \"true\"\n",
+			wantKinds:      []string{"Service", "ConfigMap", "Deployment"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdin = strings.NewReader(fnInput + tt.functionConfig)
+			out := &bytes.Buffer{}
+			err := runFn([]string{"-dir", dir}, out)
+			if err != nil {
+				t.Fatalf("%s\n%s", err, out)
+			}
+
+			list := &resourceList{}
+			err = yaml.Unmarshal(out.Bytes(), list)
+			if err != nil {
+				t.Fatal(err)
+			}
+			kinds := []string{}
+			for _, item := range list.Items {
+				kinds = append(kinds, item["kind"].(string))
+			}
+			if strings.Join(kinds, ",") != strings.Join(tt.wantKinds, ",") {
+				t.Errorf("kinds = %v, want %v", kinds, tt.wantKinds)
+			}
+
+			configmap := list.Items[len(list.Items)-2]
+			data := configmap["data"].(map[string]interface{})
+			want := "env=staging\ndbname=forest-data\ndbhost=postgres.forest.svc.cluster.local"
+			for _, line := range strings.Split(want, "\n") {
+				parts := strings.SplitN(line, "=", 2)
+				if data[parts[0]] != parts[1] {
+					t.Errorf("%s = %v, want %s", parts[0], data[parts[0]], parts[1])
+				}
+			}
+			if _, ok := data["stale"]; ok {
+				t.Errorf("the ConfigMap in the input was not replaced")
+			}
+			annotations := configmap["metadata"].(map[string]interface{})["annotations"].(map[string]interface{})
+			if annotations[pathAnnotation] != "apps/trees/configmap_trees-config.yaml" {
+				t.Errorf("path = %v", annotations[pathAnnotation])
+			}
+			if _, ok := annotations[indexAnnotation]; ok {
+				t.Errorf("the index of the property was copied to the ConfigMap")
+			}
+		})
+	}
+}
+
+func TestRunFnResults(t *testing.T) {
+	stdin = strings.NewReader(fnInput)
+	out := &bytes.Buffer{}
+	err := runFn([]string{"-dir", os.TempDir() + "/archimedes-fn-missing"}, out)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	list := &resourceList{}
+	err = yaml.Unmarshal(out.Bytes(), list)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Results) != 1 || list.Results[0].Severity != "error" || list.Results[0].ResourceRef.Name != "trees" {
+		t.Errorf("results = %+v", list.Results)
+	}
+}
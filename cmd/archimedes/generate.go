@@ -0,0 +1,197 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+// namespacedKinds are the kinds read when rendering a property that are given the
+// namespace of the application when they have none, as Argo CD does when applying them
+var namespacedKinds = map[string]bool{
+	"ArchimedesProperty": true,
+	"ArchimedesSource":   true,
+	"ArchimedesValues":   true,
+	"ConfigMap":          true,
+	"Service":            true,
+	"Ingress":            true,
+}
+
+func runGenerate(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("generate", flag.ContinueOnError)
+	f := &fnFlags{strip: true}
+	var namespace, appName, repoRoot string
+	var keepProperties bool
+	fs.StringVar(&appName, "app-name", os.Getenv("ARGOCD_APP_NAME"), "The name of the Argo CD application, reported in errors.")
+	fs.StringVar(&namespace, "namespace", envOrDefault("ARGOCD_APP_NAMESPACE", "default"),
+		"The namespace of manifests without one, the destination namespace of the application.")
+	fs.StringVar(&f.dirRepo, "repo", os.Getenv("ARGOCD_APP_SOURCE_REPO_URL"),
+		"The url of the repo checked out. Properties with this repoUrl are rendered from the checkout, others are cloned.")
+	fs.StringVar(&f.dirCommit, "revision", os.Getenv("ARGOCD_APP_REVISION"), "The commit of the checkout, recorded as the commit of properties rendered from it.")
+	fs.StringVar(&repoRoot, "repo-root", "", "The root of the checkout, found from ARGOCD_APP_SOURCE_PATH by default.")
+	fs.BoolVar(&keepProperties, "keep-properties", false, "Write the ArchimedesProperties and the sources and values they read along with the ConfigMaps.")
+	f.bind(fs)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: archimedes generate [flags] [dir]\n\n"+
+			"Renders the ArchimedesProperties of the manifests in dir, . by default, and writes the manifests with a ConfigMap\n"+
+			"in place of each property to stdout, as an Argo CD config management plugin. Flags default to the variables Argo CD sets.\n\n")
+		fs.PrintDefaults()
+	}
+	err := fs.Parse(args)
+	if err != nil {
+		return err
+	}
+	if fs.NArg() > 1 {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+	dir := "."
+	if fs.NArg() == 1 {
+		dir = fs.Arg(0)
+	}
+	f.strip = !keepProperties
+
+	if f.dirRepo != "" {
+		if repoRoot == "" {
+			repoRoot, err = checkoutRoot(dir, os.Getenv("ARGOCD_APP_SOURCE_PATH"))
+			if err != nil {
+				return err
+			}
+		}
+		f.dir = repoRoot
+	}
+
+	items, err := readManifests(dir)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if metadata, ok := item["metadata"].(map[string]interface{}); ok && namespacedKinds[fmt.Sprint(item["kind"])] {
+			if ns, _ := metadata["namespace"].(string); ns == "" {
+				metadata["namespace"] = namespace
+			}
+		}
+	}
+
+	list := &resourceList{Items: items}
+	failed, err := f.process(context.Background(), list)
+	if err != nil {
+		return err
+	}
+	if failed > 0 {
+		messages := []string{}
+		for _, result := range list.Results {
+			messages = append(messages, fmt.Sprintf("%s %s: %s", result.ResourceRef.Kind, result.ResourceRef.Name, result.Message))
+		}
+		if appName != "" {
+			return fmt.Errorf("application %s: %s", appName, strings.Join(messages, "; "))
+		}
+		return fmt.Errorf("%s", strings.Join(messages, "; "))
+	}
+
+	for i, item := range list.Items {
+		out, err := yaml.Marshal(item)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			fmt.Fprintf(stdout, "---\n")
+		}
+		_, err = stdout.Write(out)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkoutRoot returns the root of the checkout dir is the source path of
+func checkoutRoot(dir, sourcePath string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	sourcePath = filepath.Clean(sourcePath)
+	if sourcePath == "." || sourcePath == "" {
+		return abs, nil
+	}
+	if !strings.HasSuffix(abs, string(filepath.Separator)+sourcePath) {
+		return "", fmt.Errorf("%s is not the source path %s of the application, set -repo-root", abs, sourcePath)
+	}
+	return strings.TrimSuffix(abs, string(filepath.Separator)+sourcePath), nil
+}
+
+// readManifests reads the manifests of the yaml and json files under dir. Documents
+// without a kind, such as values files, and kustomize configuration are skipped.
+func readManifests(dir string) ([]map[string]interface{}, error) {
+	items := []map[string]interface{}{}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if info.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		switch filepath.Ext(path) {
+		case ".yaml", ".yml", ".json":
+		default:
+			return nil
+		}
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		decoder := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(content), 4096)
+		for {
+			item := map[string]interface{}{}
+			err := decoder.Decode(&item)
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("%s: %w", path, err)
+			}
+			apiVersion, _ := item["apiVersion"].(string)
+			if item["kind"] == nil || apiVersion == "" || strings.HasPrefix(apiVersion, "kustomize.config.k8s.io/") {
+				continue
+			}
+			items = append(items, item)
+		}
+	})
+	return items, err
+}
+
+func envOrDefault(name, value string) string {
+	if v := os.Getenv(name); v != "" {
+		return v
+	}
+	return value
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/backwoods-devops/archimedes/internal/testutil"
+)
+
+func TestRunGenerate(t *testing.T) {
+	root := testutil.WriteFiles(t, map[string]string{
+		"config/properties.tpl": "env={{ .env.name }}\n",
+		"apps/trees/property.yaml": `apiVersion: archimedes.backwoods-devops.io/v1
+kind: ArchimedesProperty
+metadata:
+  name: trees
+spec:
+  configMapName: trees-config
+  repoUrl: https://example.com/trees.git
+  revision: main
+  propertiesPath: config/properties.tpl
+  propertyType: kvp
+  sourceConfig: |
+    env:
+      name: staging
+`,
+		"apps/trees/deployment.yaml": `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: trees
+`,
+		"apps/trees/values/staging.yaml": "env:\n  name: staging\n",
+		"apps/trees/kustomization.yaml":  "apiVersion: kustomize.config.k8s.io/v1beta1\nkind: Kustomization\n",
+	})
+
+	out := &bytes.Buffer{}
+	err := runGenerate([]string{
+		"-repo", "https://example.com/trees",
+		"-revision", "3f2a1b4c",
+		"-repo-root", root,
+		"-namespace", "forest",
+		filepath.Join(root, "apps/trees"),
+	}, out)
+	if err != nil {
+		t.Fatalf("%s\n%s", err, out)
+	}
+	for _, want := range []string{"kind: Deployment\n", "kind: ConfigMap\n", "name: trees-config\n", "namespace: forest\n", "env: staging\n", "commit: 3f2a1b4c\n"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output does not contain %q\n%s", want, out)
+		}
+	}
+	for _, dontWant := range []string{"ArchimedesProperty", "Kustomization"} {
+		if strings.Contains(out.String(), dontWant) {
+			t.Errorf("output contains %q\n%s", dontWant, out)
+		}
+	}
+}
+
+func TestCheckoutRoot(t *testing.T) {
+	tests := []struct {
+		dir        string
+		sourcePath string
+		want       string
+		wantErr    bool
+	}{
+		{dir: "/repo/apps/trees", sourcePath: "apps/trees", want: "/repo"},
+		{dir: "/repo", sourcePath: ".", want: "/repo"},
+		{dir: "/repo", sourcePath: "", want: "/repo"},
+		{dir: "/repo/apps/trees", sourcePath: "apps/forest", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := checkoutRoot(filepath.FromSlash(tt.dir), filepath.FromSlash(tt.sourcePath))
+		if (err != nil) != tt.wantErr {
+			t.Errorf("checkoutRoot(%s, %s) err = %v", tt.dir, tt.sourcePath, err)
+			continue
+		}
+		if !tt.wantErr && got != filepath.FromSlash(tt.want) {
+			t.Errorf("checkoutRoot(%s, %s) = %s, want %s", tt.dir, tt.sourcePath, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command archimedes renders ArchimedesProperty templates outside of a cluster with the
+// same renderer as the operator, for local development and CI.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// command is a subcommand of the CLI
+type command struct {
+	summary string
+	run     func(args []string, stdout io.Writer) error
+}
+
+var commands = map[string]command{
+	"diff":     {summary: "Compare the ConfigMap of a proposed property with the live ConfigMap", run: runDiff},
+	"fn":       {summary: "Run as a KRM function rendering the properties of a ResourceList on stdin", run: runFn},
+	"generate": {summary: "Render the properties of a directory of manifests as an Argo CD config management plugin", run: runGenerate},
+	"render":   {summary: "Render a property template and print its ConfigMap", run: runRender},
+	"test":     {summary: "Run the test cases of property templates against golden files", run: runTest},
+}
+
+func main() {
+	if len(os.Args) < 2 || os.Args[1] == "-h" || os.Args[1] == "--help" || os.Args[1] == "help" {
+		usage(os.Stderr)
+		os.Exit(2)
+	}
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n", os.Args[1])
+		usage(os.Stderr)
+		os.Exit(2)
+	}
+	err := cmd.run(os.Args[2:], os.Stdout)
+	if errors.Is(err, flag.ErrHelp) {
+		os.Exit(2)
+	}
+	if errors.Is(err, errChanged) {
+		os.Exit(1)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "archimedes %s: %s\n", os.Args[1], err)
+		os.Exit(1)
+	}
+}
+
+func usage(w io.Writer) {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	lines := make([]string, 0, len(names))
+	for _, name := range names {
+		lines = append(lines, fmt.Sprintf("  %-10s %s", name, commands[name].summary))
+	}
+	fmt.Fprintf(w, "Usage: archimedes <command> [flags]\n\nCommands:\n%s\n\nRun archimedes <command> -h for the flags of a command.\n", strings.Join(lines, "\n"))
+}
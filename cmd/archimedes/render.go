@@ -0,0 +1,404 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	backwoodsv1 "github.com/backwoods-devops/archimedes/api/v1"
+	backwoodsv2 "github.com/backwoods-devops/archimedes/api/v2"
+	"github.com/backwoods-devops/archimedes/pkg/render"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+)
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(backwoodsv1.AddToScheme(scheme))
+	utilruntime.Must(backwoodsv2.AddToScheme(scheme))
+}
+
+// stringsFlag is a flag that may be repeated
+type stringsFlag []string
+
+func (s *stringsFlag) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringsFlag) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
+
+// renderFlags are the flags selecting the property, template, values and limits of a render
+type renderFlags struct {
+	file          string
+	dir           string
+	template      string
+	repo          string
+	revision      string
+	path          string
+	caPath        string
+	values        stringsFlag
+	name          string
+	namespace     string
+	configMapName string
+	propertyType  string
+	keyName       string
+	// inlineValues are yaml documents merged after the values files
+	inlineValues []string
+
+	objects          stringsFlag
+	cluster          bool
+	lookupNamespaces string
+	clusterDomain    string
+
+	renderTimeout   time.Duration
+	maxRenderSize   int
+	maxIncludeDepth int
+
+	gitUser        string
+	gitPasswordEnv string
+}
+
+func (f *renderFlags) bind(fs *flag.FlagSet) {
+	fs.StringVar(&f.file, "f", "", "An ArchimedesProperty manifest to render, flags override its spec.")
+	fs.StringVar(&f.dir, "dir", "", "A local checkout of the template repo. Paths of the property are relative to it.")
+	fs.StringVar(&f.template, "template", "", "A local properties template file, used instead of the propertiesPath of the property.")
+	fs.StringVar(&f.repo, "repo", "", "The url of the template repo to clone, used when neither -dir nor -template is set.")
+	fs.StringVar(&f.revision, "revision", "", "The branch of the template repo.")
+	fs.StringVar(&f.path, "path", "", "The path of the properties template in the repo.")
+	fs.StringVar(&f.caPath, "ca", "", "The CA certificate of the template repo.")
+	fs.Var(&f.values, "values", "A yaml values file merged under the sourceConfig of the property. May be repeated, later files override earlier ones.")
+	fs.StringVar(&f.name, "name", "", "The name of the property.")
+	fs.StringVar(&f.namespace, "namespace", "", "The namespace of the property.")
+	fs.StringVar(&f.configMapName, "configmap-name", "", "The name of the ConfigMap, defaults to the name of the property.")
+	fs.StringVar(&f.propertyType, "type", "", "The property type, kvp or key.")
+	fs.StringVar(&f.keyName, "key-name", "", "The key holding the output when the property type is key.")
+	f.bindLookups(fs)
+	f.bindLimits(fs)
+	f.bindGit(fs)
+}
+
+// bindLookups binds the flags of the data read by the lookup functions
+func (f *renderFlags) bindLookups(fs *flag.FlagSet) {
+	fs.Var(&f.objects, "objects", "A yaml file of Services, Ingresses, ConfigMaps and Namespaces read by the lookup functions. May be repeated.")
+	fs.BoolVar(&f.cluster, "cluster", false, "Read the lookup functions from the cluster of the current kubeconfig instead of -objects.")
+	fs.StringVar(&f.lookupNamespaces, "lookup-namespaces", "",
+		"Comma separated namespaces lookup functions may read besides the namespace of the property, \"*\" allows all.")
+	fs.StringVar(&f.clusterDomain, "cluster-domain", "cluster.local", "The DNS domain of the cluster used to build service hosts.")
+}
+
+// bindLimits binds the flags of the render limits, with the defaults of the operator
+func (f *renderFlags) bindLimits(fs *flag.FlagSet) {
+	fs.DurationVar(&f.renderTimeout, "render-timeout", 10*time.Second, "The longest a single property template render may run.")
+	fs.IntVar(&f.maxRenderSize, "max-render-size", 1024*1024, "The maximum size in bytes of a rendered property template.")
+	fs.IntVar(&f.maxIncludeDepth, "max-include-depth", 16, "The maximum nesting of include and template calls in a property template.")
+}
+
+// bindGit binds the flags of the credentials of the cloned repos
+func (f *renderFlags) bindGit(fs *flag.FlagSet) {
+	fs.StringVar(&f.gitUser, "git-user", "", "The user cloning the template repos and libraries, they are cloned anonymously when empty.")
+	fs.StringVar(&f.gitPasswordEnv, "git-password-env", "", "The environment variable holding the password or token of -git-user.")
+}
+
+// gitOptions returns the options of the clones of the template repos and libraries
+func (f *renderFlags) gitOptions() (render.GitOptions, error) {
+	return render.UserGitOptions(f.gitUser, f.gitPasswordEnv)
+}
+
+// property returns the property read from -f with the flags applied and the defaults of
+// the admission webhook set
+func (f *renderFlags) property() (*backwoodsv1.ArchimedesProperty, error) {
+	r := &backwoodsv1.ArchimedesProperty{}
+	if f.file != "" {
+		content, err := ioutil.ReadFile(f.file)
+		if err != nil {
+			return nil, err
+		}
+		r, err = decodeProperty(content)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f.file, err)
+		}
+	}
+	overrides := map[*string]string{
+		&r.Name:                f.name,
+		&r.Namespace:           f.namespace,
+		&r.Spec.RepoUrl:        f.repo,
+		&r.Spec.Revision:       f.revision,
+		&r.Spec.CAPath:         f.caPath,
+		&r.Spec.PropertiesPath: f.path,
+		&r.Spec.ConfigMapName:  f.configMapName,
+		&r.Spec.PropertyType:   f.propertyType,
+		&r.Spec.KeyName:        f.keyName,
+	}
+	for field, value := range overrides {
+		if value != "" {
+			*field = value
+		}
+	}
+	if f.repo != "" {
+		r.Spec.SourceRef = nil
+		r.Spec.SourceType = backwoodsv1.SourceTypeGit
+	}
+	if r.Name == "" {
+		r.Name = "properties"
+	}
+	if r.Namespace == "" {
+		r.Namespace = "default"
+	}
+	r.Default()
+	return r, nil
+}
+
+// decodeProperty decodes a v1 or v2 ArchimedesProperty manifest into a v1 property
+func decodeProperty(content []byte) (*backwoodsv1.ArchimedesProperty, error) {
+	obj, _, err := serializer.NewCodecFactory(scheme).UniversalDeserializer().Decode(content, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	switch property := obj.(type) {
+	case *backwoodsv1.ArchimedesProperty:
+		return property, nil
+	case *backwoodsv2.ArchimedesProperty:
+		r := &backwoodsv1.ArchimedesProperty{}
+		err = r.ConvertFrom(property)
+		return r, err
+	}
+	return nil, fmt.Errorf("expected an ArchimedesProperty, found %s", obj.GetObjectKind().GroupVersionKind().Kind)
+}
+
+// source returns the source of the template of a property, -dir or -template, or the
+// source of its sourceType otherwise. The propertiesPath of the property is set to -template.
+func (f *renderFlags) source(ctx context.Context, r *backwoodsv1.ArchimedesProperty) (render.Source, error) {
+	git, err := f.gitOptions()
+	if err != nil {
+		return nil, err
+	}
+	if f.template == "" && f.dir == "" {
+		if r.Spec.SourceType == backwoodsv1.SourceTypeGit && r.Spec.SourceRef == nil && r.Spec.RepoUrl == "" {
+			return nil, fmt.Errorf("one of -f, -repo, -dir or -template is required")
+		}
+		opts := render.SourceOptions{Git: git}
+		if r.Spec.SourceType == backwoodsv1.SourceTypeConfigMap || (r.Spec.SourceType == backwoodsv1.SourceTypeGit && r.Spec.SourceRef != nil) {
+			opts.Reader, err = f.reader()
+			if err != nil {
+				return nil, err
+			}
+		}
+		return render.SourceFor(ctx, r, opts)
+	}
+
+	dir := f.dir
+	if f.template != "" {
+		if dir == "" {
+			dir = filepath.Dir(f.template)
+		}
+		path, err := filepath.Rel(dir, f.template)
+		if err != nil {
+			return nil, err
+		}
+		if strings.HasPrefix(path, "..") {
+			return nil, fmt.Errorf("template %s is outside of %s", f.template, dir)
+		}
+		r.Spec.PropertiesPath = filepath.ToSlash(path)
+	}
+	return render.NewLocalSource(render.LocalOptions{Dir: dir, Git: git}), nil
+}
+
+// mergedValues merges the -values files and the sourceConfig of a property in the order
+// the operator merges the values a property inherits and its sourceConfig
+func (f *renderFlags) mergedValues(r *backwoodsv1.ArchimedesProperty) (map[string]interface{}, error) {
+	merged := map[string]interface{}{}
+	for _, file := range f.values {
+		content, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		values, err := render.ParseValues(string(content))
+		if err != nil {
+			return nil, &render.ValuesError{Source: file, Err: err}
+		}
+		render.MergeValues(merged, values)
+	}
+	for _, doc := range f.inlineValues {
+		values, err := render.ParseValues(doc)
+		if err != nil {
+			return nil, &render.ValuesError{Source: "values", Err: err}
+		}
+		render.MergeValues(merged, values)
+	}
+	values, err := render.ParseValues(r.Spec.SourceConfig)
+	if err != nil {
+		return nil, &render.ValuesError{Source: "sourceConfig", Err: err}
+	}
+	render.MergeValues(merged, values)
+	return merged, nil
+}
+
+// options returns the limits and lookup functions of a render of a property
+func (f *renderFlags) options(ctx context.Context, r *backwoodsv1.ArchimedesProperty) (render.RenderOptions, error) {
+	reader, err := f.reader()
+	if err != nil {
+		return render.RenderOptions{}, err
+	}
+	var allowedNamespaces []string
+	if f.lookupNamespaces != "" {
+		allowedNamespaces = strings.Split(f.lookupNamespaces, ",")
+	}
+	return render.RenderOptions{
+		Limits: render.Limits{
+			Timeout:         f.renderTimeout,
+			MaxOutputBytes:  f.maxRenderSize,
+			MaxIncludeDepth: f.maxIncludeDepth,
+		},
+		Funcs: render.NewClusterLookup(ctx, reader, r.Namespace, allowedNamespaces, f.clusterDomain).Funcs(),
+	}, nil
+}
+
+// reader returns the client lookups read from, the cluster with -cluster or the
+// -objects files otherwise
+func (f *renderFlags) reader() (client.Reader, error) {
+	if f.cluster {
+		config, err := ctrl.GetConfig()
+		if err != nil {
+			return nil, err
+		}
+		return client.New(config, client.Options{Scheme: scheme})
+	}
+	objects := []client.Object{}
+	for _, file := range f.objects {
+		decoded, err := readObjects(file)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		objects = append(objects, decoded...)
+	}
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build(), nil
+}
+
+// readObjects decodes every document of a yaml file
+func readObjects(file string) ([]client.Object, error) {
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	decoder := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(content), 4096)
+	deserializer := serializer.NewCodecFactory(scheme).UniversalDeserializer()
+	objects := []client.Object{}
+	for {
+		raw := runtime.RawExtension{}
+		err := decoder.Decode(&raw)
+		if err == io.EOF {
+			return objects, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(bytes.TrimSpace(raw.Raw)) == 0 || string(bytes.TrimSpace(raw.Raw)) == "null" {
+			continue
+		}
+		obj, _, err := deserializer.Decode(raw.Raw, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		o, ok := obj.(client.Object)
+		if !ok {
+			return nil, fmt.Errorf("%s is not an object", obj.GetObjectKind().GroupVersionKind().Kind)
+		}
+		objects = append(objects, o)
+	}
+}
+
+// renderProperty runs the render pipeline of the operator for a property and returns its ConfigMap
+func (f *renderFlags) renderProperty(ctx context.Context, r *backwoodsv1.ArchimedesProperty) (*corev1.ConfigMap, error) {
+	source, err := f.source(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	src, err := source.Fetch(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	values, err := f.mergedValues(r)
+	if err != nil {
+		return nil, err
+	}
+	opts, err := f.options(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	result, err := render.Render(r, src, values, opts)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range result.Collisions {
+		fmt.Fprintf(os.Stderr, "warning: template key %s collides with a provenance key and was kept\n", key)
+	}
+	configmap := render.ConfigMap(r, result)
+	configmap.APIVersion = "v1"
+	configmap.Kind = "ConfigMap"
+	return configmap, nil
+}
+
+func runRender(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("render", flag.ContinueOnError)
+	f := &renderFlags{}
+	f.bind(fs)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: archimedes render [flags]\n\nRenders a property template with the renderer of the operator and prints the ConfigMap as yaml.\n\n")
+		fs.PrintDefaults()
+	}
+	err := fs.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	r, err := f.property()
+	if err != nil {
+		return err
+	}
+	configmap, err := f.renderProperty(context.Background(), r)
+	if err != nil {
+		return err
+	}
+	out, err := yaml.Marshal(configmap)
+	if err != nil {
+		return err
+	}
+	_, err = stdout.Write(out)
+	return err
+}
@@ -0,0 +1,330 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/backwoods-devops/archimedes/pkg/render"
+	"github.com/pmezard/go-difflib/difflib"
+	"sigs.k8s.io/yaml"
+)
+
+// testSuiteFile is the name of the files holding test suites, a suite may also be
+// named <name>.archimedes-test.yaml
+const testSuiteFile = "archimedes-test.yaml"
+
+// testSuite is a property template and the cases it is rendered with. Paths are
+// relative to the suite file.
+type testSuite struct {
+	//Property is an ArchimedesProperty manifest rendered by every case
+	Property string `json:"property,omitempty"`
+	//Dir is the local checkout the paths of the property are relative to, defaults to
+	//the directory of the suite
+	Dir string `json:"dir,omitempty"`
+	//Template replaces the propertiesPath of the property
+	Template string `json:"template,omitempty"`
+	//PropertyType and KeyName override the spec of the property
+	PropertyType string `json:"propertyType,omitempty"`
+	KeyName      string `json:"keyName,omitempty"`
+	//Objects are yaml files of objects read by the lookup functions
+	Objects []string `json:"objects,omitempty"`
+	//Cases are rendered in order
+	Cases []testCase `json:"cases"`
+}
+
+// testCase is a set of values and the expected result of rendering a suite with them
+type testCase struct {
+	//Name identifies the case
+	Name string `json:"name"`
+	//ValuesFiles are yaml files merged under the sourceConfig of the property, in order
+	ValuesFiles []string `json:"valuesFiles,omitempty"`
+	//Values is yaml merged after the values files
+	Values string `json:"values,omitempty"`
+	//Golden is a yaml file holding the expected data of the ConfigMap, without the
+	//provenance keys
+	Golden string `json:"golden,omitempty"`
+	//Data are expected keys and values of the ConfigMap, other keys are ignored
+	Data map[string]string `json:"data,omitempty"`
+	//Error is expected to be contained in the render error
+	Error string `json:"error,omitempty"`
+}
+
+func runTest(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	f := &renderFlags{}
+	f.bindLimits(fs)
+	f.bindGit(fs)
+	fs.StringVar(&f.lookupNamespaces, "lookup-namespaces", "",
+		"Comma separated namespaces lookup functions may read besides the namespace of the property, \"*\" allows all.")
+	fs.StringVar(&f.clusterDomain, "cluster-domain", "cluster.local", "The DNS domain of the cluster used to build service hosts.")
+	update := fs.Bool("update", false, "Write the rendered data to the golden files instead of comparing it.")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: archimedes test [flags] [path...]\n\n"+
+			"Runs the cases of every %s and *.%s found in the paths, . by default.\n\n", testSuiteFile, testSuiteFile)
+		fs.PrintDefaults()
+	}
+	err := fs.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	paths := fs.Args()
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+	suites, err := findSuites(paths)
+	if err != nil {
+		return err
+	}
+	if len(suites) == 0 {
+		return fmt.Errorf("no %s files found", testSuiteFile)
+	}
+
+	total, failed := 0, 0
+	for _, file := range suites {
+		suite, err := readSuite(file)
+		if err != nil {
+			fmt.Fprintf(stdout, "--- FAIL: %s\n    %s\n", file, err)
+			total++
+			failed++
+			continue
+		}
+		for _, c := range suite.Cases {
+			total++
+			name := file + "/" + c.Name
+			report, err := suite.run(f, file, c, *update)
+			if err != nil {
+				failed++
+				fmt.Fprintf(stdout, "--- FAIL: %s\n%s\n", name, indent(err.Error()))
+				continue
+			}
+			fmt.Fprintf(stdout, "ok   %s%s\n", name, report)
+		}
+	}
+
+	if failed > 0 {
+		fmt.Fprintf(stdout, "FAIL\n")
+		return fmt.Errorf("%d of %d cases failed", failed, total)
+	}
+	fmt.Fprintf(stdout, "PASS\n")
+	return nil
+}
+
+// findSuites returns the suite files in paths, a path naming a file is always a suite
+func findSuites(paths []string) ([]string, error) {
+	suites := []string{}
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			suites = append(suites, path)
+			continue
+		}
+		err = filepath.Walk(path, func(file string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() && info.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			if !info.IsDir() && (info.Name() == testSuiteFile || strings.HasSuffix(info.Name(), "."+testSuiteFile)) {
+				suites = append(suites, file)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.Strings(suites)
+	return suites, nil
+}
+
+// readSuite reads a suite file and checks every case expects something
+func readSuite(file string) (*testSuite, error) {
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	suite := &testSuite{}
+	err = yaml.UnmarshalStrict(content, suite)
+	if err != nil {
+		return nil, err
+	}
+	if len(suite.Cases) == 0 {
+		return nil, fmt.Errorf("the suite has no cases")
+	}
+	names := map[string]bool{}
+	for _, c := range suite.Cases {
+		if c.Name == "" {
+			return nil, fmt.Errorf("every case needs a name")
+		}
+		if names[c.Name] {
+			return nil, fmt.Errorf("case %s is defined more than once", c.Name)
+		}
+		names[c.Name] = true
+		if c.Golden == "" && len(c.Data) == 0 && c.Error == "" {
+			return nil, fmt.Errorf("case %s needs a golden file, data or an error", c.Name)
+		}
+	}
+	return suite, nil
+}
+
+// run renders a case and compares the result with its expectations. The returned error
+// describes every difference found.
+func (s *testSuite) run(flags *renderFlags, file string, c testCase, update bool) (string, error) {
+	base := filepath.Dir(file)
+	resolve := func(path string) string {
+		if path == "" || filepath.IsAbs(path) {
+			return path
+		}
+		return filepath.Join(base, path)
+	}
+
+	f := *flags
+	f.file = resolve(s.Property)
+	f.dir = resolve(s.Dir)
+	if f.dir == "" {
+		f.dir = base
+	}
+	f.template = resolve(s.Template)
+	f.propertyType = s.PropertyType
+	f.keyName = s.KeyName
+	f.objects = nil
+	for _, objects := range s.Objects {
+		f.objects = append(f.objects, resolve(objects))
+	}
+	f.values = nil
+	for _, values := range c.ValuesFiles {
+		f.values = append(f.values, resolve(values))
+	}
+	f.inlineValues = nil
+	if c.Values != "" {
+		f.inlineValues = []string{c.Values}
+	}
+
+	r, err := f.property()
+	if err != nil {
+		return "", err
+	}
+	configmap, err := f.renderProperty(context.Background(), r)
+	if c.Error != "" {
+		if err == nil {
+			return "", fmt.Errorf("expected an error containing %q", c.Error)
+		}
+		if !strings.Contains(err.Error(), c.Error) {
+			return "", fmt.Errorf("expected an error containing %q, got: %s", c.Error, err)
+		}
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	data := map[string]string{}
+	for k, v := range configmap.Data {
+		data[k] = v
+	}
+	for _, k := range render.ProvenanceKeys(r) {
+		delete(data, k)
+	}
+
+	failures := []string{}
+	report := ""
+	if c.Golden != "" {
+		golden := resolve(c.Golden)
+		out, err := yaml.Marshal(data)
+		if err != nil {
+			return "", err
+		}
+		if update {
+			err = os.MkdirAll(filepath.Dir(golden), 0755)
+			if err == nil {
+				err = ioutil.WriteFile(golden, out, 0644)
+			}
+			if err != nil {
+				return "", err
+			}
+			report = " (updated " + golden + ")"
+		} else {
+			expected, err := ioutil.ReadFile(golden)
+			if err != nil {
+				return "", fmt.Errorf("%w, run with -update to create it", err)
+			}
+			if diff := unifiedDiff(golden, "rendered", string(expected), string(out)); diff != "" {
+				failures = append(failures, diff)
+			}
+		}
+	}
+	if len(c.Data) > 0 {
+		actual := map[string]string{}
+		for k := range c.Data {
+			if v, ok := data[k]; ok {
+				actual[k] = v
+			}
+		}
+		expected, err := yaml.Marshal(c.Data)
+		if err != nil {
+			return "", err
+		}
+		out, err := yaml.Marshal(actual)
+		if err != nil {
+			return "", err
+		}
+		if diff := unifiedDiff("expected data", "rendered", string(expected), string(out)); diff != "" {
+			failures = append(failures, diff)
+		}
+	}
+	if len(failures) > 0 {
+		return "", fmt.Errorf("%s", strings.Join(failures, "\n"))
+	}
+	return report, nil
+}
+
+// unifiedDiff returns the unified diff of two texts, nothing when they are equal
+func unifiedDiff(fromName, toName, from, to string) string {
+	if from == to {
+		return ""
+	}
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(strings.TrimSuffix(from, "\n")),
+		B:        difflib.SplitLines(strings.TrimSuffix(to, "\n")),
+		FromFile: fromName,
+		ToFile:   toName,
+		Context:  3,
+	})
+	if err != nil {
+		return err.Error()
+	}
+	return strings.TrimRight(diff, "\n")
+}
+
+// indent indents every line of text for the output of a failed case
+func indent(text string) string {
+	return "    " + strings.ReplaceAll(text, "\n", "\n    ")
+}
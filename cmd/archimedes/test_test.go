@@ -0,0 +1,64 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/backwoods-devops/archimedes/internal/testutil"
+)
+
+func TestRunTestSamples(t *testing.T) {
+	out := &bytes.Buffer{}
+	err := runTest([]string{"../../config/samples"}, out)
+	if err != nil {
+		t.Fatalf("%s\n%s", err, out)
+	}
+	if !strings.HasSuffix(out.String(), "PASS\n") {
+		t.Errorf("output = %q, want PASS", out)
+	}
+}
+
+func TestRunTestFailure(t *testing.T) {
+	dir := testutil.WriteFiles(t, map[string]string{
+		"properties.tpl": "name={{ .name }}\n",
+		testSuiteFile: `template: properties.tpl
+propertyType: kvp
+cases:
+- name: values
+  values: "name: trees"
+  data:
+    name: forest
+- name: error
+  values: "name: trees"
+  error: not rendered
+`,
+	})
+
+	out := &bytes.Buffer{}
+	err := runTest([]string{dir}, out)
+	if err == nil || err.Error() != "2 of 2 cases failed" {
+		t.Fatalf("err = %v, want 2 of 2 cases failed\n%s", err, out)
+	}
+	for _, want := range []string{"-name: forest", "+name: trees", `expected an error containing "not rendered"`} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output does not contain %q\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,285 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	backwoodsv1 "github.com/backwoods-devops/archimedes/api/v1"
+	"github.com/backwoods-devops/archimedes/pkg/render"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	// templateAction matches the actions of a template line
+	templateAction = regexp.MustCompile(`{{-?(.*?)-?}}`)
+	// fieldReference matches the value fields an action reads, such as .env.dbname
+	fieldReference = regexp.MustCompile(`(?:^|[\s(|])\.([A-Za-z_]\w*(?:\.[A-Za-z_]\w*)*)`)
+	// lookupCall matches the cluster lookup functions an action calls
+	lookupCall = regexp.MustCompile(`\b(serviceHost|servicePort|ingressHosts|namespaceLabels|namespaceAnnotations|configMapValue)\b`)
+)
+
+// templateLine is a line of a template file
+type templateLine struct {
+	file   string
+	number int
+	text   string
+}
+
+// valuesSource is a layer of the values of a property
+type valuesSource struct {
+	name   string
+	values map[string]interface{}
+}
+
+func runExplain(o *options, args []string, stdout io.Writer) error {
+	gitUser := o.flags.String("git-user", "", "The user cloning the template repo and library, they are cloned anonymously when empty.")
+	gitPasswordEnv := o.flags.String("git-password-env", "", "The environment variable holding the password or token of -git-user.")
+	args, err := o.parse(args, 2, 2)
+	if err != nil {
+		return err
+	}
+	git, err := render.UserGitOptions(*gitUser, *gitPasswordEnv)
+	if err != nil {
+		return err
+	}
+	c, err := o.client()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	name, key := args[0], args[1]
+
+	property := &backwoodsv1.ArchimedesProperty{}
+	err = c.Get(ctx, types.NamespacedName{Name: name, Namespace: o.namespace}, property)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "Key:       %s\n", key)
+	configmap := &corev1.ConfigMap{}
+	err = c.Get(ctx, types.NamespacedName{Name: configMapName(property), Namespace: property.Namespace}, configmap)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	if value, ok := configmap.Data[key]; ok {
+		fmt.Fprintf(stdout, "Value:     %s\n", indentFollowing(value, "           "))
+	} else {
+		fmt.Fprintf(stdout, "Value:     <not in configmap %s>\n", configMapName(property))
+	}
+
+	for _, k := range render.ProvenanceKeys(property) {
+		if k == key {
+			fmt.Fprintf(stdout, "Source:    provenance recorded by the operator, not rendered from the template\n")
+			return nil
+		}
+	}
+
+	src, err := fetchTemplate(ctx, c, property, git)
+	if err != nil {
+		return err
+	}
+	if property.Status.Commit != "" && src.Commit.Hash != property.Status.Commit {
+		fmt.Fprintf(stdout, "Warning:   the revision is at commit %s, the configmap was rendered from %s\n",
+			shortCommit(src.Commit.Hash), shortCommit(property.Status.Commit))
+	}
+
+	lines := keyLines(property, src, key)
+	if len(lines) == 0 {
+		fmt.Fprintf(stdout, "Template:  no line of %s renders the key directly, it may be built by a range or a template\n", property.Spec.PropertiesPath)
+		return nil
+	}
+	fmt.Fprintf(stdout, "Template:  %s:%d at commit %s\n", lines[0].file, lines[0].number, shortCommit(src.Commit.Hash))
+	for _, line := range lines {
+		fmt.Fprintf(stdout, "  %4d  %s\n", line.number, line.text)
+	}
+
+	fields, lookups := references(lines)
+	if len(fields) > 0 {
+		layers, err := valuesSources(ctx, c, property)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(stdout, "Values:\n")
+		for _, field := range fields {
+			fmt.Fprintf(stdout, "  .%s %s\n", field, explainField(field, layers))
+		}
+	}
+	if len(lookups) > 0 {
+		fmt.Fprintf(stdout, "Lookups:   %s read from the cluster when rendered\n", strings.Join(lookups, ", "))
+	}
+	return nil
+}
+
+// fetchTemplate fetches the template of a property from its repo, ArchimedesSource, url
+// or ConfigMap. Mounted paths are only readable by the operator.
+func fetchTemplate(ctx context.Context, c client.Client, property *backwoodsv1.ArchimedesProperty, git render.GitOptions) (*render.Template, error) {
+	r := property.DeepCopy()
+	source, err := render.SourceFor(ctx, r, render.SourceOptions{
+		Git:    git,
+		Reader: c,
+		LocalPath: func(path string) error {
+			return fmt.Errorf("the template is read from %s mounted in the operator", path)
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return source.Fetch(ctx, r)
+}
+
+// keyLines returns the template lines rendering a key. Every line of the properties
+// template renders the key of a key property, and lines starting with key= render the
+// key of a kvp property, in the properties template or its partials.
+func keyLines(property *backwoodsv1.ArchimedesProperty, src *render.Template, key string) []templateLine {
+	if property.Spec.PropertyType == "key" {
+		if key != property.Spec.KeyName {
+			return nil
+		}
+		return splitLines(property.Spec.PropertiesPath, src.Content)
+	}
+
+	files := []templateLine{}
+	files = append(files, splitLines(property.Spec.PropertiesPath, src.Content)...)
+	names := make([]string, 0, len(src.Partials))
+	for name := range src.Partials {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		files = append(files, splitLines(name, src.Partials[name])...)
+	}
+
+	lines := []templateLine{}
+	for _, line := range files {
+		parts := strings.SplitN(strings.TrimSpace(line.text), "=", 2)
+		if len(parts) == 2 && strings.TrimSpace(parts[0]) == key {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+func splitLines(file string, content []byte) []templateLine {
+	lines := []templateLine{}
+	for i, text := range strings.Split(strings.TrimRight(string(content), "\n"), "\n") {
+		lines = append(lines, templateLine{file: file, number: i + 1, text: text})
+	}
+	return lines
+}
+
+// references returns the value fields and lookup functions used by the actions of lines
+func references(lines []templateLine) ([]string, []string) {
+	fields, lookups := []string{}, []string{}
+	seen := map[string]bool{}
+	for _, line := range lines {
+		for _, action := range templateAction.FindAllStringSubmatch(line.text, -1) {
+			for _, match := range fieldReference.FindAllStringSubmatch(action[1], -1) {
+				if !seen["."+match[1]] {
+					seen["."+match[1]] = true
+					fields = append(fields, match[1])
+				}
+			}
+			for _, match := range lookupCall.FindAllString(action[1], -1) {
+				if !seen[match] {
+					seen[match] = true
+					lookups = append(lookups, match)
+				}
+			}
+		}
+	}
+	return fields, lookups
+}
+
+// valuesSources returns the values merged into the last render of a property in the
+// order they were merged, as listed in its status, with its sourceConfig last
+func valuesSources(ctx context.Context, c client.Client, property *backwoodsv1.ArchimedesProperty) ([]valuesSource, error) {
+	layers := []valuesSource{}
+	for _, name := range property.Status.Values {
+		parts := strings.SplitN(name, "/", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		var doc string
+		switch parts[0] {
+		case "ClusterArchimedesValues":
+			values := &backwoodsv1.ClusterArchimedesValues{}
+			err := c.Get(ctx, types.NamespacedName{Name: parts[1]}, values)
+			if err != nil {
+				return nil, err
+			}
+			doc = values.Spec.Values
+		case "ArchimedesValues":
+			values := &backwoodsv1.ArchimedesValues{}
+			err := c.Get(ctx, types.NamespacedName{Name: parts[1], Namespace: property.Namespace}, values)
+			if err != nil {
+				return nil, err
+			}
+			doc = values.Spec.Values
+		default:
+			continue
+		}
+		values, err := render.ParseValues(doc)
+		if err != nil {
+			return nil, &render.ValuesError{Source: name, Err: err}
+		}
+		layers = append(layers, valuesSource{name: name, values: values})
+	}
+	values, err := render.ParseValues(property.Spec.SourceConfig)
+	if err != nil {
+		return nil, &render.ValuesError{Source: "sourceConfig", Err: err}
+	}
+	return append(layers, valuesSource{name: "sourceConfig", values: values}), nil
+}
+
+// explainField describes the value of a field and the values it comes from. The last
+// layer setting a field wins, the layers it overrides are listed.
+func explainField(field string, layers []valuesSource) string {
+	path := strings.Split(field, ".")
+	if path[0] == render.MetadataKey {
+		return "is metadata of the property and its commit"
+	}
+	setBy := []string{}
+	var value interface{}
+	for _, layer := range layers {
+		if v, ok := render.ValueAt(layer.values, path); ok {
+			setBy = append(setBy, layer.name)
+			value = v
+		}
+	}
+	if len(setBy) == 0 {
+		return "is not set by any values"
+	}
+	description := fmt.Sprintf("= %v from %s", value, setBy[len(setBy)-1])
+	if len(setBy) > 1 {
+		description += fmt.Sprintf(" (overrides %s)", strings.Join(setBy[:len(setBy)-1], ", "))
+	}
+	return description
+}
+
+// indentFollowing indents every line of text after the first
+func indentFollowing(text, indent string) string {
+	return strings.ReplaceAll(text, "\n", "\n"+indent)
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	backwoodsv1 "github.com/backwoods-devops/archimedes/api/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func runHistory(o *options, args []string, stdout io.Writer) error {
+	args, err := o.parse(args, 1, 1)
+	if err != nil {
+		return err
+	}
+	c, err := o.client()
+	if err != nil {
+		return err
+	}
+
+	property := &backwoodsv1.ArchimedesProperty{}
+	err = c.Get(context.Background(), types.NamespacedName{Name: args[0], Namespace: o.namespace}, property)
+	if err != nil {
+		return err
+	}
+	if len(property.Status.History) == 0 {
+		fmt.Fprintf(stdout, "Property %s has no history\n", property.Name)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(stdout, 0, 8, 3, ' ', 0)
+	fmt.Fprintf(w, "INDEX\tCOMMIT\tVALUES\tDATA\tRENDERED\tSNAPSHOT\n")
+	marked := false
+	for i, entry := range property.Status.History {
+		current := ""
+		if !marked && entry.DataHash == property.Status.ContentHash && entry.Commit == property.Status.Commit {
+			current = " (current)"
+			marked = true
+		}
+		fmt.Fprintf(w, "%d%s\t%s\t%s\t%s\t%s\t%s\n", i, current, shortCommit(entry.Commit), shortHash(entry.ValuesHash),
+			shortHash(entry.DataHash), entry.Timestamp.UTC().Format(time.RFC3339), entry.Snapshot)
+	}
+	err = w.Flush()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "\nRoll back with spec.rollback.index or spec.rollback.commit set to an entry.\n")
+	return nil
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 10 {
+		return hash[:10]
+	}
+	return valueOrNone(hash)
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command kubectl-archimedes is a kubectl plugin for inspecting and operating
+// ArchimedesProperties. Install it on the PATH and run it as kubectl archimedes.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	backwoodsv1 "github.com/backwoods-devops/archimedes/api/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(backwoodsv1.AddToScheme(scheme))
+}
+
+// command is a subcommand of the plugin
+type command struct {
+	usage   string
+	summary string
+	run     func(o *options, args []string, stdout io.Writer) error
+}
+
+var commands = map[string]command{
+	"status":  {usage: "[name]", summary: "Show the conditions, commit, last sync and drift of properties", run: runStatus},
+	"sync":    {usage: "<name>", summary: "Request a reconcile of a property and wait for it to complete", run: runSync},
+	"suspend": {usage: "<name>", summary: "Suspend the reconciliation of a property", run: runSuspend},
+	"resume":  {usage: "<name>", summary: "Resume the reconciliation of a property", run: runResume},
+	"history": {usage: "<name>", summary: "Show the render history of a property", run: runHistory},
+	"explain": {usage: "<name> <key>", summary: "Show the template line and values behind a rendered key", run: runExplain},
+}
+
+// options are the flags shared by every command
+type options struct {
+	flags         *flag.FlagSet
+	kubeconfig    string
+	context       string
+	namespace     string
+	allNamespaces bool
+	// c is used instead of a client for the kubeconfig when set
+	c client.Client
+}
+
+// newOptions returns the flags of a command with the shared flags bound
+func newOptions(name string) *options {
+	o := &options{flags: flag.NewFlagSet(name, flag.ContinueOnError)}
+	o.flags.StringVar(&o.kubeconfig, "kubeconfig", "", "Path to the kubeconfig file to use.")
+	o.flags.StringVar(&o.context, "context", "", "The name of the kubeconfig context to use.")
+	o.flags.StringVar(&o.namespace, "namespace", "", "The namespace of the properties, defaults to the namespace of the context.")
+	o.flags.StringVar(&o.namespace, "n", "", "Shorthand for -namespace.")
+	return o
+}
+
+// parse parses the flags of a command, which may follow its arguments as with kubectl,
+// and checks the number of arguments
+func (o *options) parse(args []string, min, max int) ([]string, error) {
+	positional := []string{}
+	for {
+		err := o.flags.Parse(args)
+		if err != nil {
+			return nil, err
+		}
+		if o.flags.NArg() == 0 {
+			break
+		}
+		positional = append(positional, o.flags.Arg(0))
+		args = o.flags.Args()[1:]
+	}
+	if len(positional) < min || len(positional) > max {
+		o.flags.Usage()
+		return nil, flag.ErrHelp
+	}
+	return positional, nil
+}
+
+// client returns a client for the cluster of the kubeconfig and defaults the namespace
+// to the namespace of the context
+func (o *options) client() (client.Client, error) {
+	if o.c != nil {
+		return o.c, nil
+	}
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	rules.ExplicitPath = o.kubeconfig
+	config := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, &clientcmd.ConfigOverrides{CurrentContext: o.context})
+	if o.namespace == "" {
+		namespace, _, err := config.Namespace()
+		if err != nil {
+			return nil, err
+		}
+		o.namespace = namespace
+	}
+	restConfig, err := config.ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	return client.New(restConfig, client.Options{Scheme: scheme})
+}
+
+func main() {
+	if len(os.Args) < 2 || os.Args[1] == "-h" || os.Args[1] == "--help" || os.Args[1] == "help" {
+		usage(os.Stderr)
+		os.Exit(2)
+	}
+	name := os.Args[1]
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n", name)
+		usage(os.Stderr)
+		os.Exit(2)
+	}
+	o := newOptions(name)
+	o.flags.Usage = func() {
+		fmt.Fprintf(o.flags.Output(), "Usage: kubectl archimedes %s %s [flags]\n\n%s.\n\n", name, cmd.usage, cmd.summary)
+		o.flags.PrintDefaults()
+	}
+	err := cmd.run(o, os.Args[2:], os.Stdout)
+	if errors.Is(err, flag.ErrHelp) {
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func usage(w io.Writer) {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	lines := make([]string, 0, len(names))
+	for _, name := range names {
+		lines = append(lines, fmt.Sprintf("  %-24s %s", name+" "+commands[name].usage, commands[name].summary))
+	}
+	fmt.Fprintf(w, "Usage: kubectl archimedes <command> [flags]\n\nCommands:\n%s\n\nRun kubectl archimedes <command> -h for the flags of a command.\n", strings.Join(lines, "\n"))
+}
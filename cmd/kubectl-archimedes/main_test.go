@@ -0,0 +1,211 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	backwoodsv1 "github.com/backwoods-devops/archimedes/api/v1"
+	"github.com/backwoods-devops/archimedes/pkg/render"
+	git "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// run runs a command of the plugin against objects in a fake cluster
+func run(t *testing.T, c client.Client, name string, args ...string) string {
+	t.Helper()
+	o := newOptions(name)
+	o.c = c
+	o.namespace = "default"
+	out := &bytes.Buffer{}
+	err := commands[name].run(o, args, out)
+	if err != nil {
+		t.Fatalf("%s: %s\n%s", name, err, out)
+	}
+	return out.String()
+}
+
+func renderedProperty(name, configmap string, data map[string]string) *backwoodsv1.ArchimedesProperty {
+	return &backwoodsv1.ArchimedesProperty{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: backwoodsv1.ArchimedesPropertySpec{
+			ConfigMapName: configmap,
+			PropertyType:  "kvp",
+		},
+		Status: backwoodsv1.ArchimedesPropertyStatus{
+			Conditions: []metav1.Condition{{
+				Type:               conditionTypeConfigmapCreated,
+				Status:             metav1.ConditionTrue,
+				Reason:             "Updated",
+				LastTransitionTime: metav1.Now(),
+			}},
+			Commit:      "8c89b55db5e89d549997e54dac24672f0dc4d8bc",
+			ContentHash: render.HashData(data),
+			History: []backwoodsv1.RenderHistoryEntry{{
+				Commit:    "8c89b55db5e89d549997e54dac24672f0dc4d8bc",
+				DataHash:  render.HashData(data),
+				Timestamp: metav1.NewTime(time.Now().Add(-time.Hour)),
+				Snapshot:  configmap + "-history-0123456789",
+			}},
+		},
+	}
+}
+
+func TestStatus(t *testing.T) {
+	data := map[string]string{"db": "forest"}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		renderedProperty("in-sync", "in-sync", data),
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "in-sync", Namespace: "default"}, Data: data},
+		renderedProperty("drifted", "drifted", data),
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "drifted", Namespace: "default"}, Data: map[string]string{"db": "edited"}},
+		renderedProperty("missing", "missing", data),
+	).Build()
+
+	out := run(t, c, "status")
+	for _, want := range []string{"in-sync", "drifted", "missing", "8c89b55", "60m ago"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("status does not contain %q\n%s", want, out)
+		}
+	}
+	for name, drift := range map[string]string{"in-sync": driftInSync, "drifted": driftDrifted, "missing": driftMissing} {
+		for _, line := range strings.Split(out, "\n") {
+			if strings.HasPrefix(line, name+" ") && !strings.HasSuffix(strings.TrimSpace(line), drift) {
+				t.Errorf("drift of %s is not %s: %q", name, drift, line)
+			}
+		}
+	}
+
+	out = run(t, c, "status", "drifted")
+	for _, want := range []string{"Drift:", driftDrifted, conditionTypeConfigmapCreated} {
+		if !strings.Contains(out, want) {
+			t.Errorf("status drifted does not contain %q\n%s", want, out)
+		}
+	}
+}
+
+func TestSuspendResume(t *testing.T) {
+	property := renderedProperty("trees-app", "trees-app", nil)
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(property).Build()
+
+	run(t, c, "suspend", "trees-app")
+	err := c.Get(context.Background(), client.ObjectKeyFromObject(property), property)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !property.Spec.Suspend {
+		t.Errorf("property was not suspended")
+	}
+
+	run(t, c, "resume", "trees-app")
+	property = &backwoodsv1.ArchimedesProperty{}
+	err = c.Get(context.Background(), client.ObjectKey{Name: "trees-app", Namespace: "default"}, property)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if property.Spec.Suspend {
+		t.Errorf("property was not resumed")
+	}
+}
+
+func TestHistory(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(renderedProperty("trees-app", "trees-app", nil)).Build()
+	out := run(t, c, "history", "trees-app")
+	for _, want := range []string{"0 (current)", "8c89b55", "trees-app-history-0123456789"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("history does not contain %q\n%s", want, out)
+		}
+	}
+}
+
+func TestExplain(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kubectl-archimedes")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	repo, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ioutil.WriteFile(filepath.Join(dir, "properties.tpl"), []byte("app=trees\ndb.url=jdbc:{{ .db.host }}/{{ .db.name }}\n"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = worktree.Add("properties.tpl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = worktree.Commit("init", &git.CommitOptions{Author: &object.Signature{Name: "test", When: time.Now()}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	head, err := repo.Head()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	property := renderedProperty("trees-app", "trees-app", nil)
+	property.Spec.RepoUrl = dir
+	property.Spec.Revision = head.Name().Short()
+	property.Spec.PropertiesPath = "properties.tpl"
+	property.Spec.SourceConfig = "db:\n  name: forest\n"
+	property.Status.Commit = head.Hash().String()
+	property.Status.Values = []string{"ArchimedesValues/shared"}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		property,
+		&backwoodsv1.ArchimedesValues{
+			ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: "default"},
+			Spec:       backwoodsv1.ArchimedesValuesSpec{Values: "db:\n  host: postgres\n  name: shared\n"},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "trees-app", Namespace: "default"},
+			Data:       map[string]string{"db.url": "jdbc:postgres/forest"},
+		},
+	).Build()
+
+	out := run(t, c, "explain", "trees-app", "db.url")
+	for _, want := range []string{
+		"Value:     jdbc:postgres/forest",
+		"Template:  properties.tpl:2",
+		".db.host = postgres from ArchimedesValues/shared\n",
+		".db.name = forest from sourceConfig (overrides ArchimedesValues/shared)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("explain does not contain %q\n%s", want, out)
+		}
+	}
+
+	out = run(t, c, "explain", "trees-app", "commit")
+	if !strings.Contains(out, "provenance") {
+		t.Errorf("explain commit does not mention provenance\n%s", out)
+	}
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	backwoodsv1 "github.com/backwoods-devops/archimedes/api/v1"
+	"github.com/backwoods-devops/archimedes/pkg/render"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// conditionTypeConfigmapCreated is the condition the operator reports renders with
+	conditionTypeConfigmapCreated = "ConfigmapCreated"
+
+	driftInSync  = "InSync"
+	driftDrifted = "Drifted"
+	driftMissing = "Missing"
+	driftUnknown = "Unknown"
+)
+
+func runStatus(o *options, args []string, stdout io.Writer) error {
+	o.flags.BoolVar(&o.allNamespaces, "all-namespaces", false, "Show the properties of every namespace.")
+	o.flags.BoolVar(&o.allNamespaces, "A", false, "Shorthand for -all-namespaces.")
+	args, err := o.parse(args, 0, 1)
+	if err != nil {
+		return err
+	}
+	c, err := o.client()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	if len(args) == 1 {
+		property := &backwoodsv1.ArchimedesProperty{}
+		err = c.Get(ctx, types.NamespacedName{Name: args[0], Namespace: o.namespace}, property)
+		if err != nil {
+			return err
+		}
+		return describeProperty(ctx, c, property, stdout)
+	}
+
+	list := &backwoodsv1.ArchimedesPropertyList{}
+	opts := []client.ListOption{}
+	if !o.allNamespaces {
+		opts = append(opts, client.InNamespace(o.namespace))
+	}
+	err = c.List(ctx, list, opts...)
+	if err != nil {
+		return err
+	}
+	if len(list.Items) == 0 {
+		fmt.Fprintf(stdout, "No properties found\n")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(stdout, 0, 8, 3, ' ', 0)
+	if o.allNamespaces {
+		fmt.Fprintf(w, "NAMESPACE\t")
+	}
+	fmt.Fprintf(w, "NAME\tREADY\tREASON\tCOMMIT\tLAST SYNC\tDRIFT\n")
+	for i := range list.Items {
+		property := &list.Items[i]
+		ready, reason := "Unknown", ""
+		if condition := meta.FindStatusCondition(property.Status.Conditions, conditionTypeConfigmapCreated); condition != nil {
+			ready, reason = string(condition.Status), condition.Reason
+		}
+		if property.Spec.Suspend {
+			reason = "Suspended"
+		}
+		if o.allNamespaces {
+			fmt.Fprintf(w, "%s\t", property.Namespace)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", property.Name, ready, reason, shortCommit(property.Status.Commit),
+			lastSync(property), drift(ctx, c, property))
+	}
+	return w.Flush()
+}
+
+// describeProperty prints the status of a single property in detail
+func describeProperty(ctx context.Context, c client.Client, property *backwoodsv1.ArchimedesProperty, stdout io.Writer) error {
+	w := tabwriter.NewWriter(stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(w, "Name:\t%s\n", property.Name)
+	fmt.Fprintf(w, "Namespace:\t%s\n", property.Namespace)
+	fmt.Fprintf(w, "Suspended:\t%t\n", property.Spec.Suspend)
+	fmt.Fprintf(w, "ConfigMap:\t%s\n", configMapName(property))
+	fmt.Fprintf(w, "Commit:\t%s\n", valueOrNone(property.Status.Commit))
+	fmt.Fprintf(w, "Last Sync:\t%s\n", lastSync(property))
+	fmt.Fprintf(w, "Drift:\t%s\n", drift(ctx, c, property))
+	if len(property.Status.Values) > 0 {
+		fmt.Fprintf(w, "Values:\t%s\n", strings.Join(property.Status.Values, ", "))
+	}
+	if pending := property.Status.PendingChange; pending != nil {
+		fmt.Fprintf(w, "Pending Change:\tcommit %s\n", shortCommit(pending.Commit))
+		for _, change := range []struct {
+			name string
+			keys []string
+		}{{"Added", pending.Added}, {"Removed", pending.Removed}, {"Changed", pending.Changed}} {
+			if len(change.keys) > 0 {
+				fmt.Fprintf(w, "  %s:\t%s\n", change.name, strings.Join(change.keys, ", "))
+			}
+		}
+	}
+	if property.Status.NextSyncWindow != nil {
+		fmt.Fprintf(w, "Next Sync Window:\t%s\n", property.Status.NextSyncWindow.UTC().Format(time.RFC3339))
+	}
+	err := w.Flush()
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "Conditions:\n")
+	w = tabwriter.NewWriter(stdout, 0, 8, 3, ' ', 0)
+	fmt.Fprintf(w, "  TYPE\tSTATUS\tREASON\tAGE\tMESSAGE\n")
+	for _, condition := range property.Status.Conditions {
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\n", condition.Type, condition.Status, condition.Reason,
+			age(condition.LastTransitionTime), condition.Message)
+	}
+	return w.Flush()
+}
+
+// drift compares the live ConfigMap of a property with the content the operator last wrote
+func drift(ctx context.Context, c client.Client, property *backwoodsv1.ArchimedesProperty) string {
+	if property.Status.ContentHash == "" {
+		return driftUnknown
+	}
+	configmap := &corev1.ConfigMap{}
+	err := c.Get(ctx, types.NamespacedName{Name: configMapName(property), Namespace: property.Namespace}, configmap)
+	if apierrors.IsNotFound(err) {
+		return driftMissing
+	}
+	if err != nil {
+		return driftUnknown
+	}
+	if render.HashData(configmap.Data) != property.Status.ContentHash {
+		return driftDrifted
+	}
+	return driftInSync
+}
+
+// configMapName returns the name of the ConfigMap currently holding the properties
+func configMapName(property *backwoodsv1.ArchimedesProperty) string {
+	if property.Status.ConfigMapName != "" {
+		return property.Status.ConfigMapName
+	}
+	return property.Spec.ConfigMapName
+}
+
+// lastSync returns how long ago the ConfigMap of a property was last written
+func lastSync(property *backwoodsv1.ArchimedesProperty) string {
+	if len(property.Status.History) == 0 {
+		return "<none>"
+	}
+	return age(property.Status.History[0].Timestamp) + " ago"
+}
+
+func age(t metav1.Time) string {
+	if t.IsZero() {
+		return "<unknown>"
+	}
+	return duration.HumanDuration(time.Since(t.Time))
+}
+
+func shortCommit(commit string) string {
+	if len(commit) > 7 {
+		return commit[:7]
+	}
+	return valueOrNone(commit)
+}
+
+func valueOrNone(value string) string {
+	if value == "" {
+		return "<none>"
+	}
+	return value
+}
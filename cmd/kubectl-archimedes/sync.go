@@ -0,0 +1,153 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	backwoodsv1 "github.com/backwoods-devops/archimedes/api/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// reconcileRequestAnnotation forces a re-fetch and re-render of a property when its value changes
+	reconcileRequestAnnotation = "reconcile.archimedes.backwoods-devops.io/requestedAt"
+
+	// syncPollInterval is how often a property is checked for the completion of a sync
+	syncPollInterval = time.Second
+)
+
+// heldConditions are set by the operator when a render was not written to the ConfigMap
+var heldConditions = []string{"AwaitingApproval", "SyncWindowClosed", "DryRun"}
+
+func runSync(o *options, args []string, stdout io.Writer) error {
+	timeout := o.flags.Duration("timeout", 2*time.Minute, "How long to wait for the reconcile to complete.")
+	noWait := o.flags.Bool("no-wait", false, "Request the reconcile without waiting for it to complete.")
+	args, err := o.parse(args, 1, 1)
+	if err != nil {
+		return err
+	}
+	c, err := o.client()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	property := &backwoodsv1.ArchimedesProperty{}
+	err = c.Get(ctx, types.NamespacedName{Name: args[0], Namespace: o.namespace}, property)
+	if err != nil {
+		return err
+	}
+	if property.Spec.Suspend {
+		return fmt.Errorf("property %s is suspended, resume it first", property.Name)
+	}
+
+	requestedAt := time.Now().UTC().Format(time.RFC3339Nano)
+	patch := client.MergeFrom(property.DeepCopy())
+	if property.Annotations == nil {
+		property.Annotations = map[string]string{}
+	}
+	property.Annotations[reconcileRequestAnnotation] = requestedAt
+	err = c.Patch(ctx, property, patch)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "Requested a reconcile of property %s at %s\n", property.Name, requestedAt)
+	if *noWait {
+		return nil
+	}
+
+	err = wait.PollImmediate(syncPollInterval, *timeout, func() (bool, error) {
+		err := c.Get(ctx, client.ObjectKeyFromObject(property), property)
+		if err != nil {
+			return false, err
+		}
+		return property.Status.LastHandledReconcileAt == requestedAt, nil
+	})
+	if errors.Is(err, wait.ErrWaitTimeout) {
+		return fmt.Errorf("the operator did not handle the reconcile within %s", *timeout)
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, conditionType := range heldConditions {
+		if condition := meta.FindStatusCondition(property.Status.Conditions, conditionType); condition != nil && condition.Status == metav1.ConditionTrue {
+			fmt.Fprintf(stdout, "%s: %s\n", conditionType, condition.Message)
+		}
+	}
+	condition := meta.FindStatusCondition(property.Status.Conditions, conditionTypeConfigmapCreated)
+	if condition == nil {
+		return fmt.Errorf("property %s has no %s condition", property.Name, conditionTypeConfigmapCreated)
+	}
+	if condition.Status != metav1.ConditionTrue {
+		return fmt.Errorf("sync failed with %s: %s", condition.Reason, condition.Message)
+	}
+	fmt.Fprintf(stdout, "Synced property %s at commit %s: %s\n", property.Name, shortCommit(property.Status.Commit), condition.Message)
+	return nil
+}
+
+func runSuspend(o *options, args []string, stdout io.Writer) error {
+	return setSuspend(o, args, stdout, true)
+}
+
+func runResume(o *options, args []string, stdout io.Writer) error {
+	return setSuspend(o, args, stdout, false)
+}
+
+// setSuspend suspends or resumes the reconciliation of a property
+func setSuspend(o *options, args []string, stdout io.Writer, suspend bool) error {
+	args, err := o.parse(args, 1, 1)
+	if err != nil {
+		return err
+	}
+	c, err := o.client()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	property := &backwoodsv1.ArchimedesProperty{}
+	err = c.Get(ctx, types.NamespacedName{Name: args[0], Namespace: o.namespace}, property)
+	if err != nil {
+		return err
+	}
+	action := "resumed"
+	if suspend {
+		action = "suspended"
+	}
+	if property.Spec.Suspend == suspend {
+		fmt.Fprintf(stdout, "Property %s is already %s\n", property.Name, action)
+		return nil
+	}
+	patch := client.MergeFrom(property.DeepCopy())
+	property.Spec.Suspend = suspend
+	err = c.Patch(ctx, property, patch)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "Property %s %s\n", property.Name, action)
+	return nil
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"strings"
+
+	backwoodsv1 "github.com/backwoods-devops/archimedes/api/v1"
+	"github.com/backwoods-devops/archimedes/pkg/render"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	approvalPolicyManual = "Manual"
+
+	// approvedCommitAnnotation approves applying a pending commit when set to its hash
+	approvedCommitAnnotation = "archimedes.backwoods-devops.io/approved-commit"
+
+	// minApprovedCommitLength is the shortest abbreviated hash accepted as an approval
+	minApprovedCommitLength = 7
+)
+
+// needsApproval reports whether applying a commit to a property waits for approval. Only
+// new commits are held. Changes to the values are applied right away unless a new commit is
+// waiting, then they wait with it as only the latest commit of the revision is fetched.
+func needsApproval(instance *backwoodsv1.ArchimedesProperty, commit string) bool {
+	if instance.Spec.ApprovalPolicy != approvalPolicyManual {
+		return false
+	}
+	if instance.Status.Commit == "" || instance.Status.Commit == commit {
+		return false
+	}
+	approved := instance.Annotations[approvedCommitAnnotation]
+	return len(approved) < minApprovedCommitLength || !strings.HasPrefix(commit, approved)
+}
+
+// pendingChange compares the data rendered for a commit with the live ConfigMap of a property
+func (r *ArchimedesPropertyReconciler) pendingChange(ctx context.Context, instance *backwoodsv1.ArchimedesProperty, commit string, data map[string]string) (*backwoodsv1.PendingChange, error) {
+	live := &corev1.ConfigMap{}
+	name := instance.Status.ConfigMapName
+	if name == "" {
+		name = instance.Spec.ConfigMapName
+	}
+	err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: instance.Namespace}, live)
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+
+	diff := render.DiffData(live.Data, data)
+	return &backwoodsv1.PendingChange{
+		Commit:  commit,
+		Added:   diff.Added,
+		Removed: diff.Removed,
+		Changed: diff.Changed,
+	}, nil
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNeedsApproval(t *testing.T) {
+	const commit = "0123456789abcdef"
+	tests := []struct {
+		name     string
+		policy   string
+		applied  string
+		approved string
+		want     bool
+	}{
+		{name: "automatic", policy: "Automatic", applied: "fedcba9876543210"},
+		{name: "first render", policy: approvalPolicyManual},
+		{name: "applied commit", policy: approvalPolicyManual, applied: commit},
+		{name: "new commit", policy: approvalPolicyManual, applied: "fedcba9876543210", want: true},
+		{name: "approved", policy: approvalPolicyManual, applied: "fedcba9876543210", approved: commit},
+		{name: "approved abbreviated", policy: approvalPolicyManual, applied: "fedcba9876543210", approved: "0123456"},
+		{name: "abbreviation too short", policy: approvalPolicyManual, applied: "fedcba9876543210", approved: "012345", want: true},
+		{name: "other commit approved", policy: approvalPolicyManual, applied: "fedcba9876543210", approved: "1234567", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := testProperty("trees")
+			instance.Spec.ApprovalPolicy = tt.policy
+			instance.Status.Commit = tt.applied
+			if tt.approved != "" {
+				instance.Annotations = map[string]string{approvedCommitAnnotation: tt.approved}
+			}
+			if got := needsApproval(instance, commit); got != tt.want {
+				t.Errorf("needsApproval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPendingChange(t *testing.T) {
+	live := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "trees", Namespace: "default"},
+		Data:       map[string]string{"db.url": "postgres://forest", "db.port": "5432", "team": "trees"},
+	}
+	r := newTestReconciler(t, live)
+	pending, err := r.pendingChange(context.Background(), testProperty("trees"), "0123456", map[string]string{"db.url": "postgres://meadow", "db.port": "5432", "region": "north"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pending.Commit != "0123456" || !reflect.DeepEqual(pending.Added, []string{"region"}) ||
+		!reflect.DeepEqual(pending.Removed, []string{"team"}) || !reflect.DeepEqual(pending.Changed, []string{"db.url"}) {
+		t.Errorf("pendingChange() = %+v", pending)
+	}
+}
@@ -17,38 +17,67 @@ limitations under the License.
 package controllers
 
 import (
-	"bufio"
-	"bytes"
 	"context"
 	"fmt"
-	"html/template"
-	"io/ioutil"
-	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
 	backwoodsv1 "github.com/backwoods-devops/archimedes/api/v1"
-	git "github.com/go-git/go-git/v5"
-	"github.com/go-git/go-git/v5/plumbing"
-	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/backwoods-devops/archimedes/pkg/render"
 	"github.com/go-logr/logr"
-	"gopkg.in/yaml.v2"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 const (
-	conditionTypeConfigmapCreated = "ConfigmapCreated"
-	conditionReasonCreated        = "Created"
-	conditionReasonCreateFailed   = "CreateFailed"
-	conditionReasonUpdated        = "Updated"
-	conditionReasonUpdateFailed   = "UpdateFailed"
-	conditionReasonMergeFailed    = "MergeFailed"
+	// sourceRefIndex indexes properties by the name of the ArchimedesSource they reference
+	sourceRefIndex = ".spec.sourceRef.name"
+	// templateConfigMapIndex indexes properties by the name of the ConfigMap holding their template
+	templateConfigMapIndex = ".spec.templateConfigMap.name"
+
+	// sourceNotReadyRequeue is how long a property waits for its source to be fetched
+	sourceNotReadyRequeue = 10 * time.Second
+
+	// reconcileRequestAnnotation forces a re-fetch and re-render of a property when its value changes
+	reconcileRequestAnnotation = "reconcile.archimedes.backwoods-devops.io/requestedAt"
+
+	conditionTypeConfigmapCreated    = "ConfigmapCreated"
+	conditionTypeSuspended           = "Suspended"
+	conditionTypeAwaitingApproval    = "AwaitingApproval"
+	conditionTypeSyncWindowClosed    = "SyncWindowClosed"
+	conditionTypeProvenanceCollision = "ProvenanceCollision"
+	conditionTypeDryRun              = "DryRun"
+	conditionReasonCreated           = "Created"
+	conditionReasonCreateFailed      = "CreateFailed"
+	conditionReasonUpdated           = "Updated"
+	conditionReasonUpdateFailed      = "UpdateFailed"
+	conditionReasonMergeFailed       = "MergeFailed"
+	conditionReasonFetchFailed       = "FetchFailed"
+	conditionReasonLimitExceeded     = "RenderLimitExceeded"
+	conditionReasonKeysCollided      = "KeysCollided"
+	conditionReasonRolloutFailed     = "RolloutFailed"
+	conditionReasonRolledBack        = "RolledBack"
+	conditionReasonRollbackFailed    = "RollbackFailed"
+	conditionReasonSuspended         = "Suspended"
+	conditionReasonResumed           = "Resumed"
+	conditionReasonPendingCommit     = "PendingCommit"
+	conditionReasonOutsideSyncWindow = "OutsideSyncWindow"
+	conditionReasonInvalidSyncWindow = "InvalidSyncWindow"
+	conditionReasonSourceNotReady    = "SourceNotReady"
+	conditionReasonRendered          = "Rendered"
 )
 
 // ArchimedesPropertyReconciler reconciles a ArchimedesProperty object
@@ -56,14 +85,49 @@ type ArchimedesPropertyReconciler struct {
 	client.Client
 	Log    logr.Logger
 	Scheme *runtime.Scheme
+	// APIReader reads objects for template lookups directly from the API server
+	APIReader client.Reader
+
+	// RenderTimeout is the longest a single template render may run
+	RenderTimeout time.Duration
+	// MaxRenderSize is the maximum size in bytes of a rendered template
+	MaxRenderSize int
+	// MaxIncludeDepth is the maximum nesting of include and template calls in a template
+	MaxIncludeDepth int
+	// LookupNamespaces are the namespaces template lookups may read from besides
+	// the property's own, "*" allows every namespace
+	LookupNamespaces []string
+	// ClusterDomain is the DNS domain of the cluster used to build service hosts
+	ClusterDomain string
+	// SyncWindows limit when changes may be written to the ConfigMaps of every property
+	SyncWindows []backwoodsv1.SyncWindow
+	// Sources is the cache of the repos fetched by ArchimedesSources
+	Sources *SourceCache
+	// ClusterValues enables ClusterArchimedesValues, which needs access to cluster-scoped
+	// resources
+	ClusterValues bool
+	// HTTP downloads the templates of properties with the http source type, a source without
+	// a cache of the downloaded templates is used when nil
+	HTTP *render.HTTPSource
+	// LocalPaths are the directories properties with the path source type may read from,
+	// the path source type is disabled when empty
+	LocalPaths []string
+	// HTTPURLPrefixes are the urls properties with the http source type may download from,
+	// see render.URLHasPrefix, the http source type is disabled when empty
+	HTTPURLPrefixes []string
 }
 
 //+kubebuilder:rbac:groups=archimedes.backwoods-devops.io,resources=archimedesproperties,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=archimedes.backwoods-devops.io,resources=archimedesproperties/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=archimedes.backwoods-devops.io,resources=archimedesproperties/finalizers,verbs=update
+//+kubebuilder:rbac:groups=archimedes.backwoods-devops.io,resources=archimedessources,verbs=get;list;watch
+//+kubebuilder:rbac:groups=archimedes.backwoods-devops.io,resources=archimedesvalues;clusterarchimedesvalues,verbs=get;list;watch
 //+kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=core;coordination.k8s.io,resources=configmaps;leases,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+//+kubebuilder:rbac:groups=core,resources=services;namespaces,verbs=get;list;watch
+//+kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch
+//+kubebuilder:rbac:groups=apps,resources=deployments;statefulsets;daemonsets,verbs=get;list;watch;patch
 
 //Reconcile is part of the main kubernetes reconciliation loop
 func (r *ArchimedesPropertyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -84,58 +148,233 @@ func (r *ArchimedesPropertyReconciler) Reconcile(ctx context.Context, req ctrl.R
 		return ctrl.Result{}, err
 	}
 
-	commit, propTemplate, err := gitConfig(instance)
+	if instance.Spec.Suspend {
+		log.Info("Reconciliation is suspended")
+		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+			Type:               conditionTypeSuspended,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: instance.GetGeneration(),
+			Reason:             conditionReasonSuspended,
+			Message:            "Reconciliation is suspended",
+		})
+		err = r.Status().Update(ctx, instance)
+		if err != nil {
+			log.Error(err, "Could not update status")
+		}
+		return ctrl.Result{}, nil
+	}
+	if meta.IsStatusConditionTrue(instance.Status.Conditions, conditionTypeSuspended) {
+		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+			Type:               conditionTypeSuspended,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: instance.GetGeneration(),
+			Reason:             conditionReasonResumed,
+			Message:            "Reconciliation was resumed",
+		})
+	}
+	if requestedAt, ok := instance.Annotations[reconcileRequestAnnotation]; ok {
+		instance.Status.LastHandledReconcileAt = requestedAt
+	}
+
+	if instance.Spec.Rollback != nil {
+		return r.rollback(ctx, log, instance)
+	}
+
+	src, err := r.fetchTemplate(ctx, instance)
+	if isSourceNotReady(err) {
+		log.Info("Waiting for the source of the property", "reason", err.Error())
+		r.updateConditions(ctx, log, instance, conditionReasonSourceNotReady, err.Error(), metav1.ConditionFalse)
+		return ctrl.Result{RequeueAfter: sourceNotReadyRequeue}, nil
+	}
 	if err != nil {
 		log.Error(err, "Problem reading property template repo")
+		r.updateConditions(ctx, log, instance, conditionReasonFetchFailed, err.Error(), metav1.ConditionFalse)
+		return ctrl.Result{}, err
 	}
 
-	t := template.Must(template.New("properties").Parse(string(propTemplate)))
-	sourceConfig := instance.Spec.SourceConfig
-	cg := map[string]interface{}{}
-	err = yaml.Unmarshal([]byte(sourceConfig), &cg)
+	cg, valuesNames, err := render.ResolveValues(ctx, r.Client, instance, render.ValuesOptions{ClusterValues: r.ClusterValues})
 	if err != nil {
-		panic(err)
+		log.Error(err, "Could not resolve values")
+		r.updateConditions(ctx, log, instance, conditionReasonMergeFailed, err.Error(), metav1.ConditionFalse)
+		return ctrl.Result{}, err
 	}
+	instance.Status.Values = valuesNames
 
-	var tpl bytes.Buffer
-	t.Execute(&tpl, cg)
+	renderOpts := render.RenderOptions{
+		Limits: render.Limits{
+			Timeout:         r.RenderTimeout,
+			MaxOutputBytes:  r.MaxRenderSize,
+			MaxIncludeDepth: r.MaxIncludeDepth,
+		},
+		Funcs: r.lookup(ctx, instance).Funcs(),
+	}
+	result, err := render.Render(instance, src, cg, renderOpts)
+	if err != nil {
+		log.Error(err, "Could not merge property template")
+		reason := conditionReasonMergeFailed
+		if render.IsLimitError(err) {
+			reason = conditionReasonLimitExceeded
+		}
+		r.updateConditions(ctx, log, instance, reason, err.Error(), metav1.ConditionFalse)
+		return ctrl.Result{}, err
+	}
+	valuesHash := result.ValuesHash
+
+	if collisions := result.Collisions; len(collisions) > 0 {
+		log.Info("Template keys collide with provenance keys", "keys", collisions)
+		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+			Type:               conditionTypeProvenanceCollision,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: instance.GetGeneration(),
+			Reason:             conditionReasonKeysCollided,
+			Message:            fmt.Sprintf("Template keys %s collide with provenance keys and were kept", strings.Join(collisions, ", ")),
+		})
+	} else {
+		meta.RemoveStatusCondition(&instance.Status.Conditions, conditionTypeProvenanceCollision)
+	}
+
+	configmap := render.ConfigMap(instance, result)
 
-	var data = make(map[string]string)
-	data["commit"] = commit
-	data["repoUrl"] = instance.Spec.RepoUrl
-	data["revision"] = instance.Spec.Revision
-	data["path"] = instance.Spec.PropertiesPath
+	if instance.Spec.DryRun {
+		return r.dryRun(ctx, log, instance, src.Commit.Hash, configmap)
+	}
+	meta.RemoveStatusCondition(&instance.Status.Conditions, conditionTypeDryRun)
+
+	if needsApproval(instance, src.Commit.Hash) {
+		pending, err := r.pendingChange(ctx, instance, src.Commit.Hash, configmap.Data)
+		if err != nil {
+			log.Error(err, "Could not compare pending commit with the configmap")
+			return ctrl.Result{}, err
+		}
+		log.Info("Commit is waiting for approval", "commit", src.Commit.Hash)
+		instance.Status.PendingChange = pending
+		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+			Type:               conditionTypeAwaitingApproval,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: instance.GetGeneration(),
+			Reason:             conditionReasonPendingCommit,
+			Message:            fmt.Sprintf("Commit %s is waiting for the %s annotation", src.Commit.Hash, approvedCommitAnnotation),
+		})
+		err = r.Status().Update(ctx, instance)
+		if err != nil {
+			log.Error(err, "Could not update status")
+		}
+		return ctrl.Result{RequeueAfter: pollInterval(instance)}, nil
+	}
+	meta.RemoveStatusCondition(&instance.Status.Conditions, conditionTypeAwaitingApproval)
 
-	switch pt := instance.Spec.PropertyType; pt {
-	case "kvp":
-		scanner := bufio.NewScanner(strings.NewReader(strings.TrimSpace(tpl.String())))
-		for scanner.Scan() {
-			s := strings.Split(scanner.Text(), "=")
-			data[s[0]] = s[1]
+	windows, err := parseSyncWindows(append(append([]backwoodsv1.SyncWindow{}, r.SyncWindows...), instance.Spec.SyncWindows...))
+	if err != nil {
+		log.Error(err, "Invalid sync windows")
+		r.updateConditions(ctx, log, instance, conditionReasonInvalidSyncWindow, err.Error(), metav1.ConditionFalse)
+		return ctrl.Result{}, nil
+	}
+	if render.HashData(configmap.Data) != instance.Status.ContentHash {
+		now := time.Now()
+		next, err := nextSyncTime(windows, now)
+		if err != nil {
+			log.Error(err, "Invalid sync windows")
+			r.updateConditions(ctx, log, instance, conditionReasonInvalidSyncWindow, err.Error(), metav1.ConditionFalse)
+			return ctrl.Result{}, nil
 		}
-	case "key":
-		if instance.Spec.KeyName != "" {
-			data[instance.Spec.KeyName] = strings.TrimSpace(tpl.String())
-		} else {
-			err := errors.NewBadRequest("Missing keyName")
-			log.Error(err, "Could not create Kubernetes configmap")
+		if next.After(now) {
+			pending, err := r.pendingChange(ctx, instance, src.Commit.Hash, configmap.Data)
+			if err != nil {
+				log.Error(err, "Could not compare pending change with the configmap")
+				return ctrl.Result{}, err
+			}
+			log.Info("Change is held until the next sync window", "next", next)
+			instance.Status.PendingChange = pending
+			instance.Status.NextSyncWindow = &metav1.Time{Time: next}
+			meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+				Type:               conditionTypeSyncWindowClosed,
+				Status:             metav1.ConditionTrue,
+				ObservedGeneration: instance.GetGeneration(),
+				Reason:             conditionReasonOutsideSyncWindow,
+				Message:            fmt.Sprintf("Changes are held until %s", next.UTC().Format(time.RFC3339)),
+			})
+			err = r.Status().Update(ctx, instance)
+			if err != nil {
+				log.Error(err, "Could not update status")
+			}
+			return ctrl.Result{RequeueAfter: next.Sub(now)}, nil
 		}
-	default:
-		err := errors.NewBadRequest("Invalid PropertyType")
-		log.Error(err, "Valid types (kvp, key).")
 	}
+	instance.Status.PendingChange = nil
+	instance.Status.NextSyncWindow = nil
+	meta.RemoveStatusCondition(&instance.Status.Conditions, conditionTypeSyncWindowClosed)
 
-	configmap, err := newConfigMap(instance, data)
+	err = r.applyConfigMap(ctx, log, instance, configmap)
 	if err != nil {
-		log.Error(err, "Could not create Kubernetes configmap")
-		r.updateConditions(ctx, log, instance, conditionReasonCreateFailed, err.Error(), metav1.ConditionFalse)
 		return ctrl.Result{}, err
 	}
-	// Set Archimedes Property instance as the owner and controller
-	err = ctrl.SetControllerReference(instance, configmap, r.Scheme)
+	err = r.applyOutputs(ctx, log, instance, src, result, renderOpts)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
+	instance.Status.Commit = src.Commit.Hash
+
+	err = r.recordHistory(ctx, instance, configmap, src.Commit.Hash, valuesHash)
+	if err != nil {
+		log.Error(err, "Could not record render history")
+	}
+
+	r.updateConditions(ctx, log, instance, conditionReasonUpdated, "Configmap was updated", metav1.ConditionTrue)
+	return ctrl.Result{RequeueAfter: pollInterval(instance)}, nil
+}
+
+// dryRun records the changes the render of a property would make to its live ConfigMap
+// in status.pendingChange without writing the ConfigMap
+func (r *ArchimedesPropertyReconciler) dryRun(ctx context.Context, log logr.Logger, instance *backwoodsv1.ArchimedesProperty, commit string, configmap *corev1.ConfigMap) (ctrl.Result, error) {
+	pending, err := r.pendingChange(ctx, instance, commit, configmap.Data)
+	if err != nil {
+		log.Error(err, "Could not compare dry run with the configmap")
+		return ctrl.Result{}, err
+	}
+	log.Info("Rendered dry run", "added", len(pending.Added), "removed", len(pending.Removed), "changed", len(pending.Changed))
+	instance.Status.PendingChange = pending
+	meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+		Type:               conditionTypeDryRun,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: instance.GetGeneration(),
+		Reason:             conditionReasonRendered,
+		Message: fmt.Sprintf("Commit %s would add %d, remove %d and change %d keys of configmap %s",
+			commit, len(pending.Added), len(pending.Removed), len(pending.Changed), configmap.Name),
+	})
+	err = r.Status().Update(ctx, instance)
+	if err != nil {
+		log.Error(err, "Could not update status")
+	}
+	return ctrl.Result{}, nil
+}
+
+// applyConfigMap creates or updates the ConfigMap of a property and restarts its rollout
+// targets when the content changed. Failures are recorded in the property's conditions.
+func (r *ArchimedesPropertyReconciler) applyConfigMap(ctx context.Context, log logr.Logger, instance *backwoodsv1.ArchimedesProperty, configmap *corev1.ConfigMap) error {
+	reason, err := r.writeConfigMap(ctx, log, instance, configmap)
+	if err != nil {
+		r.updateConditions(ctx, log, instance, reason, err.Error(), metav1.ConditionFalse)
+	}
+	return err
+}
+
+// writeConfigMap creates or updates the ConfigMap of a property and restarts its rollout
+// targets when the content changed, recording the ConfigMap in the status of the property.
+// It returns the reason of a failure.
+func (r *ArchimedesPropertyReconciler) writeConfigMap(ctx context.Context, log logr.Logger, instance *backwoodsv1.ArchimedesProperty, configmap *corev1.ConfigMap) (string, error) {
+	hash := render.HashData(configmap.Data)
+	if instance.Spec.Immutable {
+		// Immutable ConfigMaps are addressed by their content and never updated
+		configmap.Name = versionedConfigMapName(instance.Spec.ConfigMapName, hash)
+		configmap.Labels[propertyLabel] = labelValue(instance.Name)
+		immutable := true
+		configmap.Immutable = &immutable
+	}
+	// Set Archimedes Property instance as the owner and controller
+	err := ctrl.SetControllerReference(instance, configmap, r.Scheme)
+	if err != nil {
+		return conditionReasonCreateFailed, err
+	}
 	// Check if this ConfigMap already exists
 	found := &corev1.ConfigMap{}
 	err = r.Get(ctx, types.NamespacedName{Name: configmap.Name, Namespace: configmap.Namespace}, found)
@@ -144,57 +383,73 @@ func (r *ArchimedesPropertyReconciler) Reconcile(ctx context.Context, req ctrl.R
 		err = r.Create(ctx, configmap)
 		if err != nil {
 			log.Error(err, "Could not create configmap")
-			r.updateConditions(ctx, log, instance, conditionReasonCreateFailed, err.Error(), metav1.ConditionFalse)
-			return ctrl.Result{}, err
+			return conditionReasonCreateFailed, err
 		}
 	} else if err != nil {
 		log.Error(err, "Could not create configmap")
-		r.updateConditions(ctx, log, instance, conditionReasonCreateFailed, err.Error(), metav1.ConditionFalse)
-		return ctrl.Result{}, err
+		return conditionReasonCreateFailed, err
 	}
-	log.Info("Updating a configmap", "Configmap.Namespace", configmap.Namespace, "Configmap.Name", configmap.Name)
-	err = r.Update(ctx, configmap)
-	if err != nil {
-		log.Error(err, "Could not update configmap")
-		r.updateConditions(ctx, log, instance, conditionReasonUpdateFailed, err.Error(), metav1.ConditionFalse)
-		return ctrl.Result{}, err
+	if !instance.Spec.Immutable {
+		log.Info("Updating a configmap", "Configmap.Namespace", configmap.Namespace, "Configmap.Name", configmap.Name)
+		err = r.Update(ctx, configmap)
+		if err != nil {
+			log.Error(err, "Could not update configmap")
+			return conditionReasonUpdateFailed, err
+		}
 	}
-	r.updateConditions(ctx, log, instance, conditionReasonUpdated, "Configmap was updated", metav1.ConditionTrue)
-	return ctrl.Result{}, nil
-}
-
-func newConfigMap(r *backwoodsv1.ArchimedesProperty, data map[string]string) (*corev1.ConfigMap, error) {
-	labels := map[string]string{
-		"created-by": "archimedes-property-operator",
+	previousName := instance.Status.ConfigMapName
+	instance.Status.ConfigMapName = configmap.Name
+
+	// Restart the consuming workloads when the content changed since the last update. The
+	// name of an immutable ConfigMap changes with its content, the workloads are moved to
+	// its first version as well.
+	changed := instance.Status.ContentHash != "" && instance.Status.ContentHash != hash
+	if instance.Spec.Immutable {
+		changed = previousName != configmap.Name
 	}
-	for k, v := range r.ObjectMeta.Labels {
-		labels[k] = v
+	if changed && len(instance.Spec.RolloutTargets) > 0 {
+		restarted, err := r.rollout(ctx, instance, hash, previousName)
+		if err != nil {
+			log.Error(err, "Could not restart rollout targets", "restarted", restarted)
+			return conditionReasonRolloutFailed, err
+		}
+		log.Info("Restarted rollout targets", "workloads", restarted)
+		instance.Status.RestartedWorkloads = restarted
 	}
-	annotations := map[string]string{}
-	for k, v := range r.ObjectMeta.Annotations {
-		annotations[k] = v
+	instance.Status.ContentHash = hash
+
+	if instance.Spec.Immutable {
+		pruned, err := r.pruneConfigMapVersions(ctx, instance)
+		if err != nil {
+			log.Error(err, "Could not prune previous configmaps", "pruned", pruned)
+		} else if len(pruned) > 0 {
+			log.Info("Pruned previous configmaps", "configmaps", pruned)
+		}
 	}
+	return "", nil
+}
 
-	return &corev1.ConfigMap{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:        r.Spec.ConfigMapName,
-			Namespace:   r.Namespace,
-			Labels:      labels,
-			Annotations: annotations,
-		},
-		Data: data,
-	}, nil
+// lookup returns the cluster lookups available to the templates of a property
+func (r *ArchimedesPropertyReconciler) lookup(ctx context.Context, instance *backwoodsv1.ArchimedesProperty) *render.ClusterLookup {
+	var reader client.Reader = r.Client
+	if r.APIReader != nil {
+		reader = r.APIReader
+	}
+	clusterDomain := r.ClusterDomain
+	if clusterDomain == "" {
+		clusterDomain = "cluster.local"
+	}
+	return render.NewClusterLookup(ctx, reader, instance.Namespace, r.LookupNamespaces, clusterDomain)
 }
 
 func (r *ArchimedesPropertyReconciler) updateConditions(ctx context.Context, log logr.Logger, instance *backwoodsv1.ArchimedesProperty, reason, message string, status metav1.ConditionStatus) {
-	instance.Status.Conditions = []metav1.Condition{{
+	meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
 		Type:               conditionTypeConfigmapCreated,
 		Status:             status,
 		ObservedGeneration: instance.GetGeneration(),
-		LastTransitionTime: metav1.NewTime(time.Now()),
 		Reason:             reason,
 		Message:            message,
-	}}
+	})
 	err := r.Status().Update(ctx, instance)
 	if err != nil {
 		log.Error(err, "Could not update status")
@@ -203,59 +458,162 @@ func (r *ArchimedesPropertyReconciler) updateConditions(ctx context.Context, log
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *ArchimedesPropertyReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	err := mgr.GetFieldIndexer().IndexField(context.Background(), &backwoodsv1.ArchimedesProperty{}, sourceRefIndex, func(obj client.Object) []string {
+		property := obj.(*backwoodsv1.ArchimedesProperty)
+		if property.Spec.SourceRef == nil {
+			return nil
+		}
+		return []string{property.Spec.SourceRef.Name}
+	})
+	if err != nil {
+		return err
+	}
+	err = mgr.GetFieldIndexer().IndexField(context.Background(), &backwoodsv1.ArchimedesProperty{}, templateConfigMapIndex, func(obj client.Object) []string {
+		property := obj.(*backwoodsv1.ArchimedesProperty)
+		if property.Spec.SourceType != backwoodsv1.SourceTypeConfigMap || property.Spec.TemplateConfigMap == nil {
+			return nil
+		}
+		return []string{property.Spec.TemplateConfigMap.Name}
+	})
+	if err != nil {
+		return err
+	}
+
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&backwoodsv1.ArchimedesProperty{}).
-		Complete(r)
+		Watches(&source.Kind{Type: &backwoodsv1.ArchimedesSource{}},
+			handler.EnqueueRequestsFromMapFunc(r.propertiesForSource),
+			builder.WithPredicates(sourceCommitChanged)).
+		Watches(&source.Kind{Type: &backwoodsv1.ArchimedesValues{}},
+			handler.EnqueueRequestsFromMapFunc(r.propertiesForValues),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&source.Kind{Type: &corev1.ConfigMap{}},
+			handler.EnqueueRequestsFromMapFunc(r.propertiesForTemplateConfigMap),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}))
+	if r.ClusterValues {
+		b = b.Watches(&source.Kind{Type: &backwoodsv1.ClusterArchimedesValues{}},
+			handler.EnqueueRequestsFromMapFunc(r.propertiesForClusterValues),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+			Watches(&source.Kind{Type: &corev1.Namespace{}},
+				handler.EnqueueRequestsFromMapFunc(r.propertiesForNamespace),
+				builder.WithPredicates(predicate.LabelChangedPredicate{}))
+	}
+	return b.Complete(r)
 }
 
-func gitConfig(r *backwoodsv1.ArchimedesProperty) (string, []byte, error) {
-
-	dir, err := ioutil.TempDir("/tmp", "archimedes_")
-	if err != nil {
-		return "", nil, err
-	}
-	defer os.RemoveAll(dir)
+// propertiesForSource returns a request for every property referencing a source
+func (r *ArchimedesPropertyReconciler) propertiesForSource(obj client.Object) []reconcile.Request {
+	return r.propertiesIn(client.InNamespace(obj.GetNamespace()), client.MatchingFields{sourceRefIndex: obj.GetName()})
+}
 
-	user := os.Getenv("USER")
-	pass := os.Getenv("PASS")
-	var certs []byte
+// propertiesForTemplateConfigMap returns a request for every property reading its template
+// from a ConfigMap
+func (r *ArchimedesPropertyReconciler) propertiesForTemplateConfigMap(obj client.Object) []reconcile.Request {
+	return r.propertiesIn(client.InNamespace(obj.GetNamespace()), client.MatchingFields{templateConfigMapIndex: obj.GetName()})
+}
 
-	if _, err := os.Stat(r.Spec.CAPath); err == nil {
-		certs, err = ioutil.ReadFile(r.Spec.CAPath)
-		if err != nil {
-			return "", nil, err
+// sourceCommitChanged passes the events of sources that fetched a different commit
+var sourceCommitChanged = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldSource, ok := e.ObjectOld.(*backwoodsv1.ArchimedesSource)
+		if !ok {
+			return false
+		}
+		newSource, ok := e.ObjectNew.(*backwoodsv1.ArchimedesSource)
+		if !ok {
+			return false
 		}
+		return newSource.Status.Commit != nil && (oldSource.Status.Commit == nil || oldSource.Status.Commit.Hash != newSource.Status.Commit.Hash)
+	},
+}
+
+// fetchTemplate reads the template of a property from the source of its source type: the
+// working copy of its ArchimedesSource or a clone of its repo for git, its url, ConfigMap
+// or mounted directory otherwise
+func (r *ArchimedesPropertyReconciler) fetchTemplate(ctx context.Context, instance *backwoodsv1.ArchimedesProperty) (*render.Template, error) {
+	source, err := r.templateSource(ctx, instance)
+	if err != nil {
+		return nil, err
 	}
+	return source.Fetch(ctx, instance)
+}
 
-	repo, err := git.PlainClone(dir, false, &git.CloneOptions{
-		URL: r.Spec.RepoUrl,
-		Auth: &http.BasicAuth{
-			Username: user,
-			Password: pass,
+// templateSource returns the source the template of a property is fetched from
+func (r *ArchimedesPropertyReconciler) templateSource(ctx context.Context, instance *backwoodsv1.ArchimedesProperty) (render.Source, error) {
+	opts := render.SourceOptions{
+		Reader: r.Client,
+		LocalPath: func(path string) error {
+			if !localPathAllowed(path, r.LocalPaths) {
+				return fmt.Errorf("localPath %s is not under a directory the operator allows path sources to read, see --local-paths", path)
+			}
+			return nil
 		},
-		ReferenceName:     plumbing.ReferenceName(fmt.Sprintf("refs/heads/%s", r.Spec.Revision)),
-		SingleBranch:      true,
-		RecurseSubmodules: git.DefaultSubmoduleRecursionDepth,
-		CABundle:          certs,
-	})
-	if err != nil {
-		return "", nil, err
+		HTTPURL: func(url string) error {
+			if !render.URLHasPrefix(url, r.HTTPURLPrefixes) {
+				return fmt.Errorf("http.url %s does not start with a url the operator allows http sources to download from, see --http-url-prefixes", url)
+			}
+			return nil
+		},
+		SourceRef: r.sourceRefSource,
 	}
-	ref, err := repo.Head()
-	if err != nil {
-		return "", nil, err
+	if r.HTTP != nil {
+		opts.HTTP = r.HTTP
 	}
+	return render.SourceFor(ctx, instance, opts)
+}
 
-	commit, err := repo.CommitObject(ref.Hash())
+// sourceRefSource returns the working copy of the ArchimedesSource of a property
+func (r *ArchimedesPropertyReconciler) sourceRefSource(ctx context.Context, instance *backwoodsv1.ArchimedesProperty) (render.Source, error) {
+	key := types.NamespacedName{Name: instance.Spec.SourceRef.Name, Namespace: instance.Namespace}
+	source := &backwoodsv1.ArchimedesSource{}
+	err := r.Get(ctx, key, source)
+	if errors.IsNotFound(err) {
+		return nil, &sourceNotReadyError{message: fmt.Sprintf("ArchimedesSource %s was not found", key.Name)}
+	}
 	if err != nil {
-		return "", nil, err
+		return nil, err
+	}
+	if source.Status.Commit == nil || r.Sources == nil {
+		return nil, &sourceNotReadyError{message: fmt.Sprintf("ArchimedesSource %s has not been fetched", key.Name)}
 	}
 
-	propTemplate, err := ioutil.ReadFile(dir + "/" + r.Spec.PropertiesPath)
-	if err != nil {
-		return commit.Hash.String(), propTemplate, err
+	revision := source.Spec.Revision
+	if revision == "" {
+		revision = backwoodsv1.DefaultRevision
 	}
+	return &cachedSource{
+		cache:    r.Sources,
+		key:      key,
+		repoUrl:  source.Spec.RepoUrl,
+		revision: revision,
+		commit: render.Commit{
+			Hash:      source.Status.Commit.Hash,
+			Author:    source.Status.Commit.Author,
+			Message:   source.Status.Commit.Message,
+			Timestamp: source.Status.Commit.Timestamp.Time,
+			Tag:       source.Status.Commit.Tag,
+		},
+	}, nil
+}
 
-	return commit.Hash.String(), propTemplate, nil
+// localPathAllowed reports whether path is one of the directories path sources may read
+// or inside one of them
+func localPathAllowed(path string, allowed []string) bool {
+	path = filepath.Clean(path)
+	for _, dir := range allowed {
+		dir = filepath.Clean(dir)
+		if path == dir || strings.HasPrefix(path, dir+string(filepath.Separator)) || dir == string(filepath.Separator) {
+			return true
+		}
+	}
+	return false
+}
 
+// pollInterval returns how often the source of a property is polled, 0 for sources that
+// notify the controller of changes or are only fetched when requested
+func pollInterval(instance *backwoodsv1.ArchimedesProperty) time.Duration {
+	if instance.Spec.SourceType == backwoodsv1.SourceTypeHTTP && instance.Spec.HTTP != nil && instance.Spec.HTTP.Interval != nil {
+		return instance.Spec.HTTP.Interval.Duration
+	}
+	return 0
 }
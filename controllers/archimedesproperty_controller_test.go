@@ -0,0 +1,185 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	backwoodsv1 "github.com/backwoods-devops/archimedes/api/v1"
+	"github.com/backwoods-devops/archimedes/internal/testutil"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// pathProperty returns a property rendering properties.tpl from a directory mounted in the
+// operator, and a reconciler allowed to read it
+func pathProperty(t *testing.T, name string, files map[string]string, objs ...client.Object) (*backwoodsv1.ArchimedesProperty, *ArchimedesPropertyReconciler) {
+	t.Helper()
+	dir := testutil.WriteFiles(t, files)
+	instance := testProperty(name)
+	instance.Spec.SourceType = backwoodsv1.SourceTypePath
+	instance.Spec.LocalPath = dir
+	instance.Spec.PropertiesPath = "properties.tpl"
+	instance.Spec.PropertyType = "kvp"
+	r := newTestReconciler(t, append(objs, instance)...)
+	r.LocalPaths = []string{dir}
+	return instance, r
+}
+
+func TestReconcileSuspended(t *testing.T) {
+	ctx := context.Background()
+	instance, r := pathProperty(t, "trees", map[string]string{"properties.tpl": "app=trees\n"})
+	instance.Spec.Suspend = true
+	if err := r.Update(ctx, instance); err != nil {
+		t.Fatal(err)
+	}
+
+	key := client.ObjectKeyFromObject(instance)
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatal(err)
+	}
+	err := r.Get(ctx, client.ObjectKey{Namespace: "default", Name: "trees"}, &corev1.ConfigMap{})
+	if !errors.IsNotFound(err) {
+		t.Errorf("configmap of a suspended property was written: %v", err)
+	}
+	got := &backwoodsv1.ArchimedesProperty{}
+	if err := r.Get(ctx, key, got); err != nil {
+		t.Fatal(err)
+	}
+	if !meta.IsStatusConditionTrue(got.Status.Conditions, conditionTypeSuspended) {
+		t.Errorf("conditions = %v, want %s", got.Status.Conditions, conditionTypeSuspended)
+	}
+
+	// resuming writes the configmap and records the resume
+	got.Spec.Suspend = false
+	if err := r.Update(ctx, got); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: "default", Name: "trees"}, &corev1.ConfigMap{}); err != nil {
+		t.Errorf("configmap of a resumed property: %v", err)
+	}
+	if err := r.Get(ctx, key, got); err != nil {
+		t.Fatal(err)
+	}
+	condition := meta.FindStatusCondition(got.Status.Conditions, conditionTypeSuspended)
+	if condition == nil || condition.Status != "False" || condition.Reason != conditionReasonResumed {
+		t.Errorf("suspended condition = %v, want %s", condition, conditionReasonResumed)
+	}
+}
+
+func TestReconcileRequestedAt(t *testing.T) {
+	ctx := context.Background()
+	instance, r := pathProperty(t, "trees", map[string]string{"properties.tpl": "app=trees\n"})
+	key := client.ObjectKeyFromObject(instance)
+
+	for _, requestedAt := range []string{"1622541600", "1622545200"} {
+		got := &backwoodsv1.ArchimedesProperty{}
+		if err := r.Get(ctx, key, got); err != nil {
+			t.Fatal(err)
+		}
+		got.Annotations = map[string]string{reconcileRequestAnnotation: requestedAt}
+		if err := r.Update(ctx, got); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+			t.Fatal(err)
+		}
+		if err := r.Get(ctx, key, got); err != nil {
+			t.Fatal(err)
+		}
+		if got.Status.LastHandledReconcileAt != requestedAt {
+			t.Errorf("status.lastHandledReconcileAt = %q, want %q", got.Status.LastHandledReconcileAt, requestedAt)
+		}
+
+		configmap := &corev1.ConfigMap{}
+		if err := r.Get(ctx, client.ObjectKey{Namespace: "default", Name: "trees"}, configmap); err != nil {
+			t.Fatal(err)
+		}
+		if configmap.Data["app"] != "trees" {
+			t.Errorf("configmap data = %v", configmap.Data)
+		}
+		// the annotation is not copied, so a forced sync does not change the configmap
+		if _, ok := configmap.Annotations[reconcileRequestAnnotation]; ok {
+			t.Errorf("configmap annotations = %v", configmap.Annotations)
+		}
+	}
+}
+
+func TestTemplateSource(t *testing.T) {
+	tests := []struct {
+		name            string
+		spec            backwoodsv1.ArchimedesPropertySpec
+		localPaths      []string
+		httpURLPrefixes []string
+		wantErr         bool
+	}{
+		{
+			name:       "allowed localPath",
+			spec:       backwoodsv1.ArchimedesPropertySpec{SourceType: backwoodsv1.SourceTypePath, LocalPath: "/templates/trees"},
+			localPaths: []string{"/templates"},
+		},
+		{
+			name:    "path sources disabled",
+			spec:    backwoodsv1.ArchimedesPropertySpec{SourceType: backwoodsv1.SourceTypePath, LocalPath: "/templates/trees"},
+			wantErr: true,
+		},
+		{
+			name:       "localPath outside of the allowed directories",
+			spec:       backwoodsv1.ArchimedesPropertySpec{SourceType: backwoodsv1.SourceTypePath, LocalPath: "/var/run/secrets"},
+			localPaths: []string{"/templates"},
+			wantErr:    true,
+		},
+		{
+			name:            "allowed url",
+			spec:            backwoodsv1.ArchimedesPropertySpec{SourceType: backwoodsv1.SourceTypeHTTP, HTTP: &backwoodsv1.HTTPSource{Url: "https://templates.example.com/teams/trees.tpl"}},
+			httpURLPrefixes: []string{"https://templates.example.com/teams"},
+		},
+		{
+			name:    "http sources disabled",
+			spec:    backwoodsv1.ArchimedesPropertySpec{SourceType: backwoodsv1.SourceTypeHTTP, HTTP: &backwoodsv1.HTTPSource{Url: "https://templates.example.com/teams/trees.tpl"}},
+			wantErr: true,
+		},
+		{
+			name:            "url outside of the allowed prefixes",
+			spec:            backwoodsv1.ArchimedesPropertySpec{SourceType: backwoodsv1.SourceTypeHTTP, HTTP: &backwoodsv1.HTTPSource{Url: "http://169.254.169.254/latest/meta-data"}},
+			httpURLPrefixes: []string{"https://templates.example.com/teams"},
+			wantErr:         true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestReconciler(t)
+			r.LocalPaths = tt.localPaths
+			r.HTTPURLPrefixes = tt.httpURLPrefixes
+			instance := testProperty("trees")
+			instance.Spec.SourceType = tt.spec.SourceType
+			instance.Spec.LocalPath = tt.spec.LocalPath
+			instance.Spec.HTTP = tt.spec.HTTP
+			_, err := r.templateSource(context.Background(), instance)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("templateSource() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
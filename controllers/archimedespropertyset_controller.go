@@ -0,0 +1,373 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	backwoodsv1 "github.com/backwoods-devops/archimedes/api/v1"
+	"github.com/backwoods-devops/archimedes/pkg/render"
+	"github.com/go-logr/logr"
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	// propertySetLabel is set on generated properties to the labelValue of the name of their
+	// ArchimedesPropertySet
+	propertySetLabel = "archimedes.backwoods-devops.io/property-set"
+
+	conditionReasonGenerated      = "Generated"
+	conditionReasonGenerateFailed = "GenerateFailed"
+)
+
+// ArchimedesPropertySetReconciler reconciles a ArchimedesPropertySet object
+type ArchimedesPropertySetReconciler struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+}
+
+// propertySetItem is a property generated by a set
+type propertySetItem struct {
+	namespace string
+	name      string
+	// values are the yaml documents merged over the sourceConfig of the template, in order
+	values []string
+}
+
+//+kubebuilder:rbac:groups=archimedes.backwoods-devops.io,resources=archimedespropertysets,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=archimedes.backwoods-devops.io,resources=archimedespropertysets/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=archimedes.backwoods-devops.io,resources=archimedespropertysets/finalizers,verbs=update
+
+// Reconcile creates or updates a property for every item generated by a set and deletes
+// the properties of the set whose items are no longer generated
+func (r *ArchimedesPropertySetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("archimedespropertyset", req.Name)
+
+	set := &backwoodsv1.ArchimedesPropertySet{}
+	err := r.Get(ctx, req.NamespacedName, set)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// Generated properties are garbage collected through their owner reference
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	items, err := r.generate(ctx, set)
+	if err != nil {
+		log.Error(err, "Could not generate the items of the set")
+		r.updateConditions(ctx, log, set, conditionReasonGenerateFailed, err.Error(), metav1.ConditionFalse)
+		return ctrl.Result{}, nil
+	}
+
+	desired := map[types.NamespacedName]bool{}
+	generated := []string{}
+	failures := []string{}
+	for _, item := range items {
+		key := types.NamespacedName{Namespace: item.namespace, Name: item.name}
+		desired[key] = true
+		err := r.applyProperty(ctx, set, item)
+		if err != nil {
+			log.Error(err, "Could not generate property", "property", key)
+			failures = append(failures, fmt.Sprintf("%s: %s", key, err))
+			continue
+		}
+		generated = append(generated, key.String())
+	}
+
+	pruned, err := r.prune(ctx, set, desired)
+	if err != nil {
+		log.Error(err, "Could not prune properties", "pruned", pruned)
+		failures = append(failures, err.Error())
+	} else if len(pruned) > 0 {
+		log.Info("Pruned properties", "properties", pruned)
+	}
+
+	sort.Strings(generated)
+	set.Status.Properties = generated
+	if len(failures) > 0 {
+		r.updateConditions(ctx, log, set, conditionReasonGenerateFailed, strings.Join(failures, "; "), metav1.ConditionFalse)
+		return ctrl.Result{}, fmt.Errorf("could not generate %d properties", len(failures))
+	}
+	r.updateConditions(ctx, log, set, conditionReasonGenerated, fmt.Sprintf("Generated %d properties", len(generated)), metav1.ConditionTrue)
+	return ctrl.Result{}, nil
+}
+
+// applyProperty creates or updates the property generated for an item. Properties that
+// exist but were not generated by the set are left alone.
+func (r *ArchimedesPropertySetReconciler) applyProperty(ctx context.Context, set *backwoodsv1.ArchimedesPropertySet, item propertySetItem) error {
+	sourceConfig, err := mergeSourceConfig(set.Spec.Template.Spec.SourceConfig, item.values)
+	if err != nil {
+		return err
+	}
+
+	property := &backwoodsv1.ArchimedesProperty{
+		ObjectMeta: metav1.ObjectMeta{Name: item.name, Namespace: item.namespace},
+	}
+	_, err = controllerutil.CreateOrUpdate(ctx, r.Client, property, func() error {
+		if property.ResourceVersion != "" && !metav1.IsControlledBy(property, set) {
+			return fmt.Errorf("property already exists and is not managed by the set")
+		}
+		if property.Labels == nil {
+			property.Labels = map[string]string{}
+		}
+		for k, v := range set.Spec.Template.Metadata.Labels {
+			property.Labels[k] = v
+		}
+		property.Labels[propertySetLabel] = labelValue(set.Name)
+		if len(set.Spec.Template.Metadata.Annotations) > 0 && property.Annotations == nil {
+			property.Annotations = map[string]string{}
+		}
+		for k, v := range set.Spec.Template.Metadata.Annotations {
+			property.Annotations[k] = v
+		}
+		property.Spec = *set.Spec.Template.Spec.DeepCopy()
+		property.Spec.SourceConfig = sourceConfig
+		// Apply the defaults of the admission webhook so unchanged properties are not updated
+		property.Default()
+		return ctrl.SetControllerReference(set, property, r.Scheme)
+	})
+	return err
+}
+
+// prune deletes the properties generated by a set that are not desired anymore
+func (r *ArchimedesPropertySetReconciler) prune(ctx context.Context, set *backwoodsv1.ArchimedesPropertySet, desired map[types.NamespacedName]bool) ([]string, error) {
+	list := &backwoodsv1.ArchimedesPropertyList{}
+	err := r.List(ctx, list, client.MatchingLabels{propertySetLabel: labelValue(set.Name)})
+	if err != nil {
+		return nil, err
+	}
+	pruned := []string{}
+	for i := range list.Items {
+		property := &list.Items[i]
+		key := client.ObjectKeyFromObject(property)
+		if desired[key] || !metav1.IsControlledBy(property, set) {
+			continue
+		}
+		err = r.Delete(ctx, property)
+		if err != nil && !errors.IsNotFound(err) {
+			return pruned, err
+		}
+		pruned = append(pruned, key.String())
+	}
+	return pruned, nil
+}
+
+// generate returns the items of every generator of a set with their names defaulted
+func (r *ArchimedesPropertySetReconciler) generate(ctx context.Context, set *backwoodsv1.ArchimedesPropertySet) ([]propertySetItem, error) {
+	items := []propertySetItem{}
+	for i, g := range set.Spec.Generators {
+		var generated []propertySetItem
+		var err error
+		switch {
+		case countGenerators(g.List != nil, g.Namespaces != nil, g.Matrix != nil) != 1:
+			err = fmt.Errorf("exactly one of list, namespaces or matrix is required")
+		case g.List != nil:
+			generated = listItems(g.List)
+		case g.Namespaces != nil:
+			generated, err = r.namespaceItems(ctx, g.Namespaces)
+		case g.Matrix != nil:
+			generated, err = r.matrixItems(ctx, g.Matrix)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("generator %d: %w", i, err)
+		}
+		items = append(items, generated...)
+	}
+
+	name := set.Spec.Template.Metadata.Name
+	if name == "" {
+		name = set.Name
+	}
+	seen := map[types.NamespacedName]bool{}
+	for i := range items {
+		if items[i].name == "" {
+			items[i].name = name
+		}
+		if items[i].namespace == "" {
+			return nil, fmt.Errorf("item %s has no namespace", items[i].name)
+		}
+		key := types.NamespacedName{Namespace: items[i].namespace, Name: items[i].name}
+		if seen[key] {
+			return nil, fmt.Errorf("property %s is generated more than once", key)
+		}
+		seen[key] = true
+	}
+	return items, nil
+}
+
+func countGenerators(set ...bool) int {
+	count := 0
+	for _, s := range set {
+		if s {
+			count++
+		}
+	}
+	return count
+}
+
+func listItems(g *backwoodsv1.ListGenerator) []propertySetItem {
+	items := make([]propertySetItem, 0, len(g.Elements))
+	for _, element := range g.Elements {
+		item := propertySetItem{namespace: element.Namespace, name: element.Name}
+		if element.Values != "" {
+			item.values = []string{element.Values}
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+func (r *ArchimedesPropertySetReconciler) namespaceItems(ctx context.Context, g *backwoodsv1.NamespaceGenerator) ([]propertySetItem, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&g.Selector)
+	if err != nil {
+		return nil, err
+	}
+	list := &corev1.NamespaceList{}
+	err = r.List(ctx, list, client.MatchingLabelsSelector{Selector: selector})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(list.Items, func(i, j int) bool { return list.Items[i].Name < list.Items[j].Name })
+	items := make([]propertySetItem, 0, len(list.Items))
+	for _, namespace := range list.Items {
+		item := propertySetItem{namespace: namespace.Name}
+		if g.Values != "" {
+			item.values = []string{g.Values}
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// matrixItems combines every item of each generator of a matrix with every item of the next
+func (r *ArchimedesPropertySetReconciler) matrixItems(ctx context.Context, g *backwoodsv1.MatrixGenerator) ([]propertySetItem, error) {
+	if len(g.Generators) < 2 {
+		return nil, fmt.Errorf("a matrix needs at least two generators")
+	}
+	items := []propertySetItem{{}}
+	for i, nested := range g.Generators {
+		var generated []propertySetItem
+		var err error
+		switch {
+		case countGenerators(nested.List != nil, nested.Namespaces != nil) != 1:
+			err = fmt.Errorf("exactly one of list or namespaces is required")
+		case nested.List != nil:
+			generated = listItems(nested.List)
+		case nested.Namespaces != nil:
+			generated, err = r.namespaceItems(ctx, nested.Namespaces)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("matrix generator %d: %w", i, err)
+		}
+
+		combined := make([]propertySetItem, 0, len(items)*len(generated))
+		for _, a := range items {
+			for _, b := range generated {
+				item := propertySetItem{namespace: a.namespace, name: a.name}
+				if b.namespace != "" {
+					item.namespace = b.namespace
+				}
+				if b.name != "" {
+					item.name = b.name
+				}
+				item.values = append(append([]string{}, a.values...), b.values...)
+				combined = append(combined, item)
+			}
+		}
+		items = combined
+	}
+	return items, nil
+}
+
+// mergeSourceConfig merges yaml documents over the sourceConfig of a template
+func mergeSourceConfig(sourceConfig string, values []string) (string, error) {
+	if len(values) == 0 {
+		return sourceConfig, nil
+	}
+	merged := map[string]interface{}{}
+	for _, doc := range append([]string{sourceConfig}, values...) {
+		parsed, err := render.ParseValues(doc)
+		if err != nil {
+			return "", err
+		}
+		render.MergeValues(merged, parsed)
+	}
+	out, err := yaml.Marshal(merged)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+func (r *ArchimedesPropertySetReconciler) updateConditions(ctx context.Context, log logr.Logger, set *backwoodsv1.ArchimedesPropertySet, reason, message string, status metav1.ConditionStatus) {
+	meta.SetStatusCondition(&set.Status.Conditions, metav1.Condition{
+		Type:               conditionTypeReady,
+		Status:             status,
+		ObservedGeneration: set.GetGeneration(),
+		Reason:             reason,
+		Message:            message,
+	})
+	err := r.Status().Update(ctx, set)
+	if err != nil {
+		log.Error(err, "Could not update status")
+	}
+}
+
+// setsForNamespace returns a request for every set, as any of them may select the namespace
+func (r *ArchimedesPropertySetReconciler) setsForNamespace(obj client.Object) []reconcile.Request {
+	list := &backwoodsv1.ArchimedesPropertySetList{}
+	err := r.List(context.Background(), list)
+	if err != nil {
+		r.Log.Error(err, "Could not list property sets")
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(list.Items))
+	for _, set := range list.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: set.Name}})
+	}
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ArchimedesPropertySetReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&backwoodsv1.ArchimedesPropertySet{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Owns(&backwoodsv1.ArchimedesProperty{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&source.Kind{Type: &corev1.Namespace{}},
+			handler.EnqueueRequestsFromMapFunc(r.setsForNamespace),
+			builder.WithPredicates(predicate.LabelChangedPredicate{})).
+		Complete(r)
+}
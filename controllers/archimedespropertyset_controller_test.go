@@ -0,0 +1,272 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+
+	backwoodsv1 "github.com/backwoods-devops/archimedes/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// newTestSetReconciler returns a property set reconciler reading and writing a fake client
+// seeded with objs
+func newTestSetReconciler(t *testing.T, objs ...client.Object) *ArchimedesPropertySetReconciler {
+	t.Helper()
+	r := newTestReconciler(t, objs...)
+	return &ArchimedesPropertySetReconciler{Client: r.Client, Scheme: r.Scheme, Log: ctrl.Log}
+}
+
+func testNamespace(name string, labels map[string]string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+}
+
+func testSet(name string, generators ...backwoodsv1.PropertySetGenerator) *backwoodsv1.ArchimedesPropertySet {
+	return &backwoodsv1.ArchimedesPropertySet{
+		TypeMeta:   metav1.TypeMeta{APIVersion: backwoodsv1.GroupVersion.String(), Kind: "ArchimedesPropertySet"},
+		ObjectMeta: metav1.ObjectMeta{Name: name, UID: "set-uid"},
+		Spec:       backwoodsv1.ArchimedesPropertySetSpec{Generators: generators},
+	}
+}
+
+func TestListItems(t *testing.T) {
+	tests := []struct {
+		name     string
+		elements []backwoodsv1.PropertySetElement
+		want     []propertySetItem
+	}{
+		{name: "empty", want: []propertySetItem{}},
+		{
+			name: "elements",
+			elements: []backwoodsv1.PropertySetElement{
+				{Namespace: "forest", Name: "trees", Values: "env: dev"},
+				{Namespace: "meadow"},
+			},
+			want: []propertySetItem{
+				{namespace: "forest", name: "trees", values: []string{"env: dev"}},
+				{namespace: "meadow"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := listItems(&backwoodsv1.ListGenerator{Elements: tt.elements})
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("listItems() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatrixItems(t *testing.T) {
+	r := newTestSetReconciler(t,
+		testNamespace("forest", map[string]string{"team": "trees"}),
+		testNamespace("meadow", map[string]string{"team": "trees"}),
+		testNamespace("lake", map[string]string{"team": "fish"}),
+	)
+	namespaces := &backwoodsv1.NamespaceGenerator{
+		Selector: metav1.LabelSelector{MatchLabels: map[string]string{"team": "trees"}},
+		Values:   "team: trees",
+	}
+	tests := []struct {
+		name       string
+		generators []backwoodsv1.NestedGenerator
+		want       []propertySetItem
+		wantErr    bool
+	}{
+		{
+			name: "namespaces and list",
+			generators: []backwoodsv1.NestedGenerator{
+				{Namespaces: namespaces},
+				{List: &backwoodsv1.ListGenerator{Elements: []backwoodsv1.PropertySetElement{
+					{Name: "trees-dev", Values: "env: dev"},
+					{Name: "trees-prod"},
+				}}},
+			},
+			want: []propertySetItem{
+				{namespace: "forest", name: "trees-dev", values: []string{"team: trees", "env: dev"}},
+				{namespace: "forest", name: "trees-prod", values: []string{"team: trees"}},
+				{namespace: "meadow", name: "trees-dev", values: []string{"team: trees", "env: dev"}},
+				{namespace: "meadow", name: "trees-prod", values: []string{"team: trees"}},
+			},
+		},
+		{
+			name: "later generators override namespace and name",
+			generators: []backwoodsv1.NestedGenerator{
+				{List: &backwoodsv1.ListGenerator{Elements: []backwoodsv1.PropertySetElement{
+					{Namespace: "forest", Name: "trees", Values: "env: dev"},
+				}}},
+				{List: &backwoodsv1.ListGenerator{Elements: []backwoodsv1.PropertySetElement{
+					{Namespace: "meadow", Values: "env: prod"},
+					{Name: "birch"},
+				}}},
+			},
+			want: []propertySetItem{
+				{namespace: "meadow", name: "trees", values: []string{"env: dev", "env: prod"}},
+				{namespace: "forest", name: "birch", values: []string{"env: dev"}},
+			},
+		},
+		{
+			name:       "one generator",
+			generators: []backwoodsv1.NestedGenerator{{Namespaces: namespaces}},
+			wantErr:    true,
+		},
+		{
+			name: "nested generator with list and namespaces",
+			generators: []backwoodsv1.NestedGenerator{
+				{Namespaces: namespaces},
+				{Namespaces: namespaces, List: &backwoodsv1.ListGenerator{}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.matrixItems(context.Background(), &backwoodsv1.MatrixGenerator{Generators: tt.generators})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("matrixItems() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("matrixItems() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	r := newTestSetReconciler(t)
+	list := func(elements ...backwoodsv1.PropertySetElement) backwoodsv1.PropertySetGenerator {
+		return backwoodsv1.PropertySetGenerator{List: &backwoodsv1.ListGenerator{Elements: elements}}
+	}
+	tests := []struct {
+		name       string
+		generators []backwoodsv1.PropertySetGenerator
+		want       []propertySetItem
+		wantErr    bool
+	}{
+		{
+			name:       "name defaults to the set name",
+			generators: []backwoodsv1.PropertySetGenerator{list(backwoodsv1.PropertySetElement{Namespace: "forest"}, backwoodsv1.PropertySetElement{Namespace: "meadow", Name: "birch"})},
+			want:       []propertySetItem{{namespace: "forest", name: "trees"}, {namespace: "meadow", name: "birch"}},
+		},
+		{
+			name: "duplicate property",
+			generators: []backwoodsv1.PropertySetGenerator{
+				list(backwoodsv1.PropertySetElement{Namespace: "forest"}),
+				list(backwoodsv1.PropertySetElement{Namespace: "forest", Name: "trees"}),
+			},
+			wantErr: true,
+		},
+		{
+			name:       "missing namespace",
+			generators: []backwoodsv1.PropertySetGenerator{list(backwoodsv1.PropertySetElement{Name: "birch"})},
+			wantErr:    true,
+		},
+		{
+			name:       "generator without a kind",
+			generators: []backwoodsv1.PropertySetGenerator{{}},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.generate(context.Background(), testSet("trees", tt.generators...))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("generate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("generate() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeSourceConfig(t *testing.T) {
+	tests := []struct {
+		name         string
+		sourceConfig string
+		values       []string
+		want         string
+		wantErr      bool
+	}{
+		{name: "no values", sourceConfig: "env:  dev\n", want: "env:  dev\n"},
+		{name: "later values win", sourceConfig: "env: dev\nteam: trees\n", values: []string{"env: staging", "env: prod"}, want: "env: prod\nteam: trees\n"},
+		{name: "nested values merge", sourceConfig: "db:\n  host: forest\n  port: 5432\n", values: []string{"db:\n  host: meadow\n"}, want: "db:\n  host: meadow\n  port: 5432\n"},
+		{name: "empty sourceConfig", values: []string{"env: dev"}, want: "env: dev\n"},
+		{name: "invalid values", sourceConfig: "env: dev", values: []string{"env: ["}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mergeSourceConfig(tt.sourceConfig, tt.values)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("mergeSourceConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("mergeSourceConfig() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrune(t *testing.T) {
+	ctx := context.Background()
+	set := testSet("trees")
+	owner := metav1.NewControllerRef(set, backwoodsv1.GroupVersion.WithKind("ArchimedesPropertySet"))
+	property := func(namespace, name string, controlled bool) *backwoodsv1.ArchimedesProperty {
+		p := testProperty(name)
+		p.Namespace = namespace
+		p.Labels = map[string]string{propertySetLabel: labelValue(set.Name)}
+		if controlled {
+			p.OwnerReferences = []metav1.OwnerReference{*owner}
+		}
+		return p
+	}
+	r := newTestSetReconciler(t,
+		property("forest", "trees", true),
+		property("meadow", "trees", true),
+		property("lake", "trees", false),
+	)
+
+	desired := map[types.NamespacedName]bool{{Namespace: "forest", Name: "trees"}: true}
+	pruned, err := r.prune(ctx, set, desired)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"meadow/trees"}; !reflect.DeepEqual(pruned, want) {
+		t.Errorf("pruned = %v, want %v", pruned, want)
+	}
+
+	list := &backwoodsv1.ArchimedesPropertyList{}
+	if err := r.List(ctx, list); err != nil {
+		t.Fatal(err)
+	}
+	kept := []string{}
+	for _, p := range list.Items {
+		kept = append(kept, client.ObjectKeyFromObject(&p).String())
+	}
+	sort.Strings(kept)
+	if want := []string{"forest/trees", "lake/trees"}; !reflect.DeepEqual(kept, want) {
+		t.Errorf("kept = %v, want %v", kept, want)
+	}
+}
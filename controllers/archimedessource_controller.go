@@ -0,0 +1,166 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	backwoodsv1 "github.com/backwoods-devops/archimedes/api/v1"
+	"github.com/backwoods-devops/archimedes/pkg/render"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+const (
+	conditionTypeReady          = "Ready"
+	conditionReasonFetched      = "Fetched"
+	conditionReasonAuthFailed   = "AuthenticationFailed"
+	conditionReasonSourceFailed = "FetchFailed"
+
+	// defaultSourceInterval is how often a source is polled when it has no interval
+	defaultSourceInterval = 5 * time.Minute
+
+	// sourceSecretUsernameKey and sourceSecretPasswordKey are the keys of the
+	// credentials in the Secret referenced by a source
+	sourceSecretUsernameKey = "username"
+	sourceSecretPasswordKey = "password"
+)
+
+// ArchimedesSourceReconciler reconciles a ArchimedesSource object
+type ArchimedesSourceReconciler struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+
+	// Sources is the cache the repos of the sources are fetched into
+	Sources *SourceCache
+}
+
+//+kubebuilder:rbac:groups=archimedes.backwoods-devops.io,resources=archimedessources,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=archimedes.backwoods-devops.io,resources=archimedessources/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=archimedes.backwoods-devops.io,resources=archimedessources/finalizers,verbs=update
+
+// Reconcile fetches the repo of a source into the cache and publishes the commit
+// fetched in its status, then requeues the source after its polling interval
+func (r *ArchimedesSourceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("archimedessource", req.NamespacedName)
+
+	source := &backwoodsv1.ArchimedesSource{}
+	err := r.Get(ctx, req.NamespacedName, source)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// The source was deleted, its working copy is no longer needed
+			return ctrl.Result{}, r.Sources.remove(req.NamespacedName)
+		}
+		return ctrl.Result{}, err
+	}
+
+	interval := source.Spec.Interval.Duration
+	if interval <= 0 {
+		interval = defaultSourceInterval
+	}
+	revision := source.Spec.Revision
+	if revision == "" {
+		revision = backwoodsv1.DefaultRevision
+	}
+
+	auth, err := r.auth(ctx, source)
+	if err != nil {
+		log.Error(err, "Could not read the credentials of the source")
+		r.updateConditions(ctx, log, source, conditionReasonAuthFailed, err.Error(), metav1.ConditionFalse)
+		return ctrl.Result{RequeueAfter: interval}, nil
+	}
+	certs, err := render.ReadCA(source.Spec.CAPath)
+	if err != nil {
+		log.Error(err, "Could not read the CA certificate of the source")
+		r.updateConditions(ctx, log, source, conditionReasonSourceFailed, err.Error(), metav1.ConditionFalse)
+		return ctrl.Result{RequeueAfter: interval}, nil
+	}
+
+	commit, err := r.Sources.fetch(req.NamespacedName, source.Spec.RepoUrl, revision, auth, certs)
+	if err != nil {
+		log.Error(err, "Could not fetch the repo of the source")
+		r.updateConditions(ctx, log, source, conditionReasonSourceFailed, err.Error(), metav1.ConditionFalse)
+		return ctrl.Result{RequeueAfter: interval}, nil
+	}
+
+	if source.Status.Commit == nil || source.Status.Commit.Hash != commit.Hash {
+		log.Info("Fetched a new commit", "commit", commit.Hash)
+	}
+	source.Status.Commit = &backwoodsv1.SourceCommit{
+		Hash:      commit.Hash,
+		Author:    commit.Author,
+		Message:   commit.Message,
+		Timestamp: metav1.NewTime(commit.Timestamp),
+		Tag:       commit.Tag,
+	}
+	now := metav1.Now()
+	source.Status.LastFetchTime = &now
+	r.updateConditions(ctx, log, source, conditionReasonFetched, fmt.Sprintf("Fetched commit %s", commit.Hash), metav1.ConditionTrue)
+	return ctrl.Result{RequeueAfter: interval}, nil
+}
+
+// auth returns the credentials of a source from its Secret, or from the environment
+// of the operator when it has none
+func (r *ArchimedesSourceReconciler) auth(ctx context.Context, source *backwoodsv1.ArchimedesSource) (*http.BasicAuth, error) {
+	if source.Spec.SecretRef == nil {
+		return render.EnvAuth(), nil
+	}
+	secret := &corev1.Secret{}
+	err := r.Get(ctx, types.NamespacedName{Name: source.Spec.SecretRef.Name, Namespace: source.Namespace}, secret)
+	if err != nil {
+		return nil, err
+	}
+	return &http.BasicAuth{
+		Username: string(secret.Data[sourceSecretUsernameKey]),
+		Password: string(secret.Data[sourceSecretPasswordKey]),
+	}, nil
+}
+
+func (r *ArchimedesSourceReconciler) updateConditions(ctx context.Context, log logr.Logger, source *backwoodsv1.ArchimedesSource, reason, message string, status metav1.ConditionStatus) {
+	meta.SetStatusCondition(&source.Status.Conditions, metav1.Condition{
+		Type:               conditionTypeReady,
+		Status:             status,
+		ObservedGeneration: source.GetGeneration(),
+		Reason:             reason,
+		Message:            message,
+	})
+	err := r.Status().Update(ctx, source)
+	if err != nil {
+		log.Error(err, "Could not update status")
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ArchimedesSourceReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		// Status updates are ignored, the source polls its repo on its interval instead
+		For(&backwoodsv1.ArchimedesSource{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
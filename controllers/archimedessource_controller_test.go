@@ -0,0 +1,236 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	backwoodsv1 "github.com/backwoods-devops/archimedes/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// newTestSourceReconciler returns a source reconciler fetching into a SourceCache in a
+// temporary directory, sharing the fake client of a property reconciler
+func newTestSourceReconciler(t *testing.T, objs ...client.Object) (*ArchimedesSourceReconciler, *ArchimedesPropertyReconciler) {
+	t.Helper()
+	r := newTestReconciler(t, objs...)
+	r.Sources = NewSourceCache(t.TempDir())
+	return &ArchimedesSourceReconciler{Client: r.Client, Scheme: r.Scheme, Log: ctrl.Log, Sources: r.Sources}, r
+}
+
+// testSource returns a source polling the master branch of the repo at dir
+func testSource(name, dir string, interval time.Duration) *backwoodsv1.ArchimedesSource {
+	return &backwoodsv1.ArchimedesSource{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: backwoodsv1.ArchimedesSourceSpec{
+			RepoUrl:  dir,
+			Revision: "master",
+			Interval: metav1.Duration{Duration: interval},
+		},
+	}
+}
+
+func TestSourceReconcile(t *testing.T) {
+	ctx := context.Background()
+	repoDir := t.TempDir()
+	hash := commitFiles(t, repoDir, map[string]string{"properties.tpl": "app=trees\n"})
+	tests := []struct {
+		name        string
+		source      *backwoodsv1.ArchimedesSource
+		objs        []client.Object
+		wantRequeue time.Duration
+		wantReason  string
+	}{
+		{name: "fetched", source: testSource("trees", repoDir, time.Minute), wantRequeue: time.Minute, wantReason: conditionReasonFetched},
+		{name: "default interval", source: testSource("trees", repoDir, 0), wantRequeue: defaultSourceInterval, wantReason: conditionReasonFetched},
+		{
+			name: "secret credentials",
+			source: func() *backwoodsv1.ArchimedesSource {
+				s := testSource("trees", repoDir, time.Minute)
+				s.Spec.SecretRef = &backwoodsv1.SecretReference{Name: "git"}
+				return s
+			}(),
+			objs: []client.Object{&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "git", Namespace: "default"},
+				Data:       map[string][]byte{sourceSecretUsernameKey: []byte("archimedes"), sourceSecretPasswordKey: []byte("secret")},
+			}},
+			wantRequeue: time.Minute,
+			wantReason:  conditionReasonFetched,
+		},
+		{
+			name: "missing secret",
+			source: func() *backwoodsv1.ArchimedesSource {
+				s := testSource("trees", repoDir, time.Minute)
+				s.Spec.SecretRef = &backwoodsv1.SecretReference{Name: "git"}
+				return s
+			}(),
+			wantRequeue: time.Minute,
+			wantReason:  conditionReasonAuthFailed,
+		},
+		{name: "missing repo", source: testSource("trees", filepath.Join(repoDir, "missing"), time.Minute), wantRequeue: time.Minute, wantReason: conditionReasonSourceFailed},
+		{
+			name: "missing branch",
+			source: func() *backwoodsv1.ArchimedesSource {
+				s := testSource("trees", repoDir, time.Minute)
+				s.Spec.Revision = "release"
+				return s
+			}(),
+			wantRequeue: time.Minute,
+			wantReason:  conditionReasonSourceFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, _ := newTestSourceReconciler(t, append(tt.objs, tt.source)...)
+			key := client.ObjectKeyFromObject(tt.source)
+
+			// polling is requeued on the interval whether or not the fetch failed
+			result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.RequeueAfter != tt.wantRequeue {
+				t.Errorf("Reconcile() requeueAfter = %v, want %v", result.RequeueAfter, tt.wantRequeue)
+			}
+
+			got := &backwoodsv1.ArchimedesSource{}
+			if err := r.Get(ctx, key, got); err != nil {
+				t.Fatal(err)
+			}
+			condition := meta.FindStatusCondition(got.Status.Conditions, conditionTypeReady)
+			if condition == nil || condition.Reason != tt.wantReason {
+				t.Fatalf("ready condition = %v, want %s", condition, tt.wantReason)
+			}
+			if tt.wantReason != conditionReasonFetched {
+				if condition.Status != metav1.ConditionFalse || got.Status.Commit != nil {
+					t.Errorf("status of a failed fetch = %v, commit %v", condition, got.Status.Commit)
+				}
+				return
+			}
+			if condition.Status != metav1.ConditionTrue || got.Status.Commit == nil || got.Status.Commit.Hash != hash || got.Status.LastFetchTime == nil {
+				t.Errorf("status = %v, commit %v", condition, got.Status.Commit)
+			}
+		})
+	}
+}
+
+func TestSourceReconcileDeleted(t *testing.T) {
+	ctx := context.Background()
+	repoDir := t.TempDir()
+	commitFiles(t, repoDir, map[string]string{"properties.tpl": "app=trees\n"})
+	source := testSource("trees", repoDir, time.Minute)
+	r, _ := newTestSourceReconciler(t, source)
+	key := client.ObjectKeyFromObject(source)
+
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(r.Sources.path(key)); err != nil {
+		t.Fatalf("working copy was not fetched: %v", err)
+	}
+	if err := r.Delete(ctx, source); err != nil {
+		t.Fatal(err)
+	}
+	result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	if err != nil || result.RequeueAfter != 0 {
+		t.Fatalf("Reconcile() = %v, %v", result, err)
+	}
+	if _, err := os.Stat(r.Sources.path(key)); !os.IsNotExist(err) {
+		t.Errorf("working copy of a deleted source was not removed: %v", err)
+	}
+}
+
+// sourceRefProperty returns a property rendering properties.tpl from an ArchimedesSource
+func sourceRefProperty(name, source string) *backwoodsv1.ArchimedesProperty {
+	instance := testProperty(name)
+	instance.Spec.SourceRef = &backwoodsv1.SourceReference{Name: source}
+	instance.Spec.PropertiesPath = "properties.tpl"
+	instance.Spec.PropertyType = "kvp"
+	return instance
+}
+
+func TestReconcileSourceRef(t *testing.T) {
+	ctx := context.Background()
+	repoDir := t.TempDir()
+	commitFiles(t, repoDir, map[string]string{"properties.tpl": "app={{ .app }}\n"})
+	source := testSource("trees", repoDir, time.Minute)
+	trees := sourceRefProperty("trees", "trees")
+	trees.Spec.SourceConfig = "app: trees\n"
+	forest := sourceRefProperty("forest", "trees")
+	forest.Spec.SourceConfig = "app: forest\n"
+	sources, r := newTestSourceReconciler(t, source, trees, forest)
+
+	// the properties wait until their source has fetched its repo
+	result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(trees)})
+	if err != nil || result.RequeueAfter != sourceNotReadyRequeue {
+		t.Fatalf("Reconcile() before the fetch = %v, %v", result, err)
+	}
+
+	if _, err := sources.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(source)}); err != nil {
+		t.Fatal(err)
+	}
+	// both properties render from the one working copy of the source
+	for _, instance := range []*backwoodsv1.ArchimedesProperty{trees, forest} {
+		if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(instance)}); err != nil {
+			t.Fatal(err)
+		}
+		configmap := &corev1.ConfigMap{}
+		if err := r.Get(ctx, client.ObjectKey{Namespace: "default", Name: instance.Name}, configmap); err != nil {
+			t.Fatal(err)
+		}
+		if configmap.Data["app"] != instance.Name {
+			t.Errorf("configmap %s data = %v", instance.Name, configmap.Data)
+		}
+	}
+	entries, err := ioutil.ReadDir(filepath.Join(r.Sources.dir, "default"))
+	if err != nil || len(entries) != 1 {
+		t.Errorf("working copies = %v, %v, want one", entries, err)
+	}
+}
+
+func TestReconcileMissingSourceRef(t *testing.T) {
+	ctx := context.Background()
+	instance := sourceRefProperty("trees", "missing")
+	_, r := newTestSourceReconciler(t, instance)
+	key := client.ObjectKeyFromObject(instance)
+
+	result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.RequeueAfter != sourceNotReadyRequeue {
+		t.Errorf("Reconcile() requeueAfter = %v, want %v", result.RequeueAfter, sourceNotReadyRequeue)
+	}
+	got := &backwoodsv1.ArchimedesProperty{}
+	if err := r.Get(ctx, key, got); err != nil {
+		t.Fatal(err)
+	}
+	condition := meta.FindStatusCondition(got.Status.Conditions, conditionTypeConfigmapCreated)
+	if condition == nil || condition.Status != metav1.ConditionFalse || condition.Reason != conditionReasonSourceNotReady || !strings.Contains(condition.Message, "ArchimedesSource missing was not found") {
+		t.Errorf("condition = %v, want %s", condition, conditionReasonSourceNotReady)
+	}
+}
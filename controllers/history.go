@@ -0,0 +1,176 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	backwoodsv1 "github.com/backwoods-devops/archimedes/api/v1"
+	"github.com/backwoods-devops/archimedes/pkg/render"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const (
+	// historyLabel marks the ConfigMaps holding snapshots of previous renders
+	historyLabel = "archimedes.backwoods-devops.io/history"
+
+	// defaultHistoryLimit is the number of renders kept in the history by default
+	defaultHistoryLimit = 10
+)
+
+// recordHistory adds a successful render to the history of a property, keeping a snapshot
+// of its data in an owned ConfigMap. Snapshots of entries beyond the history limit are deleted.
+func (r *ArchimedesPropertyReconciler) recordHistory(ctx context.Context, instance *backwoodsv1.ArchimedesProperty, configmap *corev1.ConfigMap, commit, valuesHash string) error {
+	hash := instance.Status.ContentHash
+	history := instance.Status.History
+	if len(history) > 0 && history[0].DataHash == hash && history[0].Commit == commit && history[0].ValuesHash == valuesHash {
+		return nil
+	}
+
+	snapshot := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      suffixedName(instance.Spec.ConfigMapName, "-history-"+hash[:10]),
+			Namespace: instance.Namespace,
+			Labels: map[string]string{
+				"created-by":  "archimedes-property-operator",
+				propertyLabel: labelValue(instance.Name),
+				historyLabel:  "true",
+			},
+			Annotations: map[string]string{},
+		},
+		Data: configmap.Data,
+	}
+	for k, v := range configmap.Annotations {
+		if strings.HasPrefix(k, render.ProvenanceAnnotationPrefix) {
+			snapshot.Annotations[k] = v
+		}
+	}
+	immutable := true
+	snapshot.Immutable = &immutable
+	err := ctrl.SetControllerReference(instance, snapshot, r.Scheme)
+	if err != nil {
+		return err
+	}
+	err = r.Create(ctx, snapshot)
+	if err != nil && !errors.IsAlreadyExists(err) {
+		return err
+	}
+
+	limit := defaultHistoryLimit
+	if instance.Spec.HistoryLimit != nil {
+		limit = int(*instance.Spec.HistoryLimit)
+	}
+	history = append([]backwoodsv1.RenderHistoryEntry{{
+		Commit:     commit,
+		ValuesHash: valuesHash,
+		DataHash:   hash,
+		Timestamp:  metav1.Now(),
+		Snapshot:   snapshot.Name,
+	}}, history...)
+	if len(history) > limit {
+		dropped := history[limit:]
+		history = history[:limit]
+		for _, entry := range dropped {
+			if historyReferences(history, entry.Snapshot) {
+				continue
+			}
+			err = r.Delete(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: entry.Snapshot, Namespace: instance.Namespace}})
+			if err != nil && !errors.IsNotFound(err) {
+				return err
+			}
+		}
+	}
+	instance.Status.History = history
+	return nil
+}
+
+// historyReferences reports whether an entry of the history uses the snapshot
+func historyReferences(history []backwoodsv1.RenderHistoryEntry, snapshot string) bool {
+	for _, entry := range history {
+		if entry.Snapshot == snapshot {
+			return true
+		}
+	}
+	return false
+}
+
+// rollbackEntry returns the entry of the history a property's rollback points at
+func rollbackEntry(instance *backwoodsv1.ArchimedesProperty) (*backwoodsv1.RenderHistoryEntry, error) {
+	rollback := instance.Spec.Rollback
+	history := instance.Status.History
+	if rollback.Index != nil {
+		i := int(*rollback.Index)
+		if i < 0 || i >= len(history) {
+			return nil, fmt.Errorf("rollback index %d is outside of the %d history entries", i, len(history))
+		}
+		return &history[i], nil
+	}
+	if rollback.Commit != "" {
+		for i := range history {
+			if strings.HasPrefix(history[i].Commit, rollback.Commit) {
+				return &history[i], nil
+			}
+		}
+		return nil, fmt.Errorf("no history entry for commit %s", rollback.Commit)
+	}
+	return nil, fmt.Errorf("rollback needs an index or commit")
+}
+
+// rollback restores the ConfigMap of a property from a snapshot in its history instead of
+// rendering its revision
+func (r *ArchimedesPropertyReconciler) rollback(ctx context.Context, log logr.Logger, instance *backwoodsv1.ArchimedesProperty) (ctrl.Result, error) {
+	entry, err := rollbackEntry(instance)
+	if err != nil {
+		log.Error(err, "Could not find rollback entry")
+		r.updateConditions(ctx, log, instance, conditionReasonRollbackFailed, err.Error(), metav1.ConditionFalse)
+		return ctrl.Result{}, nil
+	}
+
+	snapshot := &corev1.ConfigMap{}
+	err = r.Get(ctx, types.NamespacedName{Name: entry.Snapshot, Namespace: instance.Namespace}, snapshot)
+	if err != nil {
+		log.Error(err, "Could not read rollback snapshot", "snapshot", entry.Snapshot)
+		r.updateConditions(ctx, log, instance, conditionReasonRollbackFailed, err.Error(), metav1.ConditionFalse)
+		return ctrl.Result{}, err
+	}
+
+	data := map[string]string{}
+	for k, v := range snapshot.Data {
+		data[k] = v
+	}
+	configmap := render.ConfigMap(instance, &render.Result{Data: data})
+	for k, v := range snapshot.Annotations {
+		if strings.HasPrefix(k, render.ProvenanceAnnotationPrefix) {
+			configmap.Annotations[k] = v
+		}
+	}
+
+	err = r.applyConfigMap(ctx, log, instance, configmap)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	instance.Status.Commit = entry.Commit
+	r.updateConditions(ctx, log, instance, conditionReasonRolledBack, fmt.Sprintf("Configmap was rolled back to commit %s rendered at %s", entry.Commit, entry.Timestamp.UTC().Format("2006-01-02T15:04:05Z")), metav1.ConditionTrue)
+	return ctrl.Result{}, nil
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	backwoodsv1 "github.com/backwoods-devops/archimedes/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestRollbackEntry(t *testing.T) {
+	history := []backwoodsv1.RenderHistoryEntry{
+		{Commit: "89abcdef", Snapshot: "trees-history-2"},
+		{Commit: "01234567", Snapshot: "trees-history-1"},
+		{Commit: "01234567", Snapshot: "trees-history-0"},
+	}
+	index := func(i int32) *int32 { return &i }
+	tests := []struct {
+		name     string
+		rollback backwoodsv1.Rollback
+		want     string
+		wantErr  bool
+	}{
+		{name: "index", rollback: backwoodsv1.Rollback{Index: index(1)}, want: "trees-history-1"},
+		{name: "index out of range", rollback: backwoodsv1.Rollback{Index: index(3)}, wantErr: true},
+		{name: "negative index", rollback: backwoodsv1.Rollback{Index: index(-1)}, wantErr: true},
+		{name: "commit prefix picks the newest entry", rollback: backwoodsv1.Rollback{Commit: "0123"}, want: "trees-history-1"},
+		{name: "index wins over commit", rollback: backwoodsv1.Rollback{Index: index(0), Commit: "0123"}, want: "trees-history-2"},
+		{name: "unknown commit", rollback: backwoodsv1.Rollback{Commit: "fedc"}, wantErr: true},
+		{name: "empty", rollback: backwoodsv1.Rollback{}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := testProperty("trees")
+			instance.Status.History = history
+			instance.Spec.Rollback = &tt.rollback
+			entry, err := rollbackEntry(instance)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("rollbackEntry() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && entry.Snapshot != tt.want {
+				t.Errorf("rollbackEntry() = %s, want %s", entry.Snapshot, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecordHistory(t *testing.T) {
+	ctx := context.Background()
+	instance := testProperty("trees")
+	limit := int32(2)
+	instance.Spec.HistoryLimit = &limit
+	r := newTestReconciler(t, instance)
+
+	// the first and third renders produce the same data and share a snapshot
+	renders := []struct{ hash, commit string }{
+		{hash: "aaaaaaaaaaaa", commit: "c1"},
+		{hash: "bbbbbbbbbbbb", commit: "c2"},
+		{hash: "aaaaaaaaaaaa", commit: "c3"},
+		{hash: "cccccccccccc", commit: "c4"},
+	}
+	for _, render := range renders {
+		instance.Status.ContentHash = render.hash
+		configmap := &corev1.ConfigMap{Data: map[string]string{"hash": render.hash}}
+		if err := r.recordHistory(ctx, instance, configmap, render.commit, "values"); err != nil {
+			t.Fatal(err)
+		}
+		// recording the same render again adds no entry
+		if err := r.recordHistory(ctx, instance, configmap, render.commit, "values"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	commits := []string{}
+	for _, entry := range instance.Status.History {
+		commits = append(commits, entry.Commit)
+	}
+	if want := []string{"c4", "c3"}; !reflect.DeepEqual(commits, want) {
+		t.Errorf("history commits = %v, want %v", commits, want)
+	}
+
+	list := &corev1.ConfigMapList{}
+	if err := r.List(ctx, list, client.MatchingLabels{historyLabel: "true"}); err != nil {
+		t.Fatal(err)
+	}
+	snapshots := []string{}
+	for _, cm := range list.Items {
+		snapshots = append(snapshots, cm.Name)
+		if cm.Labels[propertyLabel] != "trees" || cm.Immutable == nil || !*cm.Immutable {
+			t.Errorf("snapshot %s labels = %v, immutable = %v", cm.Name, cm.Labels, cm.Immutable)
+		}
+	}
+	sort.Strings(snapshots)
+	// the snapshot of c1 is still used by c3, the snapshot of c2 was deleted
+	if want := []string{"trees-history-aaaaaaaaaa", "trees-history-cccccccccc"}; !reflect.DeepEqual(snapshots, want) {
+		t.Errorf("snapshots = %v, want %v", snapshots, want)
+	}
+}
+
+func TestRecordHistoryLongConfigMapName(t *testing.T) {
+	ctx := context.Background()
+	instance := testProperty("trees")
+	instance.Spec.ConfigMapName = strings.Repeat("forest.", 35) + "trees"
+	instance.Status.ContentHash = "aaaaaaaaaaaa"
+	r := newTestReconciler(t, instance)
+
+	configmap := &corev1.ConfigMap{Data: map[string]string{"app": "trees"}}
+	if err := r.recordHistory(ctx, instance, configmap, "c1", "values"); err != nil {
+		t.Fatal(err)
+	}
+	snapshot := instance.Status.History[0].Snapshot
+	if msgs := validation.IsDNS1123Subdomain(snapshot); len(msgs) > 0 {
+		t.Errorf("snapshot name %q: %v", snapshot, msgs)
+	}
+	if !strings.HasSuffix(snapshot, "-history-aaaaaaaaaa") {
+		t.Errorf("snapshot name %q, want the history suffix", snapshot)
+	}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: "default", Name: snapshot}, &corev1.ConfigMap{}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRollback(t *testing.T) {
+	ctx := context.Background()
+	instance := testProperty("trees")
+	instance.Status.History = []backwoodsv1.RenderHistoryEntry{
+		{Commit: "89abcdef", Snapshot: "trees-history-2"},
+		{Commit: "01234567", Snapshot: "trees-history-1"},
+	}
+	instance.Spec.Rollback = &backwoodsv1.Rollback{Commit: "0123"}
+	snapshot := &corev1.ConfigMap{}
+	snapshot.Name, snapshot.Namespace = "trees-history-1", "default"
+	snapshot.Data = map[string]string{"db.url": "postgres://forest"}
+	r := newTestReconciler(t, instance, snapshot)
+
+	if _, err := r.rollback(ctx, r.Log, instance); err != nil {
+		t.Fatal(err)
+	}
+	configmap := &corev1.ConfigMap{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: "default", Name: "trees"}, configmap); err != nil {
+		t.Fatal(err)
+	}
+	if configmap.Data["db.url"] != "postgres://forest" {
+		t.Errorf("data = %v", configmap.Data)
+	}
+	if instance.Status.Commit != "01234567" {
+		t.Errorf("status.commit = %s, want 01234567", instance.Status.Commit)
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	backwoodsv1 "github.com/backwoods-devops/archimedes/api/v1"
+	"github.com/backwoods-devops/archimedes/pkg/render"
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// applyOutputs writes the v2 outputs of a property after the first, formatting the render
+// of the first output for each of them, and records their status on the property. The
+// ConfigMaps of outputs that were removed are left to be deleted with the property.
+// Failures are recorded in the property's conditions.
+func (r *ArchimedesPropertyReconciler) applyOutputs(ctx context.Context, log logr.Logger, instance *backwoodsv1.ArchimedesProperty, src *render.Template, result *render.Result, opts render.RenderOptions) error {
+	outputs, err := instance.AdditionalOutputs()
+	if err != nil {
+		log.Error(err, "Could not read the outputs of the property")
+		r.updateConditions(ctx, log, instance, conditionReasonMergeFailed, err.Error(), metav1.ConditionFalse)
+		return err
+	}
+
+	var statuses []backwoodsv1.OutputStatus
+	for _, out := range outputs {
+		output := instance.ForOutput(out)
+		outputResult, err := render.RenderOutput(output, src, result, opts)
+		if err != nil {
+			log.Error(err, "Could not format output", "output", out.ConfigMapName)
+			r.updateConditions(ctx, log, instance, conditionReasonMergeFailed, fmt.Sprintf("output %s: %s", out.ConfigMapName, err), metav1.ConditionFalse)
+			return err
+		}
+		if len(outputResult.Collisions) > 0 {
+			log.Info("Template keys collide with provenance keys", "output", out.ConfigMapName, "keys", outputResult.Collisions)
+		}
+
+		// the status is recorded before a failure is, the ConfigMap may have been written
+		reason, err := r.writeConfigMap(ctx, log, output, render.ConfigMap(output, outputResult))
+		setOutputStatus(instance, output)
+		if err != nil {
+			r.updateConditions(ctx, log, instance, reason, fmt.Sprintf("output %s: %s", out.ConfigMapName, err), metav1.ConditionFalse)
+			return err
+		}
+		statuses = append(statuses, outputStatus(output))
+	}
+	// the status of removed outputs is dropped once every output was written
+	instance.Status.Outputs = statuses
+	return nil
+}
+
+// outputStatus returns the status of an output from the property writing it
+func outputStatus(output *backwoodsv1.ArchimedesProperty) backwoodsv1.OutputStatus {
+	return backwoodsv1.OutputStatus{
+		Name:               output.Spec.ConfigMapName,
+		ContentHash:        output.Status.ContentHash,
+		RestartedWorkloads: output.Status.RestartedWorkloads,
+		ConfigMapName:      output.Status.ConfigMapName,
+	}
+}
+
+// setOutputStatus records the status of an output on a property
+func setOutputStatus(instance *backwoodsv1.ArchimedesProperty, output *backwoodsv1.ArchimedesProperty) {
+	status := outputStatus(output)
+	for i := range instance.Status.Outputs {
+		if instance.Status.Outputs[i].Name == status.Name {
+			instance.Status.Outputs[i] = status
+			return
+		}
+	}
+	instance.Status.Outputs = append(instance.Status.Outputs, status)
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	backwoodsv1 "github.com/backwoods-devops/archimedes/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestReconcileOutputs(t *testing.T) {
+	ctx := context.Background()
+	instance, r := pathProperty(t, "trees", map[string]string{"properties.tpl": "app=trees\n"})
+	retain := int32(0)
+	instance.Spec.Immutable = true
+	instance.Spec.RetainVersions = &retain
+	instance.Annotations = map[string]string{backwoodsv1.OutputsAnnotation: `[
+		{"configMapName": "trees-file", "type": "key", "keyName": "app.properties", "provenance": {"placement": "annotations"}},
+		{"configMapName": "trees-versions", "type": "kvp", "immutable": true, "retainVersions": 0}
+	]`}
+	if err := r.Update(ctx, instance); err != nil {
+		t.Fatal(err)
+	}
+	key := client.ObjectKeyFromObject(instance)
+
+	for _, content := range []string{"app=trees\n", "app=forest\n"} {
+		if err := ioutil.WriteFile(filepath.Join(instance.Spec.LocalPath, "properties.tpl"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got := &backwoodsv1.ArchimedesProperty{}
+	if err := r.Get(ctx, key, got); err != nil {
+		t.Fatal(err)
+	}
+	outputs := got.Status.Outputs
+	if len(outputs) != 2 || outputs[0].Name != "trees-file" || outputs[0].ConfigMapName != "trees-file" ||
+		outputs[1].Name != "trees-versions" || outputs[1].ConfigMapName != versionedConfigMapName("trees-versions", outputs[1].ContentHash) {
+		t.Fatalf("status.outputs = %+v", outputs)
+	}
+
+	file := &corev1.ConfigMap{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: "default", Name: "trees-file"}, file); err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]string{"app.properties": "app=forest"}; !reflect.DeepEqual(file.Data, want) {
+		t.Errorf("trees-file data = %v, want %v", file.Data, want)
+	}
+	if file.Annotations["archimedes.backwoods-devops.io/path"] != "properties.tpl" {
+		t.Errorf("trees-file annotations = %v", file.Annotations)
+	}
+	versions := &corev1.ConfigMap{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: "default", Name: outputs[1].ConfigMapName}, versions); err != nil {
+		t.Fatal(err)
+	}
+	if versions.Data["app"] != "forest" || versions.Data["path"] != "properties.tpl" {
+		t.Errorf("%s data = %v", versions.Name, versions.Data)
+	}
+
+	// every immutable output prunes its own previous versions and none of the others
+	list := &corev1.ConfigMapList{}
+	if err := r.List(ctx, list); err != nil {
+		t.Fatal(err)
+	}
+	names := []string{}
+	for _, cm := range list.Items {
+		if cm.Labels[historyLabel] == "" {
+			names = append(names, cm.Name)
+		}
+	}
+	sort.Strings(names)
+	want := []string{got.Status.ConfigMapName, "trees-file", outputs[1].ConfigMapName}
+	sort.Strings(want)
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("configmaps = %v, want %v", names, want)
+	}
+}
+
+func TestReconcileRemovedOutput(t *testing.T) {
+	ctx := context.Background()
+	instance, r := pathProperty(t, "trees", map[string]string{"properties.tpl": "app=trees\n"})
+	instance.Annotations = map[string]string{backwoodsv1.OutputsAnnotation: `[{"configMapName": "trees-file", "type": "key", "keyName": "app.properties"}]`}
+	if err := r.Update(ctx, instance); err != nil {
+		t.Fatal(err)
+	}
+	key := client.ObjectKeyFromObject(instance)
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatal(err)
+	}
+
+	got := &backwoodsv1.ArchimedesProperty{}
+	if err := r.Get(ctx, key, got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Status.Outputs) != 1 {
+		t.Fatalf("status.outputs = %+v", got.Status.Outputs)
+	}
+	got.Annotations = nil
+	if err := r.Update(ctx, got); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatal(err)
+	}
+	removed := &backwoodsv1.ArchimedesProperty{}
+	if err := r.Get(ctx, key, removed); err != nil {
+		t.Fatal(err)
+	}
+	if removed.Status.Outputs != nil {
+		t.Errorf("status.outputs of a removed output = %+v", removed.Status.Outputs)
+	}
+}
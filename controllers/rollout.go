@@ -0,0 +1,136 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	backwoodsv1 "github.com/backwoods-devops/archimedes/api/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// configHashAnnotation is set on the pod template of rollout targets to restart their pods
+const configHashAnnotation = "archimedes.backwoods-devops.io/config-hash"
+
+// rollout restarts the rollout targets of a property by setting the content hash on
+// their pod templates. References to the previous ConfigMap are moved to the current
+// one when its name changed, as are references to the configMapName of an immutable
+// property, which workloads are deployed with before its first version is created.
+// It returns the workloads that were patched.
+func (r *ArchimedesPropertyReconciler) rollout(ctx context.Context, instance *backwoodsv1.ArchimedesProperty, hash, previousName string) ([]string, error) {
+	restarted := []string{}
+	for _, target := range instance.Spec.RolloutTargets {
+		workloads, err := r.rolloutWorkloads(ctx, instance.Namespace, target)
+		if err != nil {
+			return restarted, err
+		}
+		for _, workload := range workloads {
+			patch := client.MergeFrom(workload.DeepCopyObject().(client.Object))
+			template := podTemplate(workload)
+			if template.Annotations == nil {
+				template.Annotations = map[string]string{}
+			}
+			template.Annotations[configHashAnnotation] = hash
+			if previousName != "" && previousName != instance.Status.ConfigMapName {
+				renameConfigMapRefs(&template.Spec, previousName, instance.Status.ConfigMapName)
+			}
+			if instance.Spec.Immutable {
+				renameConfigMapRefs(&template.Spec, instance.Spec.ConfigMapName, instance.Status.ConfigMapName)
+			}
+			err = r.Patch(ctx, workload, patch)
+			if err != nil {
+				return restarted, err
+			}
+			restarted = append(restarted, fmt.Sprintf("%s/%s", target.Kind, workload.GetName()))
+		}
+	}
+	return restarted, nil
+}
+
+// rolloutWorkloads returns the workloads matched by a rollout target
+func (r *ArchimedesPropertyReconciler) rolloutWorkloads(ctx context.Context, namespace string, target backwoodsv1.RolloutTarget) ([]client.Object, error) {
+	if target.Name != "" {
+		var workload client.Object
+		switch target.Kind {
+		case "Deployment":
+			workload = &appsv1.Deployment{}
+		case "StatefulSet":
+			workload = &appsv1.StatefulSet{}
+		case "DaemonSet":
+			workload = &appsv1.DaemonSet{}
+		default:
+			return nil, fmt.Errorf("unsupported rollout target kind %q", target.Kind)
+		}
+		err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: target.Name}, workload)
+		if err != nil {
+			return nil, err
+		}
+		return []client.Object{workload}, nil
+	}
+
+	if target.Selector == nil {
+		return nil, fmt.Errorf("rollout target of kind %s needs a name or selector", target.Kind)
+	}
+	selector, err := metav1.LabelSelectorAsSelector(target.Selector)
+	if err != nil {
+		return nil, err
+	}
+	opts := []client.ListOption{client.InNamespace(namespace), client.MatchingLabelsSelector{Selector: selector}}
+
+	workloads := []client.Object{}
+	switch target.Kind {
+	case "Deployment":
+		list := &appsv1.DeploymentList{}
+		err = r.List(ctx, list, opts...)
+		for i := range list.Items {
+			workloads = append(workloads, &list.Items[i])
+		}
+	case "StatefulSet":
+		list := &appsv1.StatefulSetList{}
+		err = r.List(ctx, list, opts...)
+		for i := range list.Items {
+			workloads = append(workloads, &list.Items[i])
+		}
+	case "DaemonSet":
+		list := &appsv1.DaemonSetList{}
+		err = r.List(ctx, list, opts...)
+		for i := range list.Items {
+			workloads = append(workloads, &list.Items[i])
+		}
+	default:
+		return nil, fmt.Errorf("unsupported rollout target kind %q", target.Kind)
+	}
+	return workloads, err
+}
+
+// podTemplate returns the pod template of a workload
+func podTemplate(workload client.Object) *corev1.PodTemplateSpec {
+	switch w := workload.(type) {
+	case *appsv1.Deployment:
+		return &w.Spec.Template
+	case *appsv1.StatefulSet:
+		return &w.Spec.Template
+	case *appsv1.DaemonSet:
+		return &w.Spec.Template
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	backwoodsv1 "github.com/backwoods-devops/archimedes/api/v1"
+	"github.com/backwoods-devops/archimedes/pkg/render"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// newTestReconciler returns a property reconciler reading and writing a fake client
+// seeded with objs. Reconciler behaviour is tested against the fake client rather than
+// the envtest suite in suite_test.go, so these tests run without an API server and etcd.
+func newTestReconciler(t *testing.T, objs ...client.Object) *ArchimedesPropertyReconciler {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := backwoodsv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	return &ArchimedesPropertyReconciler{Client: c, APIReader: c, Scheme: scheme, Log: ctrl.Log}
+}
+
+// testProperty returns a property in the default namespace
+func testProperty(name string) *backwoodsv1.ArchimedesProperty {
+	return &backwoodsv1.ArchimedesProperty{
+		TypeMeta:   metav1.TypeMeta{APIVersion: backwoodsv1.GroupVersion.String(), Kind: "ArchimedesProperty"},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", UID: "property-uid"},
+		Spec:       backwoodsv1.ArchimedesPropertySpec{ConfigMapName: name},
+	}
+}
+
+func testDeployment(name string, labels map[string]string, configMap string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: labels},
+		Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name:    "app",
+				EnvFrom: []corev1.EnvFromSource{{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: configMap}}}},
+			}},
+		}}},
+	}
+}
+
+func TestRollout(t *testing.T) {
+	ctx := context.Background()
+	r := newTestReconciler(t,
+		testDeployment("trees", nil, "trees-abc"),
+		testDeployment("birch", map[string]string{"app": "forest"}, "trees-abc"),
+		testDeployment("oak", map[string]string{"app": "forest"}, "trees-abc"),
+		testDeployment("pine", map[string]string{"app": "meadow"}, "trees-abc"),
+	)
+	instance := testProperty("trees")
+	instance.Status.ConfigMapName = "trees-def"
+	instance.Spec.RolloutTargets = []backwoodsv1.RolloutTarget{
+		{Kind: "Deployment", Name: "trees"},
+		{Kind: "Deployment", Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "forest"}}},
+	}
+
+	restarted, err := r.rollout(ctx, instance, "def", "trees-abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"Deployment/trees", "Deployment/birch", "Deployment/oak"}
+	if !reflect.DeepEqual(restarted, want) {
+		t.Errorf("restarted = %v, want %v", restarted, want)
+	}
+
+	for name, patched := range map[string]bool{"trees": true, "birch": true, "oak": true, "pine": false} {
+		deployment := &appsv1.Deployment{}
+		if err := r.Get(ctx, client.ObjectKey{Namespace: "default", Name: name}, deployment); err != nil {
+			t.Fatal(err)
+		}
+		template := deployment.Spec.Template
+		hash := template.Annotations[configHashAnnotation]
+		ref := template.Spec.Containers[0].EnvFrom[0].ConfigMapRef.Name
+		if patched && (hash != "def" || ref != "trees-def") {
+			t.Errorf("%s: hash = %q, configMapRef = %q, want def and trees-def", name, hash, ref)
+		}
+		if !patched && (hash != "" || ref != "trees-abc") {
+			t.Errorf("%s was patched: hash = %q, configMapRef = %q", name, hash, ref)
+		}
+	}
+}
+
+func TestApplyConfigMapImmutableRollout(t *testing.T) {
+	ctx := context.Background()
+	instance := testProperty("trees")
+	instance.Spec.Immutable = true
+	instance.Spec.RolloutTargets = []backwoodsv1.RolloutTarget{{Kind: "Deployment", Name: "trees"}}
+	// the workload is deployed with the configMapName before the first version exists
+	r := newTestReconciler(t, instance, testDeployment("trees", nil, "trees"))
+
+	for _, data := range []map[string]string{{"app": "trees"}, {"app": "forest"}} {
+		configmap := render.ConfigMap(instance, &render.Result{Data: data})
+		if err := r.applyConfigMap(ctx, r.Log, instance, configmap); err != nil {
+			t.Fatal(err)
+		}
+		want := versionedConfigMapName("trees", render.HashData(data))
+		if instance.Status.ConfigMapName != want {
+			t.Errorf("status.configMapName = %s, want %s", instance.Status.ConfigMapName, want)
+		}
+		deployment := &appsv1.Deployment{}
+		if err := r.Get(ctx, client.ObjectKey{Namespace: "default", Name: "trees"}, deployment); err != nil {
+			t.Fatal(err)
+		}
+		template := deployment.Spec.Template
+		if ref := template.Spec.Containers[0].EnvFrom[0].ConfigMapRef.Name; ref != want {
+			t.Errorf("configMapRef = %s, want %s", ref, want)
+		}
+		if hash := template.Annotations[configHashAnnotation]; hash != render.HashData(data) {
+			t.Errorf("hash = %s, want %s", hash, render.HashData(data))
+		}
+	}
+}
+
+func TestRolloutErrors(t *testing.T) {
+	r := newTestReconciler(t)
+	tests := []struct {
+		name   string
+		target backwoodsv1.RolloutTarget
+	}{
+		{name: "missing workload", target: backwoodsv1.RolloutTarget{Kind: "Deployment", Name: "trees"}},
+		{name: "unsupported kind", target: backwoodsv1.RolloutTarget{Kind: "CronJob", Name: "trees"}},
+		{name: "no name or selector", target: backwoodsv1.RolloutTarget{Kind: "Deployment"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := testProperty("trees")
+			instance.Spec.RolloutTargets = []backwoodsv1.RolloutTarget{tt.target}
+			if _, err := r.rollout(context.Background(), instance, "def", ""); err == nil {
+				t.Error("rollout() error = nil")
+			}
+		})
+	}
+}
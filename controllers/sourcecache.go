@@ -0,0 +1,203 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	backwoodsv1 "github.com/backwoods-devops/archimedes/api/v1"
+	"github.com/backwoods-devops/archimedes/pkg/render"
+	git "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// SourceCache keeps a working copy of the repo of every ArchimedesSource on disk, so
+// a repo is fetched once for all the properties referencing its source
+type SourceCache struct {
+	dir string
+
+	mu    sync.Mutex
+	locks map[types.NamespacedName]*sync.RWMutex
+}
+
+// NewSourceCache returns a SourceCache keeping its working copies under dir
+func NewSourceCache(dir string) *SourceCache {
+	return &SourceCache{
+		dir:   dir,
+		locks: map[types.NamespacedName]*sync.RWMutex{},
+	}
+}
+
+// cachedSource reads the templates of properties from the working copy of an
+// ArchimedesSource kept by a SourceCache
+type cachedSource struct {
+	cache    *SourceCache
+	key      types.NamespacedName
+	repoUrl  string
+	revision string
+	commit   render.Commit
+}
+
+// Fetch reads the template of a property from the working copy at the commit of the source
+func (s *cachedSource) Fetch(ctx context.Context, r *backwoodsv1.ArchimedesProperty) (*render.Template, error) {
+	var src *render.Template
+	err := s.cache.read(s.key, s.commit.Hash, func(dir string) error {
+		var err error
+		src, err = render.ReadTemplate(dir, s.repoUrl, s.revision, s.commit, r, render.GitOptions{})
+		return err
+	})
+	return src, err
+}
+
+// sourceNotReadyError is returned when the source of a property has not fetched its repo yet
+type sourceNotReadyError struct {
+	message string
+}
+
+func (e *sourceNotReadyError) Error() string {
+	return e.message
+}
+
+// isSourceNotReady reports whether err was caused by a source that has not fetched its repo
+func isSourceNotReady(err error) bool {
+	var notReady *sourceNotReadyError
+	return errors.As(err, &notReady)
+}
+
+func (c *SourceCache) lock(key types.NamespacedName) *sync.RWMutex {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	l, ok := c.locks[key]
+	if !ok {
+		l = &sync.RWMutex{}
+		c.locks[key] = l
+	}
+	return l
+}
+
+func (c *SourceCache) path(key types.NamespacedName) string {
+	return filepath.Join(c.dir, key.Namespace, key.Name)
+}
+
+// fetch brings the working copy of a source up to date with the revision of its repo
+// and returns the commit checked out. The working copy is cloned again when the url
+// of the repo changed.
+func (c *SourceCache) fetch(key types.NamespacedName, url, revision string, auth *http.BasicAuth, certs []byte) (*render.Commit, error) {
+	l := c.lock(key)
+	l.Lock()
+	defer l.Unlock()
+
+	dir := c.path(key)
+	repo, err := git.PlainOpen(dir)
+	if err == nil {
+		var remote *git.Remote
+		remote, err = repo.Remote(git.DefaultRemoteName)
+		if err == nil && (len(remote.Config().URLs) == 0 || remote.Config().URLs[0] != url) {
+			err = fmt.Errorf("remote url changed")
+		}
+	}
+	if err != nil {
+		err = os.RemoveAll(dir)
+		if err != nil {
+			return nil, err
+		}
+		err = os.MkdirAll(dir, 0o755)
+		if err != nil {
+			return nil, err
+		}
+		return render.CloneRepo(dir, url, revision, auth, certs)
+	}
+
+	remoteRef := plumbing.NewRemoteReferenceName(git.DefaultRemoteName, revision)
+	err = repo.Fetch(&git.FetchOptions{
+		RemoteName: git.DefaultRemoteName,
+		RefSpecs:   []config.RefSpec{config.RefSpec(fmt.Sprintf("+refs/heads/%s:%s", revision, remoteRef))},
+		Auth:       auth,
+		CABundle:   certs,
+		Tags:       git.AllTags,
+		Force:      true,
+	})
+	if err != nil && err != git.NoErrAlreadyUpToDate {
+		return nil, err
+	}
+	ref, err := repo.Reference(remoteRef, true)
+	if err != nil {
+		return nil, err
+	}
+
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return nil, err
+	}
+	err = worktree.Reset(&git.ResetOptions{Commit: ref.Hash(), Mode: git.HardReset})
+	if err != nil {
+		return nil, err
+	}
+	submodules, err := worktree.Submodules()
+	if err != nil {
+		return nil, err
+	}
+	err = submodules.Update(&git.SubmoduleUpdateOptions{
+		Init:              true,
+		RecurseSubmodules: git.DefaultSubmoduleRecursionDepth,
+		Auth:              auth,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return render.DescribeCommit(repo, ref.Hash())
+}
+
+// read calls fn with the directory of the working copy of a source once it has the
+// given commit checked out. The working copy is not changed until fn returns.
+func (c *SourceCache) read(key types.NamespacedName, commit string, fn func(dir string) error) error {
+	l := c.lock(key)
+	l.RLock()
+	defer l.RUnlock()
+
+	dir := c.path(key)
+	repo, err := git.PlainOpen(dir)
+	if err != nil {
+		return &sourceNotReadyError{message: fmt.Sprintf("ArchimedesSource %s has not been fetched", key.Name)}
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return err
+	}
+	if head.Hash().String() != commit {
+		return &sourceNotReadyError{message: fmt.Sprintf("ArchimedesSource %s is fetching commit %s", key.Name, commit)}
+	}
+	return fn(dir)
+}
+
+// remove deletes the working copy of a source
+func (c *SourceCache) remove(key types.NamespacedName) error {
+	l := c.lock(key)
+	l.Lock()
+	defer l.Unlock()
+
+	return os.RemoveAll(c.path(key))
+}
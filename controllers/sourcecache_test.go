@@ -0,0 +1,152 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	git "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// commitFiles writes files into the repo at dir, initialising it on its master branch
+// when it does not exist, and commits them. It returns the hash of the commit.
+func commitFiles(t *testing.T, dir string, files map[string]string) string {
+	t.Helper()
+	repo, err := git.PlainOpen(dir)
+	if err == git.ErrRepositoryNotExists {
+		repo, err = git.PlainInit(dir, false)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := worktree.Add(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	hash, err := worktree.Commit("update", &git.CommitOptions{Author: &object.Signature{Name: "test", When: time.Now()}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return hash.String()
+}
+
+// readCached returns the content of a file in the working copy of a source at a commit
+func readCached(c *SourceCache, key types.NamespacedName, commit, name string) (string, error) {
+	var content []byte
+	err := c.read(key, commit, func(dir string) error {
+		var err error
+		content, err = ioutil.ReadFile(filepath.Join(dir, name))
+		return err
+	})
+	return string(content), err
+}
+
+func TestSourceCacheFetch(t *testing.T) {
+	repoDir := t.TempDir()
+	first := commitFiles(t, repoDir, map[string]string{"properties.tpl": "app=trees\n"})
+	c := NewSourceCache(t.TempDir())
+	key := types.NamespacedName{Namespace: "default", Name: "trees"}
+
+	if _, err := readCached(c, key, first, "properties.tpl"); !isSourceNotReady(err) {
+		t.Fatalf("read() before fetch error = %v, want not ready", err)
+	}
+
+	commit, err := c.fetch(key, repoDir, "master", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if commit.Hash != first {
+		t.Errorf("fetch() commit = %s, want %s", commit.Hash, first)
+	}
+	if content, err := readCached(c, key, first, "properties.tpl"); err != nil || content != "app=trees\n" {
+		t.Errorf("read() = %q, %v", content, err)
+	}
+
+	// a new commit is fetched into the same working copy, resetting local changes
+	second := commitFiles(t, repoDir, map[string]string{"properties.tpl": "app=forest\n"})
+	if err := ioutil.WriteFile(filepath.Join(c.path(key), "properties.tpl"), []byte("changed\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	commit, err = c.fetch(key, repoDir, "master", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if commit.Hash != second {
+		t.Errorf("fetch() commit = %s, want %s", commit.Hash, second)
+	}
+	if content, err := readCached(c, key, second, "properties.tpl"); err != nil || content != "app=forest\n" {
+		t.Errorf("read() = %q, %v", content, err)
+	}
+	if _, err := readCached(c, key, first, "properties.tpl"); !isSourceNotReady(err) {
+		t.Errorf("read() of the previous commit error = %v, want not ready", err)
+	}
+
+	// a changed url clones the new repo in place of the working copy
+	otherDir := t.TempDir()
+	other := commitFiles(t, otherDir, map[string]string{"properties.tpl": "app=desert\n"})
+	commit, err = c.fetch(key, otherDir, "master", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if commit.Hash != other {
+		t.Errorf("fetch() commit = %s, want %s", commit.Hash, other)
+	}
+	if content, err := readCached(c, key, other, "properties.tpl"); err != nil || content != "app=desert\n" {
+		t.Errorf("read() = %q, %v", content, err)
+	}
+
+	if err := c.remove(key); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(c.path(key)); !os.IsNotExist(err) {
+		t.Errorf("working copy was not removed: %v", err)
+	}
+}
+
+func TestSourceCacheFetchErrors(t *testing.T) {
+	repoDir := t.TempDir()
+	commitFiles(t, repoDir, map[string]string{"properties.tpl": "app=trees\n"})
+	c := NewSourceCache(t.TempDir())
+	key := types.NamespacedName{Namespace: "default", Name: "trees"}
+
+	if _, err := c.fetch(key, filepath.Join(repoDir, "missing"), "master", nil, nil); err == nil {
+		t.Error("fetch() of a missing repo error = nil")
+	}
+	if _, err := c.fetch(key, repoDir, "release", nil, nil); err == nil {
+		t.Error("fetch() of a missing branch error = nil")
+	}
+	if _, err := c.fetch(key, repoDir, "master", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.fetch(key, repoDir, "release", nil, nil); err == nil {
+		t.Error("fetch() of a missing branch into a working copy error = nil")
+	}
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	backwoodsv1 "github.com/backwoods-devops/archimedes/api/v1"
+	"github.com/robfig/cron/v3"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	syncWindowAllow = "allow"
+	syncWindowDeny  = "deny"
+
+	// maxSyncWindowSteps bounds the search for the next time changes are allowed
+	maxSyncWindowSteps = 1000
+)
+
+// syncWindow is a SyncWindow with its schedule parsed
+type syncWindow struct {
+	kind     string
+	schedule cron.Schedule
+	duration time.Duration
+}
+
+// LoadSyncWindows reads operator-wide sync windows from a YAML file holding a list of windows
+func LoadSyncWindows(path string) ([]backwoodsv1.SyncWindow, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	windows := []backwoodsv1.SyncWindow{}
+	err = yaml.Unmarshal(content, &windows)
+	if err != nil {
+		return nil, err
+	}
+	_, err = parseSyncWindows(windows)
+	return windows, err
+}
+
+// parseSyncWindows parses the schedules of sync windows
+func parseSyncWindows(windows []backwoodsv1.SyncWindow) ([]syncWindow, error) {
+	parsed := make([]syncWindow, 0, len(windows))
+	for _, w := range windows {
+		if w.Kind != syncWindowAllow && w.Kind != syncWindowDeny {
+			return nil, fmt.Errorf("sync window kind must be %s or %s, not %q", syncWindowAllow, syncWindowDeny, w.Kind)
+		}
+		schedule, err := cron.ParseStandard(w.Schedule)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sync window schedule %q: %v", w.Schedule, err)
+		}
+		if schedule.Next(time.Now()).IsZero() {
+			return nil, fmt.Errorf("sync window schedule %q never fires", w.Schedule)
+		}
+		if w.Duration.Duration <= 0 {
+			return nil, fmt.Errorf("sync window %q needs a positive duration", w.Schedule)
+		}
+		parsed = append(parsed, syncWindow{kind: w.Kind, schedule: schedule, duration: w.Duration.Duration})
+	}
+	return parsed, nil
+}
+
+// openUntil reports whether the window is open at t and, if so, when it closes
+func (w syncWindow) openUntil(t time.Time) (time.Time, bool) {
+	last := w.schedule.Next(t.Add(-w.duration))
+	if last.IsZero() || last.After(t) {
+		return time.Time{}, false
+	}
+	// Binary search the last time the window opened at or before t, so a schedule firing
+	// often within a long duration takes a few steps. Schedules fire on whole seconds, last
+	// always opened the window and it does not open in (before, t].
+	before := t
+	for before.Sub(last) >= time.Second {
+		mid := last.Add(before.Sub(last) / 2)
+		if next := w.schedule.Next(mid); !next.IsZero() && !next.After(t) {
+			last = next
+		} else {
+			before = mid
+		}
+	}
+	end := last.Add(w.duration)
+	return end, end.After(t)
+}
+
+// syncAllowed reports whether changes may be written at t
+func syncAllowed(windows []syncWindow, t time.Time) bool {
+	hasAllow, inAllow := false, false
+	for _, w := range windows {
+		_, open := w.openUntil(t)
+		switch w.kind {
+		case syncWindowDeny:
+			if open {
+				return false
+			}
+		case syncWindowAllow:
+			hasAllow = true
+			inAllow = inAllow || open
+		}
+	}
+	return !hasAllow || inAllow
+}
+
+// nextSyncTime returns the first time from now at which changes may be written. It steps
+// through the times at which any window opens or closes, as only then can the result change.
+func nextSyncTime(windows []syncWindow, now time.Time) (time.Time, error) {
+	t := now
+	for i := 0; i < maxSyncWindowSteps; i++ {
+		if syncAllowed(windows, t) {
+			return t, nil
+		}
+		var next time.Time
+		for _, w := range windows {
+			change, open := w.openUntil(t)
+			if !open {
+				change = w.schedule.Next(t)
+			}
+			if !change.IsZero() && (next.IsZero() || change.Before(next)) {
+				next = change
+			}
+		}
+		if next.IsZero() {
+			break
+		}
+		t = next
+	}
+	return time.Time{}, fmt.Errorf("sync windows never allow changes")
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	backwoodsv1 "github.com/backwoods-devops/archimedes/api/v1"
+	"github.com/robfig/cron/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// at returns a time on Monday 2021-10-04, schedules are parsed in the local time zone
+func at(hour, min int) time.Time {
+	return time.Date(2021, 10, 4, hour, min, 0, 0, time.Local)
+}
+
+func testWindow(t *testing.T, kind, schedule string, duration time.Duration) syncWindow {
+	t.Helper()
+	parsed, err := cron.ParseStandard(schedule)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return syncWindow{kind: kind, schedule: parsed, duration: duration}
+}
+
+func TestOpenUntil(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule string
+		duration time.Duration
+		t        time.Time
+		wantEnd  time.Time
+		wantOpen bool
+	}{
+		{name: "open", schedule: "0 9 * * *", duration: time.Hour, t: at(9, 30), wantEnd: at(10, 0), wantOpen: true},
+		{name: "opening", schedule: "0 9 * * *", duration: time.Hour, t: at(9, 0), wantEnd: at(10, 0), wantOpen: true},
+		{name: "closing", schedule: "0 9 * * *", duration: time.Hour, t: at(10, 0)},
+		{name: "closed", schedule: "0 9 * * *", duration: time.Hour, t: at(8, 59)},
+		{name: "every minute for a day", schedule: "* * * * *", duration: 24 * time.Hour, t: at(12, 0).Add(30 * time.Second), wantEnd: at(12, 0).Add(24 * time.Hour), wantOpen: true},
+		{name: "last of overlapping openings", schedule: "*/15 * * * *", duration: time.Hour, t: at(9, 20), wantEnd: at(10, 15), wantOpen: true},
+		{name: "never fires", schedule: "0 0 30 2 *", duration: time.Hour, t: at(9, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			end, open := testWindow(t, syncWindowAllow, tt.schedule, tt.duration).openUntil(tt.t)
+			if open != tt.wantOpen || (open && !end.Equal(tt.wantEnd)) {
+				t.Errorf("openUntil() = %v, %v, want %v, %v", end, open, tt.wantEnd, tt.wantOpen)
+			}
+		})
+	}
+}
+
+func TestNextSyncTime(t *testing.T) {
+	tests := []struct {
+		name    string
+		windows []syncWindow
+		now     time.Time
+		want    time.Time
+		wantErr bool
+	}{
+		{name: "no windows", now: at(9, 0), want: at(9, 0)},
+		{
+			name:    "inside an allow window",
+			windows: []syncWindow{testWindow(t, syncWindowAllow, "0 9 * * 1-5", 8*time.Hour)},
+			now:     at(10, 0),
+			want:    at(10, 0),
+		},
+		{
+			name:    "before an allow window",
+			windows: []syncWindow{testWindow(t, syncWindowAllow, "0 9 * * 1-5", 8*time.Hour)},
+			now:     at(7, 0),
+			want:    at(9, 0),
+		},
+		{
+			name: "deny inside an allow window",
+			windows: []syncWindow{
+				testWindow(t, syncWindowAllow, "0 9 * * 1-5", 8*time.Hour),
+				testWindow(t, syncWindowDeny, "0 12 * * *", time.Hour),
+			},
+			now:  at(12, 30),
+			want: at(13, 0),
+		},
+		{
+			name:    "always denied",
+			windows: []syncWindow{testWindow(t, syncWindowDeny, "* * * * *", 24*time.Hour)},
+			now:     at(9, 0),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := nextSyncTime(tt.windows, tt.now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("nextSyncTime() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !got.Equal(tt.want) {
+				t.Errorf("nextSyncTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSyncWindows(t *testing.T) {
+	tests := []struct {
+		name    string
+		window  backwoodsv1.SyncWindow
+		wantErr bool
+	}{
+		{name: "valid", window: backwoodsv1.SyncWindow{Kind: "allow", Schedule: "0 9 * * *", Duration: metav1.Duration{Duration: time.Hour}}},
+		{name: "invalid kind", window: backwoodsv1.SyncWindow{Kind: "hold", Schedule: "0 9 * * *", Duration: metav1.Duration{Duration: time.Hour}}, wantErr: true},
+		{name: "invalid schedule", window: backwoodsv1.SyncWindow{Kind: "allow", Schedule: "every day", Duration: metav1.Duration{Duration: time.Hour}}, wantErr: true},
+		{name: "never fires", window: backwoodsv1.SyncWindow{Kind: "allow", Schedule: "0 0 30 2 *", Duration: metav1.Duration{Duration: time.Hour}}, wantErr: true},
+		{name: "no duration", window: backwoodsv1.SyncWindow{Kind: "allow", Schedule: "0 9 * * *"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseSyncWindows([]backwoodsv1.SyncWindow{tt.window})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseSyncWindows() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	backwoodsv1 "github.com/backwoods-devops/archimedes/api/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// propertiesForValues returns a request for every property in the namespace of a
+// ArchimedesValues, the properties using the values are found when they render
+func (r *ArchimedesPropertyReconciler) propertiesForValues(obj client.Object) []reconcile.Request {
+	return r.propertiesIn(client.InNamespace(obj.GetNamespace()))
+}
+
+// propertiesForClusterValues returns a request for every property, as cluster values
+// may be inherited or referenced from any namespace
+func (r *ArchimedesPropertyReconciler) propertiesForClusterValues(obj client.Object) []reconcile.Request {
+	return r.propertiesIn()
+}
+
+// propertiesForNamespace returns a request for every property in a namespace, so
+// changes to its labels are reflected in the cluster values it inherits
+func (r *ArchimedesPropertyReconciler) propertiesForNamespace(obj client.Object) []reconcile.Request {
+	return r.propertiesIn(client.InNamespace(obj.GetName()))
+}
+
+func (r *ArchimedesPropertyReconciler) propertiesIn(opts ...client.ListOption) []reconcile.Request {
+	list := &backwoodsv1.ArchimedesPropertyList{}
+	err := r.List(context.Background(), list, opts...)
+	if err != nil {
+		r.Log.Error(err, "Could not list properties")
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(list.Items))
+	for _, property := range list.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&property)})
+	}
+	return requests
+}
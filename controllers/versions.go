@@ -0,0 +1,159 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"strings"
+
+	backwoodsv1 "github.com/backwoods-devops/archimedes/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// propertyLabel is set on ConfigMaps created for a property to the labelValue of the
+	// name of the property
+	propertyLabel = "archimedes.backwoods-devops.io/property"
+
+	// defaultRetainVersions is the number of previous immutable ConfigMaps kept by default
+	defaultRetainVersions = 3
+)
+
+// labelValue returns a name as a label value. Names longer than a label value may be are
+// truncated and suffixed with a hash of the whole name, objects selected by such a label
+// are matched to their owner as well.
+func labelValue(name string) string {
+	if len(name) <= validation.LabelValueMaxLength {
+		return name
+	}
+	sum := sha256.Sum256([]byte(name))
+	prefix := strings.TrimRight(name[:validation.LabelValueMaxLength-11], "-.")
+	return fmt.Sprintf("%s-%x", prefix, sum[:5])
+}
+
+// suffixedName returns name followed by suffix as the name of an object. Names too long for
+// an object name are truncated and suffixed with a hash of the whole name, so objects named
+// after different names stay apart.
+func suffixedName(name, suffix string) string {
+	if len(name)+len(suffix) <= validation.DNS1123SubdomainMaxLength {
+		return name + suffix
+	}
+	sum := sha256.Sum256([]byte(name))
+	short := fmt.Sprintf("-%x", sum[:5])
+	prefix := strings.TrimRight(name[:validation.DNS1123SubdomainMaxLength-len(suffix)-len(short)], "-.")
+	return prefix + short + suffix
+}
+
+// versionedConfigMapName returns the name of the immutable ConfigMap holding content with the given hash
+func versionedConfigMapName(name, hash string) string {
+	return suffixedName(name, "-"+hash[:10])
+}
+
+// isVersionOf reports whether a ConfigMap name is a versionedConfigMapName of name
+func isVersionOf(configMapName, name string) bool {
+	return len(configMapName) > 10 && versionedConfigMapName(name, configMapName[len(configMapName)-10:]) == configMapName
+}
+
+// pruneConfigMapVersions deletes the immutable ConfigMaps of a property beyond the
+// number of previous versions it retains. The current ConfigMap is never deleted, nor are
+// the versions of the other outputs of the property.
+func (r *ArchimedesPropertyReconciler) pruneConfigMapVersions(ctx context.Context, instance *backwoodsv1.ArchimedesProperty) ([]string, error) {
+	retain := defaultRetainVersions
+	if instance.Spec.RetainVersions != nil {
+		retain = int(*instance.Spec.RetainVersions)
+	}
+
+	list := &corev1.ConfigMapList{}
+	err := r.List(ctx, list, client.InNamespace(instance.Namespace), client.MatchingLabels{propertyLabel: labelValue(instance.Name)})
+	if err != nil {
+		return nil, err
+	}
+
+	versions := []corev1.ConfigMap{}
+	for _, cm := range list.Items {
+		if cm.Name == instance.Status.ConfigMapName || !metav1.IsControlledBy(&cm, instance) {
+			continue
+		}
+		if cm.Immutable == nil || !*cm.Immutable || cm.Labels[historyLabel] != "" || !isVersionOf(cm.Name, instance.Spec.ConfigMapName) {
+			continue
+		}
+		versions = append(versions, cm)
+	}
+	if len(versions) <= retain {
+		return nil, nil
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[j].CreationTimestamp.Before(&versions[i].CreationTimestamp)
+	})
+	pruned := []string{}
+	for i := retain; i < len(versions); i++ {
+		err = r.Delete(ctx, &versions[i])
+		if err != nil && !errors.IsNotFound(err) {
+			return pruned, err
+		}
+		pruned = append(pruned, versions[i].Name)
+	}
+	return pruned, nil
+}
+
+// renameConfigMapRefs points every reference to the ConfigMap named from in a pod spec to to
+func renameConfigMapRefs(spec *corev1.PodSpec, from, to string) {
+	for i := range spec.Volumes {
+		volume := &spec.Volumes[i]
+		if volume.ConfigMap != nil && volume.ConfigMap.Name == from {
+			volume.ConfigMap.Name = to
+		}
+		if volume.Projected != nil {
+			for j := range volume.Projected.Sources {
+				source := &volume.Projected.Sources[j]
+				if source.ConfigMap != nil && source.ConfigMap.Name == from {
+					source.ConfigMap.Name = to
+				}
+			}
+		}
+	}
+
+	containers := []*corev1.Container{}
+	for i := range spec.InitContainers {
+		containers = append(containers, &spec.InitContainers[i])
+	}
+	for i := range spec.Containers {
+		containers = append(containers, &spec.Containers[i])
+	}
+	for _, container := range containers {
+		for j := range container.EnvFrom {
+			ref := container.EnvFrom[j].ConfigMapRef
+			if ref != nil && ref.Name == from {
+				ref.Name = to
+			}
+		}
+		for j := range container.Env {
+			valueFrom := container.Env[j].ValueFrom
+			if valueFrom != nil && valueFrom.ConfigMapKeyRef != nil && valueFrom.ConfigMapKeyRef.Name == from {
+				valueFrom.ConfigMapKeyRef.Name = to
+			}
+		}
+	}
+}
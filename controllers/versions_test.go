@@ -0,0 +1,160 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	backwoodsv1 "github.com/backwoods-devops/archimedes/api/v1"
+	"github.com/backwoods-devops/archimedes/pkg/render"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestLabelValue(t *testing.T) {
+	if got := labelValue("trees"); got != "trees" {
+		t.Errorf("labelValue(trees) = %q", got)
+	}
+	long := strings.Repeat("forest.", 30) + "trees"
+	got := labelValue(long)
+	if msgs := validation.IsValidLabelValue(got); len(msgs) > 0 {
+		t.Errorf("labelValue() = %q: %v", got, msgs)
+	}
+	if other := labelValue(strings.Repeat("forest.", 30) + "birch"); other == got {
+		t.Errorf("labelValue() of different names = %q", got)
+	}
+}
+
+func TestVersionedConfigMapName(t *testing.T) {
+	hash := render.HashData(map[string]string{"app": "trees"})
+	if got, want := versionedConfigMapName("trees", hash), "trees-"+hash[:10]; got != want {
+		t.Errorf("versionedConfigMapName(trees) = %q, want %q", got, want)
+	}
+	long := strings.Repeat("forest.", 36) + "trees"
+	got := versionedConfigMapName(long, hash)
+	if msgs := validation.IsDNS1123Subdomain(got); len(msgs) > 0 {
+		t.Errorf("versionedConfigMapName() = %q: %v", got, msgs)
+	}
+	if !strings.HasSuffix(got, "-"+hash[:10]) {
+		t.Errorf("versionedConfigMapName() = %q, want the hash suffix", got)
+	}
+	if other := versionedConfigMapName(strings.Repeat("forest.", 36)+"birch", hash); other == got {
+		t.Errorf("versionedConfigMapName() of different names = %q", got)
+	}
+}
+
+func TestPruneConfigMapVersions(t *testing.T) {
+	instance := testProperty("trees")
+	instance.Status.ConfigMapName = "trees-c000000000"
+	retain := int32(2)
+	instance.Spec.RetainVersions = &retain
+
+	owner := metav1.NewControllerRef(instance, backwoodsv1.GroupVersion.WithKind("ArchimedesProperty"))
+	immutable := true
+	now := time.Now()
+	version := func(name string, age time.Duration, labels map[string]string, owned bool) client.Object {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "default",
+				Labels:            map[string]string{propertyLabel: "trees"},
+				CreationTimestamp: metav1.NewTime(now.Add(-age)),
+			},
+			Immutable: &immutable,
+		}
+		for k, v := range labels {
+			cm.Labels[k] = v
+		}
+		if owned {
+			cm.OwnerReferences = []metav1.OwnerReference{*owner}
+		}
+		return cm
+	}
+	r := newTestReconciler(t,
+		version("trees-c000000000", 5*time.Hour, nil, true),
+		version("trees-1000000000", time.Hour, nil, true),
+		version("trees-2000000000", 2*time.Hour, nil, true),
+		version("trees-3000000000", 3*time.Hour, nil, true),
+		version("trees-4000000000", 4*time.Hour, nil, true),
+		version("trees-history-5000000000", 6*time.Hour, map[string]string{historyLabel: "true"}, true),
+		version("trees-f000000000", 7*time.Hour, nil, false),
+		// a version of another output of the property
+		version("trees-file-8000000000", 8*time.Hour, nil, true),
+	)
+
+	pruned, err := r.pruneConfigMapVersions(context.Background(), instance)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"trees-3000000000", "trees-4000000000"}
+	if !reflect.DeepEqual(pruned, want) {
+		t.Errorf("pruned = %v, want %v", pruned, want)
+	}
+
+	list := &corev1.ConfigMapList{}
+	if err := r.List(context.Background(), list); err != nil {
+		t.Fatal(err)
+	}
+	kept := []string{}
+	for _, cm := range list.Items {
+		kept = append(kept, cm.Name)
+	}
+	sort.Strings(kept)
+	want = []string{"trees-1000000000", "trees-2000000000", "trees-c000000000", "trees-f000000000", "trees-file-8000000000", "trees-history-5000000000"}
+	if !reflect.DeepEqual(kept, want) {
+		t.Errorf("kept = %v, want %v", kept, want)
+	}
+}
+
+func TestRenameConfigMapRefs(t *testing.T) {
+	ref := func(name string) corev1.LocalObjectReference { return corev1.LocalObjectReference{Name: name} }
+	container := func(name string) corev1.Container {
+		return corev1.Container{
+			EnvFrom: []corev1.EnvFromSource{{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: ref(name)}}},
+			Env: []corev1.EnvVar{{Name: "DB", ValueFrom: &corev1.EnvVarSource{
+				ConfigMapKeyRef: &corev1.ConfigMapKeySelector{LocalObjectReference: ref(name), Key: "db"},
+			}}},
+		}
+	}
+	spec := func(name, other string) *corev1.PodSpec {
+		return &corev1.PodSpec{
+			Volumes: []corev1.Volume{
+				{Name: "config", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: ref(name)}}},
+				{Name: "projected", VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{Sources: []corev1.VolumeProjection{
+					{ConfigMap: &corev1.ConfigMapProjection{LocalObjectReference: ref(name)}},
+					{ConfigMap: &corev1.ConfigMapProjection{LocalObjectReference: ref(other)}},
+				}}}},
+				{Name: "other", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: ref(other)}}},
+			},
+			InitContainers: []corev1.Container{container(name)},
+			Containers:     []corev1.Container{container(name), container(other)},
+		}
+	}
+
+	got := spec("trees-abc", "birch")
+	renameConfigMapRefs(got, "trees-abc", "trees-def")
+	if want := spec("trees-def", "birch"); !reflect.DeepEqual(got, want) {
+		t.Errorf("renameConfigMapRefs() = %+v, want %+v", got, want)
+	}
+}
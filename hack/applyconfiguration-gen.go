@@ -0,0 +1,65 @@
+//go:build ignore
+// +build ignore
+
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// applyconfiguration-gen runs the applyconfiguration-gen of code-generator v0.22 with the
+// apply configurations of the meta/v1 types the API references. Its
+// --external-applyconfigurations flag cannot parse packages with a dot in their path, such
+// as k8s.io/apimachinery, so they are set here instead. make code-generator builds it.
+package main
+
+import (
+	"flag"
+
+	"github.com/spf13/pflag"
+	"k8s.io/gengo/types"
+	"k8s.io/klog/v2"
+
+	generatorargs "k8s.io/code-generator/cmd/applyconfiguration-gen/args"
+	"k8s.io/code-generator/cmd/applyconfiguration-gen/generators"
+)
+
+const (
+	meta      = "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metaApply = "k8s.io/client-go/applyconfigurations/meta/v1"
+)
+
+func main() {
+	klog.InitFlags(nil)
+	genericArgs, customArgs := generatorargs.NewDefaults()
+	for _, name := range []string{"OwnerReference", "ManagedFieldsEntry", "Condition", "LabelSelector", "LabelSelectorRequirement"} {
+		customArgs.ExternalApplyConfigurations[types.Name{Package: meta, Name: name}] = metaApply
+	}
+	genericArgs.AddFlags(pflag.CommandLine)
+	pflag.CommandLine.AddGoFlagSet(flag.CommandLine)
+	if err := flag.Set("logtostderr", "true"); err != nil {
+		klog.Fatalf("Error: %v", err)
+	}
+	pflag.Parse()
+
+	if err := generatorargs.Validate(genericArgs); err != nil {
+		klog.Fatalf("Error: %v", err)
+	}
+	if err := genericArgs.Execute(
+		generators.NameSystems(),
+		generators.DefaultNameSystem(),
+		generators.Packages,
+	); err != nil {
+		klog.Fatalf("Error: %v", err)
+	}
+}
@@ -0,0 +1,76 @@
+#!/usr/bin/env bash
+
+# Generates the typed clientset, listers, informers and apply configurations of the
+# archimedes.backwoods-devops.io/v1 API into pkg/client. Run it with make generate-client.
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+ROOT=$(cd "$(dirname "${BASH_SOURCE[0]}")/.." && pwd)
+BIN=${BIN:-${ROOT}/bin}
+MODULE=github.com/backwoods-devops/archimedes
+APIS=${MODULE}/api/v1
+OUTPUT=${MODULE}/pkg/client
+HEADER=${ROOT}/hack/boilerplate.go.txt
+PLURALS=ArchimedesValues:ArchimedesValues,ClusterArchimedesValues:ClusterArchimedesValues
+
+# The generators take the group from the directory above the version and client-gen treats a
+# directory named api as the core group, so they read the types through a link named after it
+LINK=${ROOT}/zz_codegen
+GROUP_APIS=${MODULE}/zz_codegen/archimedes/v1
+
+TMP=$(mktemp -d)
+trap 'rm -rf "${TMP}" "${LINK}"' EXIT
+
+cd "${ROOT}"
+mkdir -p "${LINK}/archimedes"
+ln -s ../../api/v1 "${LINK}/archimedes/v1"
+
+"${BIN}/applyconfiguration-gen" \
+  --go-header-file "${HEADER}" \
+  --input-dirs "${GROUP_APIS}" \
+  --output-package "${OUTPUT}/applyconfiguration" \
+  --output-base "${TMP}"
+
+"${BIN}/client-gen" \
+  --go-header-file "${HEADER}" \
+  --plural-exceptions "${PLURALS}" \
+  --clientset-name versioned \
+  --input-base "" \
+  --input "${GROUP_APIS}" \
+  --apply-configuration-package "${OUTPUT}/applyconfiguration" \
+  --output-package "${OUTPUT}/clientset" \
+  --output-base "${TMP}"
+
+"${BIN}/lister-gen" \
+  --go-header-file "${HEADER}" \
+  --plural-exceptions "${PLURALS}" \
+  --input-dirs "${GROUP_APIS}" \
+  --output-package "${OUTPUT}/listers" \
+  --output-base "${TMP}"
+
+"${BIN}/informer-gen" \
+  --go-header-file "${HEADER}" \
+  --plural-exceptions "${PLURALS}" \
+  --input-dirs "${GROUP_APIS}" \
+  --versioned-clientset-package "${OUTPUT}/clientset/versioned" \
+  --listers-package "${OUTPUT}/listers" \
+  --output-package "${OUTPUT}/informers" \
+  --output-base "${TMP}"
+
+grep -rl "${GROUP_APIS}" "${TMP}" | xargs sed -i.bak "s|${GROUP_APIS}|${APIS}|g"
+
+# The tracker of the fake clientset guesses the resource of the objects it is seeded with,
+# archimedesvalueses for values, so they are added through addObject
+FAKE=${TMP}/${OUTPUT}/clientset/versioned/fake
+sed -i.bak 's|o.Add(obj)|addObject(o, obj)|' "${FAKE}/clientset_generated.go"
+cp "${ROOT}/hack/fake_tracker.go.txt" "${FAKE}/tracker.go"
+find "${TMP}" -name '*.bak' -delete
+
+for dir in applyconfiguration clientset informers listers; do
+  rm -rf "${ROOT}/pkg/client/${dir}"
+  cp -r "${TMP}/${OUTPUT}/${dir}" "${ROOT}/pkg/client/${dir}"
+done
+
+gofmt -w pkg/client
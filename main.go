@@ -22,6 +22,7 @@ import (
 	"os"
 	"regexp"
 	"strings"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -36,7 +37,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	backwoodsv1 "github.com/backwoods-devops/archimedes/api/v1"
+	backwoodsv2 "github.com/backwoods-devops/archimedes/api/v2"
 	"github.com/backwoods-devops/archimedes/controllers"
+	"github.com/backwoods-devops/archimedes/pkg/render"
 	//+kubebuilder:scaffold:imports
 )
 
@@ -49,6 +52,7 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
 	utilruntime.Must(backwoodsv1.AddToScheme(scheme))
+	utilruntime.Must(backwoodsv2.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
 }
 
@@ -56,11 +60,44 @@ func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
 	var probeAddr string
+	var renderTimeout time.Duration
+	var maxRenderSize int
+	var maxIncludeDepth int
+	var lookupNamespaces string
+	var clusterDomain string
+	var syncWindowsFile string
+	var validateSourceConfig bool
+	var sourceCacheDir string
+	var clusterValues bool
+	var propertySets bool
+	var localPaths string
+	var httpURLPrefixes string
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.DurationVar(&renderTimeout, "render-timeout", 10*time.Second, "The longest a single property template render may run.")
+	flag.IntVar(&maxRenderSize, "max-render-size", 1024*1024, "The maximum size in bytes of a rendered property template.")
+	flag.IntVar(&maxIncludeDepth, "max-include-depth", 16, "The maximum nesting of include and template calls in a property template.")
+	flag.StringVar(&lookupNamespaces, "lookup-namespaces", "",
+		"Comma separated namespaces template lookups may read from besides the property's own. "+
+			"Use * to allow every namespace.")
+	flag.StringVar(&clusterDomain, "cluster-domain", "cluster.local", "The DNS domain of the cluster used to build service hosts.")
+	flag.StringVar(&syncWindowsFile, "sync-windows-file", "",
+		"Path to a YAML file listing sync windows that apply to every property.")
+	flag.BoolVar(&validateSourceConfig, "webhook-validate-source-config", true,
+		"Reject properties whose sourceConfig is not valid YAML at admission.")
+	flag.StringVar(&sourceCacheDir, "source-cache-dir", "/tmp/archimedes-sources",
+		"Directory the repos of ArchimedesSources are fetched into.")
+	flag.BoolVar(&clusterValues, "cluster-values", true,
+		"Merge ClusterArchimedesValues into properties, this needs access to namespaces and cluster-scoped resources.")
+	flag.BoolVar(&propertySets, "property-sets", true,
+		"Run the ArchimedesPropertySet controller, this needs access to namespaces and cluster-scoped resources.")
+	flag.StringVar(&localPaths, "local-paths", "",
+		"Comma separated directories, such as mounted volumes, properties with the path source type may read templates from.")
+	flag.StringVar(&httpURLPrefixes, "http-url-prefixes", "",
+		"Comma separated urls, such as https://templates.example.com/teams, properties with the http source type may download templates from.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -101,14 +138,78 @@ func main() {
 		os.Exit(1)
 	}
 
+	var allowedLookupNamespaces []string
+	if lookupNamespaces != "" {
+		allowedLookupNamespaces = strings.Split(strings.ReplaceAll(lookupNamespaces, " ", ""), ",")
+	}
+
+	var syncWindows []backwoodsv1.SyncWindow
+	if syncWindowsFile != "" {
+		syncWindows, err = controllers.LoadSyncWindows(syncWindowsFile)
+		if err != nil {
+			setupLog.Error(err, "unable to load sync windows", "file", syncWindowsFile)
+			os.Exit(1)
+		}
+	}
+
+	sources := controllers.NewSourceCache(sourceCacheDir)
+
+	var allowedLocalPaths []string
+	if localPaths != "" {
+		allowedLocalPaths = strings.Split(strings.ReplaceAll(localPaths, " ", ""), ",")
+	}
+	var allowedHTTPURLPrefixes []string
+	if httpURLPrefixes != "" {
+		allowedHTTPURLPrefixes = strings.Split(strings.ReplaceAll(httpURLPrefixes, " ", ""), ",")
+	}
+
 	if err = (&controllers.ArchimedesPropertyReconciler{
-		Client: mgr.GetClient(),
-		Log:    ctrl.Log.WithName("controllers").WithName("ArchimedesProperties"),
-		Scheme: mgr.GetScheme(),
+		Client:    mgr.GetClient(),
+		Log:       ctrl.Log.WithName("controllers").WithName("ArchimedesProperties"),
+		Scheme:    mgr.GetScheme(),
+		APIReader: mgr.GetAPIReader(),
+
+		RenderTimeout:   renderTimeout,
+		MaxRenderSize:   maxRenderSize,
+		MaxIncludeDepth: maxIncludeDepth,
+
+		LookupNamespaces: allowedLookupNamespaces,
+		ClusterDomain:    clusterDomain,
+		SyncWindows:      syncWindows,
+		Sources:          sources,
+		ClusterValues:    clusterValues,
+		HTTP:             render.NewHTTPSource(render.HTTPOptions{URLPrefixes: allowedHTTPURLPrefixes}),
+		LocalPaths:       allowedLocalPaths,
+		HTTPURLPrefixes:  allowedHTTPURLPrefixes,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ArchimedesProperty")
 		os.Exit(1)
 	}
+	if err = (&controllers.ArchimedesSourceReconciler{
+		Client:  mgr.GetClient(),
+		Log:     ctrl.Log.WithName("controllers").WithName("ArchimedesSources"),
+		Scheme:  mgr.GetScheme(),
+		Sources: sources,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ArchimedesSource")
+		os.Exit(1)
+	}
+	if propertySets {
+		if err = (&controllers.ArchimedesPropertySetReconciler{
+			Client: mgr.GetClient(),
+			Log:    ctrl.Log.WithName("controllers").WithName("ArchimedesPropertySets"),
+			Scheme: mgr.GetScheme(),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ArchimedesPropertySet")
+			os.Exit(1)
+		}
+	}
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err = (&backwoodsv1.PropertyWebhook{ValidateSourceConfig: validateSourceConfig}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ArchimedesProperty")
+			os.Exit(1)
+		}
+	}
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
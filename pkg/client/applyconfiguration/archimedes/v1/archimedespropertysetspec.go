@@ -0,0 +1,52 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+// ArchimedesPropertySetSpecApplyConfiguration represents an declarative configuration of the ArchimedesPropertySetSpec type for use
+// with apply.
+type ArchimedesPropertySetSpecApplyConfiguration struct {
+	Generators []PropertySetGeneratorApplyConfiguration `json:"generators,omitempty"`
+	Template   *PropertySetTemplateApplyConfiguration   `json:"template,omitempty"`
+}
+
+// ArchimedesPropertySetSpecApplyConfiguration constructs an declarative configuration of the ArchimedesPropertySetSpec type for use with
+// apply.
+func ArchimedesPropertySetSpec() *ArchimedesPropertySetSpecApplyConfiguration {
+	return &ArchimedesPropertySetSpecApplyConfiguration{}
+}
+
+// WithGenerators adds the given value to the Generators field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Generators field.
+func (b *ArchimedesPropertySetSpecApplyConfiguration) WithGenerators(values ...*PropertySetGeneratorApplyConfiguration) *ArchimedesPropertySetSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithGenerators")
+		}
+		b.Generators = append(b.Generators, *values[i])
+	}
+	return b
+}
+
+// WithTemplate sets the Template field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Template field is set to the value of the last call.
+func (b *ArchimedesPropertySetSpecApplyConfiguration) WithTemplate(value *PropertySetTemplateApplyConfiguration) *ArchimedesPropertySetSpecApplyConfiguration {
+	b.Template = value
+	return b
+}
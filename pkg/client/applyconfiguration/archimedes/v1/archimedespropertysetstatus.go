@@ -0,0 +1,58 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "k8s.io/client-go/applyconfigurations/meta/v1"
+)
+
+// ArchimedesPropertySetStatusApplyConfiguration represents an declarative configuration of the ArchimedesPropertySetStatus type for use
+// with apply.
+type ArchimedesPropertySetStatusApplyConfiguration struct {
+	Conditions []v1.ConditionApplyConfiguration `json:"conditions,omitempty"`
+	Properties []string                         `json:"properties,omitempty"`
+}
+
+// ArchimedesPropertySetStatusApplyConfiguration constructs an declarative configuration of the ArchimedesPropertySetStatus type for use with
+// apply.
+func ArchimedesPropertySetStatus() *ArchimedesPropertySetStatusApplyConfiguration {
+	return &ArchimedesPropertySetStatusApplyConfiguration{}
+}
+
+// WithConditions adds the given value to the Conditions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Conditions field.
+func (b *ArchimedesPropertySetStatusApplyConfiguration) WithConditions(values ...*v1.ConditionApplyConfiguration) *ArchimedesPropertySetStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithConditions")
+		}
+		b.Conditions = append(b.Conditions, *values[i])
+	}
+	return b
+}
+
+// WithProperties adds the given value to the Properties field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Properties field.
+func (b *ArchimedesPropertySetStatusApplyConfiguration) WithProperties(values ...string) *ArchimedesPropertySetStatusApplyConfiguration {
+	for i := range values {
+		b.Properties = append(b.Properties, values[i])
+	}
+	return b
+}
@@ -0,0 +1,280 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+// ArchimedesPropertySpecApplyConfiguration represents an declarative configuration of the ArchimedesPropertySpec type for use
+// with apply.
+type ArchimedesPropertySpecApplyConfiguration struct {
+	ConfigMapName     *string                             `json:"configMapName,omitempty"`
+	RepoUrl           *string                             `json:"repoUrl,omitempty"`
+	Revision          *string                             `json:"revision,omitempty"`
+	CAPath            *string                             `json:"caPath,omitempty"`
+	SourceRef         *SourceReferenceApplyConfiguration  `json:"sourceRef,omitempty"`
+	SourceType        *string                             `json:"sourceType,omitempty"`
+	HTTP              *HTTPSourceApplyConfiguration       `json:"http,omitempty"`
+	TemplateConfigMap *ConfigMapSourceApplyConfiguration  `json:"templateConfigMap,omitempty"`
+	LocalPath         *string                             `json:"localPath,omitempty"`
+	PropertiesPath    *string                             `json:"propertiesPath,omitempty"`
+	IncludePaths      []string                            `json:"includePaths,omitempty"`
+	Library           *TemplateLibraryApplyConfiguration  `json:"library,omitempty"`
+	SourceConfig      *string                             `json:"sourceConfig,omitempty"`
+	ValuesFrom        []ValuesReferenceApplyConfiguration `json:"valuesFrom,omitempty"`
+	PropertyType      *string                             `json:"propertyType,omitempty"`
+	KeyName           *string                             `json:"keyName,omitempty"`
+	Provenance        *ProvenanceApplyConfiguration       `json:"provenance,omitempty"`
+	RolloutTargets    []RolloutTargetApplyConfiguration   `json:"rolloutTargets,omitempty"`
+	Immutable         *bool                               `json:"immutable,omitempty"`
+	RetainVersions    *int32                              `json:"retainVersions,omitempty"`
+	HistoryLimit      *int32                              `json:"historyLimit,omitempty"`
+	Rollback          *RollbackApplyConfiguration         `json:"rollback,omitempty"`
+	Suspend           *bool                               `json:"suspend,omitempty"`
+	ApprovalPolicy    *string                             `json:"approvalPolicy,omitempty"`
+	SyncWindows       []SyncWindowApplyConfiguration      `json:"syncWindows,omitempty"`
+	DryRun            *bool                               `json:"dryRun,omitempty"`
+}
+
+// ArchimedesPropertySpecApplyConfiguration constructs an declarative configuration of the ArchimedesPropertySpec type for use with
+// apply.
+func ArchimedesPropertySpec() *ArchimedesPropertySpecApplyConfiguration {
+	return &ArchimedesPropertySpecApplyConfiguration{}
+}
+
+// WithConfigMapName sets the ConfigMapName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ConfigMapName field is set to the value of the last call.
+func (b *ArchimedesPropertySpecApplyConfiguration) WithConfigMapName(value string) *ArchimedesPropertySpecApplyConfiguration {
+	b.ConfigMapName = &value
+	return b
+}
+
+// WithRepoUrl sets the RepoUrl field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RepoUrl field is set to the value of the last call.
+func (b *ArchimedesPropertySpecApplyConfiguration) WithRepoUrl(value string) *ArchimedesPropertySpecApplyConfiguration {
+	b.RepoUrl = &value
+	return b
+}
+
+// WithRevision sets the Revision field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Revision field is set to the value of the last call.
+func (b *ArchimedesPropertySpecApplyConfiguration) WithRevision(value string) *ArchimedesPropertySpecApplyConfiguration {
+	b.Revision = &value
+	return b
+}
+
+// WithCAPath sets the CAPath field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CAPath field is set to the value of the last call.
+func (b *ArchimedesPropertySpecApplyConfiguration) WithCAPath(value string) *ArchimedesPropertySpecApplyConfiguration {
+	b.CAPath = &value
+	return b
+}
+
+// WithSourceRef sets the SourceRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SourceRef field is set to the value of the last call.
+func (b *ArchimedesPropertySpecApplyConfiguration) WithSourceRef(value *SourceReferenceApplyConfiguration) *ArchimedesPropertySpecApplyConfiguration {
+	b.SourceRef = value
+	return b
+}
+
+// WithSourceType sets the SourceType field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SourceType field is set to the value of the last call.
+func (b *ArchimedesPropertySpecApplyConfiguration) WithSourceType(value string) *ArchimedesPropertySpecApplyConfiguration {
+	b.SourceType = &value
+	return b
+}
+
+// WithHTTP sets the HTTP field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the HTTP field is set to the value of the last call.
+func (b *ArchimedesPropertySpecApplyConfiguration) WithHTTP(value *HTTPSourceApplyConfiguration) *ArchimedesPropertySpecApplyConfiguration {
+	b.HTTP = value
+	return b
+}
+
+// WithTemplateConfigMap sets the TemplateConfigMap field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TemplateConfigMap field is set to the value of the last call.
+func (b *ArchimedesPropertySpecApplyConfiguration) WithTemplateConfigMap(value *ConfigMapSourceApplyConfiguration) *ArchimedesPropertySpecApplyConfiguration {
+	b.TemplateConfigMap = value
+	return b
+}
+
+// WithLocalPath sets the LocalPath field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LocalPath field is set to the value of the last call.
+func (b *ArchimedesPropertySpecApplyConfiguration) WithLocalPath(value string) *ArchimedesPropertySpecApplyConfiguration {
+	b.LocalPath = &value
+	return b
+}
+
+// WithPropertiesPath sets the PropertiesPath field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PropertiesPath field is set to the value of the last call.
+func (b *ArchimedesPropertySpecApplyConfiguration) WithPropertiesPath(value string) *ArchimedesPropertySpecApplyConfiguration {
+	b.PropertiesPath = &value
+	return b
+}
+
+// WithIncludePaths adds the given value to the IncludePaths field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the IncludePaths field.
+func (b *ArchimedesPropertySpecApplyConfiguration) WithIncludePaths(values ...string) *ArchimedesPropertySpecApplyConfiguration {
+	for i := range values {
+		b.IncludePaths = append(b.IncludePaths, values[i])
+	}
+	return b
+}
+
+// WithLibrary sets the Library field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Library field is set to the value of the last call.
+func (b *ArchimedesPropertySpecApplyConfiguration) WithLibrary(value *TemplateLibraryApplyConfiguration) *ArchimedesPropertySpecApplyConfiguration {
+	b.Library = value
+	return b
+}
+
+// WithSourceConfig sets the SourceConfig field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SourceConfig field is set to the value of the last call.
+func (b *ArchimedesPropertySpecApplyConfiguration) WithSourceConfig(value string) *ArchimedesPropertySpecApplyConfiguration {
+	b.SourceConfig = &value
+	return b
+}
+
+// WithValuesFrom adds the given value to the ValuesFrom field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ValuesFrom field.
+func (b *ArchimedesPropertySpecApplyConfiguration) WithValuesFrom(values ...*ValuesReferenceApplyConfiguration) *ArchimedesPropertySpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithValuesFrom")
+		}
+		b.ValuesFrom = append(b.ValuesFrom, *values[i])
+	}
+	return b
+}
+
+// WithPropertyType sets the PropertyType field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PropertyType field is set to the value of the last call.
+func (b *ArchimedesPropertySpecApplyConfiguration) WithPropertyType(value string) *ArchimedesPropertySpecApplyConfiguration {
+	b.PropertyType = &value
+	return b
+}
+
+// WithKeyName sets the KeyName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the KeyName field is set to the value of the last call.
+func (b *ArchimedesPropertySpecApplyConfiguration) WithKeyName(value string) *ArchimedesPropertySpecApplyConfiguration {
+	b.KeyName = &value
+	return b
+}
+
+// WithProvenance sets the Provenance field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Provenance field is set to the value of the last call.
+func (b *ArchimedesPropertySpecApplyConfiguration) WithProvenance(value *ProvenanceApplyConfiguration) *ArchimedesPropertySpecApplyConfiguration {
+	b.Provenance = value
+	return b
+}
+
+// WithRolloutTargets adds the given value to the RolloutTargets field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the RolloutTargets field.
+func (b *ArchimedesPropertySpecApplyConfiguration) WithRolloutTargets(values ...*RolloutTargetApplyConfiguration) *ArchimedesPropertySpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithRolloutTargets")
+		}
+		b.RolloutTargets = append(b.RolloutTargets, *values[i])
+	}
+	return b
+}
+
+// WithImmutable sets the Immutable field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Immutable field is set to the value of the last call.
+func (b *ArchimedesPropertySpecApplyConfiguration) WithImmutable(value bool) *ArchimedesPropertySpecApplyConfiguration {
+	b.Immutable = &value
+	return b
+}
+
+// WithRetainVersions sets the RetainVersions field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RetainVersions field is set to the value of the last call.
+func (b *ArchimedesPropertySpecApplyConfiguration) WithRetainVersions(value int32) *ArchimedesPropertySpecApplyConfiguration {
+	b.RetainVersions = &value
+	return b
+}
+
+// WithHistoryLimit sets the HistoryLimit field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the HistoryLimit field is set to the value of the last call.
+func (b *ArchimedesPropertySpecApplyConfiguration) WithHistoryLimit(value int32) *ArchimedesPropertySpecApplyConfiguration {
+	b.HistoryLimit = &value
+	return b
+}
+
+// WithRollback sets the Rollback field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Rollback field is set to the value of the last call.
+func (b *ArchimedesPropertySpecApplyConfiguration) WithRollback(value *RollbackApplyConfiguration) *ArchimedesPropertySpecApplyConfiguration {
+	b.Rollback = value
+	return b
+}
+
+// WithSuspend sets the Suspend field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Suspend field is set to the value of the last call.
+func (b *ArchimedesPropertySpecApplyConfiguration) WithSuspend(value bool) *ArchimedesPropertySpecApplyConfiguration {
+	b.Suspend = &value
+	return b
+}
+
+// WithApprovalPolicy sets the ApprovalPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ApprovalPolicy field is set to the value of the last call.
+func (b *ArchimedesPropertySpecApplyConfiguration) WithApprovalPolicy(value string) *ArchimedesPropertySpecApplyConfiguration {
+	b.ApprovalPolicy = &value
+	return b
+}
+
+// WithSyncWindows adds the given value to the SyncWindows field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the SyncWindows field.
+func (b *ArchimedesPropertySpecApplyConfiguration) WithSyncWindows(values ...*SyncWindowApplyConfiguration) *ArchimedesPropertySpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithSyncWindows")
+		}
+		b.SyncWindows = append(b.SyncWindows, *values[i])
+	}
+	return b
+}
+
+// WithDryRun sets the DryRun field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DryRun field is set to the value of the last call.
+func (b *ArchimedesPropertySpecApplyConfiguration) WithDryRun(value bool) *ArchimedesPropertySpecApplyConfiguration {
+	b.DryRun = &value
+	return b
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "k8s.io/client-go/applyconfigurations/meta/v1"
+)
+
+// ArchimedesPropertyStatusApplyConfiguration represents an declarative configuration of the ArchimedesPropertyStatus type for use
+// with apply.
+type ArchimedesPropertyStatusApplyConfiguration struct {
+	Conditions             []v1.ConditionApplyConfiguration       `json:"conditions,omitempty"`
+	ContentHash            *string                                `json:"contentHash,omitempty"`
+	RestartedWorkloads     []string                               `json:"restartedWorkloads,omitempty"`
+	ConfigMapName          *string                                `json:"configMapName,omitempty"`
+	History                []RenderHistoryEntryApplyConfiguration `json:"history,omitempty"`
+	LastHandledReconcileAt *string                                `json:"lastHandledReconcileAt,omitempty"`
+	Commit                 *string                                `json:"commit,omitempty"`
+	PendingChange          *PendingChangeApplyConfiguration       `json:"pendingChange,omitempty"`
+	NextSyncWindow         *metav1.Time                           `json:"nextSyncWindow,omitempty"`
+	Values                 []string                               `json:"values,omitempty"`
+	Outputs                []OutputStatusApplyConfiguration       `json:"outputs,omitempty"`
+}
+
+// ArchimedesPropertyStatusApplyConfiguration constructs an declarative configuration of the ArchimedesPropertyStatus type for use with
+// apply.
+func ArchimedesPropertyStatus() *ArchimedesPropertyStatusApplyConfiguration {
+	return &ArchimedesPropertyStatusApplyConfiguration{}
+}
+
+// WithConditions adds the given value to the Conditions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Conditions field.
+func (b *ArchimedesPropertyStatusApplyConfiguration) WithConditions(values ...*v1.ConditionApplyConfiguration) *ArchimedesPropertyStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithConditions")
+		}
+		b.Conditions = append(b.Conditions, *values[i])
+	}
+	return b
+}
+
+// WithContentHash sets the ContentHash field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ContentHash field is set to the value of the last call.
+func (b *ArchimedesPropertyStatusApplyConfiguration) WithContentHash(value string) *ArchimedesPropertyStatusApplyConfiguration {
+	b.ContentHash = &value
+	return b
+}
+
+// WithRestartedWorkloads adds the given value to the RestartedWorkloads field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the RestartedWorkloads field.
+func (b *ArchimedesPropertyStatusApplyConfiguration) WithRestartedWorkloads(values ...string) *ArchimedesPropertyStatusApplyConfiguration {
+	for i := range values {
+		b.RestartedWorkloads = append(b.RestartedWorkloads, values[i])
+	}
+	return b
+}
+
+// WithConfigMapName sets the ConfigMapName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ConfigMapName field is set to the value of the last call.
+func (b *ArchimedesPropertyStatusApplyConfiguration) WithConfigMapName(value string) *ArchimedesPropertyStatusApplyConfiguration {
+	b.ConfigMapName = &value
+	return b
+}
+
+// WithHistory adds the given value to the History field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the History field.
+func (b *ArchimedesPropertyStatusApplyConfiguration) WithHistory(values ...*RenderHistoryEntryApplyConfiguration) *ArchimedesPropertyStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithHistory")
+		}
+		b.History = append(b.History, *values[i])
+	}
+	return b
+}
+
+// WithLastHandledReconcileAt sets the LastHandledReconcileAt field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastHandledReconcileAt field is set to the value of the last call.
+func (b *ArchimedesPropertyStatusApplyConfiguration) WithLastHandledReconcileAt(value string) *ArchimedesPropertyStatusApplyConfiguration {
+	b.LastHandledReconcileAt = &value
+	return b
+}
+
+// WithCommit sets the Commit field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Commit field is set to the value of the last call.
+func (b *ArchimedesPropertyStatusApplyConfiguration) WithCommit(value string) *ArchimedesPropertyStatusApplyConfiguration {
+	b.Commit = &value
+	return b
+}
+
+// WithPendingChange sets the PendingChange field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PendingChange field is set to the value of the last call.
+func (b *ArchimedesPropertyStatusApplyConfiguration) WithPendingChange(value *PendingChangeApplyConfiguration) *ArchimedesPropertyStatusApplyConfiguration {
+	b.PendingChange = value
+	return b
+}
+
+// WithNextSyncWindow sets the NextSyncWindow field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NextSyncWindow field is set to the value of the last call.
+func (b *ArchimedesPropertyStatusApplyConfiguration) WithNextSyncWindow(value metav1.Time) *ArchimedesPropertyStatusApplyConfiguration {
+	b.NextSyncWindow = &value
+	return b
+}
+
+// WithValues adds the given value to the Values field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Values field.
+func (b *ArchimedesPropertyStatusApplyConfiguration) WithValues(values ...string) *ArchimedesPropertyStatusApplyConfiguration {
+	for i := range values {
+		b.Values = append(b.Values, values[i])
+	}
+	return b
+}
+
+// WithOutputs adds the given value to the Outputs field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Outputs field.
+func (b *ArchimedesPropertyStatusApplyConfiguration) WithOutputs(values ...*OutputStatusApplyConfiguration) *ArchimedesPropertyStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithOutputs")
+		}
+		b.Outputs = append(b.Outputs, *values[i])
+	}
+	return b
+}
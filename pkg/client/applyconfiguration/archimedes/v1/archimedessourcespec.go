@@ -0,0 +1,78 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ArchimedesSourceSpecApplyConfiguration represents an declarative configuration of the ArchimedesSourceSpec type for use
+// with apply.
+type ArchimedesSourceSpecApplyConfiguration struct {
+	RepoUrl   *string                            `json:"repoUrl,omitempty"`
+	Revision  *string                            `json:"revision,omitempty"`
+	CAPath    *string                            `json:"caPath,omitempty"`
+	SecretRef *SecretReferenceApplyConfiguration `json:"secretRef,omitempty"`
+	Interval  *metav1.Duration                   `json:"interval,omitempty"`
+}
+
+// ArchimedesSourceSpecApplyConfiguration constructs an declarative configuration of the ArchimedesSourceSpec type for use with
+// apply.
+func ArchimedesSourceSpec() *ArchimedesSourceSpecApplyConfiguration {
+	return &ArchimedesSourceSpecApplyConfiguration{}
+}
+
+// WithRepoUrl sets the RepoUrl field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RepoUrl field is set to the value of the last call.
+func (b *ArchimedesSourceSpecApplyConfiguration) WithRepoUrl(value string) *ArchimedesSourceSpecApplyConfiguration {
+	b.RepoUrl = &value
+	return b
+}
+
+// WithRevision sets the Revision field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Revision field is set to the value of the last call.
+func (b *ArchimedesSourceSpecApplyConfiguration) WithRevision(value string) *ArchimedesSourceSpecApplyConfiguration {
+	b.Revision = &value
+	return b
+}
+
+// WithCAPath sets the CAPath field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CAPath field is set to the value of the last call.
+func (b *ArchimedesSourceSpecApplyConfiguration) WithCAPath(value string) *ArchimedesSourceSpecApplyConfiguration {
+	b.CAPath = &value
+	return b
+}
+
+// WithSecretRef sets the SecretRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SecretRef field is set to the value of the last call.
+func (b *ArchimedesSourceSpecApplyConfiguration) WithSecretRef(value *SecretReferenceApplyConfiguration) *ArchimedesSourceSpecApplyConfiguration {
+	b.SecretRef = value
+	return b
+}
+
+// WithInterval sets the Interval field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Interval field is set to the value of the last call.
+func (b *ArchimedesSourceSpecApplyConfiguration) WithInterval(value metav1.Duration) *ArchimedesSourceSpecApplyConfiguration {
+	b.Interval = &value
+	return b
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "k8s.io/client-go/applyconfigurations/meta/v1"
+)
+
+// ArchimedesSourceStatusApplyConfiguration represents an declarative configuration of the ArchimedesSourceStatus type for use
+// with apply.
+type ArchimedesSourceStatusApplyConfiguration struct {
+	Conditions    []v1.ConditionApplyConfiguration `json:"conditions,omitempty"`
+	Commit        *SourceCommitApplyConfiguration  `json:"commit,omitempty"`
+	LastFetchTime *metav1.Time                     `json:"lastFetchTime,omitempty"`
+}
+
+// ArchimedesSourceStatusApplyConfiguration constructs an declarative configuration of the ArchimedesSourceStatus type for use with
+// apply.
+func ArchimedesSourceStatus() *ArchimedesSourceStatusApplyConfiguration {
+	return &ArchimedesSourceStatusApplyConfiguration{}
+}
+
+// WithConditions adds the given value to the Conditions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Conditions field.
+func (b *ArchimedesSourceStatusApplyConfiguration) WithConditions(values ...*v1.ConditionApplyConfiguration) *ArchimedesSourceStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithConditions")
+		}
+		b.Conditions = append(b.Conditions, *values[i])
+	}
+	return b
+}
+
+// WithCommit sets the Commit field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Commit field is set to the value of the last call.
+func (b *ArchimedesSourceStatusApplyConfiguration) WithCommit(value *SourceCommitApplyConfiguration) *ArchimedesSourceStatusApplyConfiguration {
+	b.Commit = value
+	return b
+}
+
+// WithLastFetchTime sets the LastFetchTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastFetchTime field is set to the value of the last call.
+func (b *ArchimedesSourceStatusApplyConfiguration) WithLastFetchTime(value metav1.Time) *ArchimedesSourceStatusApplyConfiguration {
+	b.LastFetchTime = &value
+	return b
+}
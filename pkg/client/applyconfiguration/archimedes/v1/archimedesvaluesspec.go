@@ -0,0 +1,51 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "k8s.io/client-go/applyconfigurations/meta/v1"
+)
+
+// ArchimedesValuesSpecApplyConfiguration represents an declarative configuration of the ArchimedesValuesSpec type for use
+// with apply.
+type ArchimedesValuesSpecApplyConfiguration struct {
+	Values           *string                             `json:"values,omitempty"`
+	PropertySelector *v1.LabelSelectorApplyConfiguration `json:"propertySelector,omitempty"`
+}
+
+// ArchimedesValuesSpecApplyConfiguration constructs an declarative configuration of the ArchimedesValuesSpec type for use with
+// apply.
+func ArchimedesValuesSpec() *ArchimedesValuesSpecApplyConfiguration {
+	return &ArchimedesValuesSpecApplyConfiguration{}
+}
+
+// WithValues sets the Values field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Values field is set to the value of the last call.
+func (b *ArchimedesValuesSpecApplyConfiguration) WithValues(value string) *ArchimedesValuesSpecApplyConfiguration {
+	b.Values = &value
+	return b
+}
+
+// WithPropertySelector sets the PropertySelector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PropertySelector field is set to the value of the last call.
+func (b *ArchimedesValuesSpecApplyConfiguration) WithPropertySelector(value *v1.LabelSelectorApplyConfiguration) *ArchimedesValuesSpecApplyConfiguration {
+	b.PropertySelector = value
+	return b
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// HTTPSourceApplyConfiguration represents an declarative configuration of the HTTPSource type for use
+// with apply.
+type HTTPSourceApplyConfiguration struct {
+	Url      *string      `json:"url,omitempty"`
+	CAPath   *string      `json:"caPath,omitempty"`
+	Interval *v1.Duration `json:"interval,omitempty"`
+}
+
+// HTTPSourceApplyConfiguration constructs an declarative configuration of the HTTPSource type for use with
+// apply.
+func HTTPSource() *HTTPSourceApplyConfiguration {
+	return &HTTPSourceApplyConfiguration{}
+}
+
+// WithUrl sets the Url field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Url field is set to the value of the last call.
+func (b *HTTPSourceApplyConfiguration) WithUrl(value string) *HTTPSourceApplyConfiguration {
+	b.Url = &value
+	return b
+}
+
+// WithCAPath sets the CAPath field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CAPath field is set to the value of the last call.
+func (b *HTTPSourceApplyConfiguration) WithCAPath(value string) *HTTPSourceApplyConfiguration {
+	b.CAPath = &value
+	return b
+}
+
+// WithInterval sets the Interval field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Interval field is set to the value of the last call.
+func (b *HTTPSourceApplyConfiguration) WithInterval(value v1.Duration) *HTTPSourceApplyConfiguration {
+	b.Interval = &value
+	return b
+}
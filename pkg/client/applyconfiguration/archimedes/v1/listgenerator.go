@@ -0,0 +1,43 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+// ListGeneratorApplyConfiguration represents an declarative configuration of the ListGenerator type for use
+// with apply.
+type ListGeneratorApplyConfiguration struct {
+	Elements []PropertySetElementApplyConfiguration `json:"elements,omitempty"`
+}
+
+// ListGeneratorApplyConfiguration constructs an declarative configuration of the ListGenerator type for use with
+// apply.
+func ListGenerator() *ListGeneratorApplyConfiguration {
+	return &ListGeneratorApplyConfiguration{}
+}
+
+// WithElements adds the given value to the Elements field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Elements field.
+func (b *ListGeneratorApplyConfiguration) WithElements(values ...*PropertySetElementApplyConfiguration) *ListGeneratorApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithElements")
+		}
+		b.Elements = append(b.Elements, *values[i])
+	}
+	return b
+}
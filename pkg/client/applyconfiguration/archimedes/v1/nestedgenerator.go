@@ -0,0 +1,47 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+// NestedGeneratorApplyConfiguration represents an declarative configuration of the NestedGenerator type for use
+// with apply.
+type NestedGeneratorApplyConfiguration struct {
+	List       *ListGeneratorApplyConfiguration      `json:"list,omitempty"`
+	Namespaces *NamespaceGeneratorApplyConfiguration `json:"namespaces,omitempty"`
+}
+
+// NestedGeneratorApplyConfiguration constructs an declarative configuration of the NestedGenerator type for use with
+// apply.
+func NestedGenerator() *NestedGeneratorApplyConfiguration {
+	return &NestedGeneratorApplyConfiguration{}
+}
+
+// WithList sets the List field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the List field is set to the value of the last call.
+func (b *NestedGeneratorApplyConfiguration) WithList(value *ListGeneratorApplyConfiguration) *NestedGeneratorApplyConfiguration {
+	b.List = value
+	return b
+}
+
+// WithNamespaces sets the Namespaces field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Namespaces field is set to the value of the last call.
+func (b *NestedGeneratorApplyConfiguration) WithNamespaces(value *NamespaceGeneratorApplyConfiguration) *NestedGeneratorApplyConfiguration {
+	b.Namespaces = value
+	return b
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+// PendingChangeApplyConfiguration represents an declarative configuration of the PendingChange type for use
+// with apply.
+type PendingChangeApplyConfiguration struct {
+	Commit  *string  `json:"commit,omitempty"`
+	Added   []string `json:"added,omitempty"`
+	Removed []string `json:"removed,omitempty"`
+	Changed []string `json:"changed,omitempty"`
+}
+
+// PendingChangeApplyConfiguration constructs an declarative configuration of the PendingChange type for use with
+// apply.
+func PendingChange() *PendingChangeApplyConfiguration {
+	return &PendingChangeApplyConfiguration{}
+}
+
+// WithCommit sets the Commit field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Commit field is set to the value of the last call.
+func (b *PendingChangeApplyConfiguration) WithCommit(value string) *PendingChangeApplyConfiguration {
+	b.Commit = &value
+	return b
+}
+
+// WithAdded adds the given value to the Added field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Added field.
+func (b *PendingChangeApplyConfiguration) WithAdded(values ...string) *PendingChangeApplyConfiguration {
+	for i := range values {
+		b.Added = append(b.Added, values[i])
+	}
+	return b
+}
+
+// WithRemoved adds the given value to the Removed field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Removed field.
+func (b *PendingChangeApplyConfiguration) WithRemoved(values ...string) *PendingChangeApplyConfiguration {
+	for i := range values {
+		b.Removed = append(b.Removed, values[i])
+	}
+	return b
+}
+
+// WithChanged adds the given value to the Changed field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Changed field.
+func (b *PendingChangeApplyConfiguration) WithChanged(values ...string) *PendingChangeApplyConfiguration {
+	for i := range values {
+		b.Changed = append(b.Changed, values[i])
+	}
+	return b
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+// PropertySetElementApplyConfiguration represents an declarative configuration of the PropertySetElement type for use
+// with apply.
+type PropertySetElementApplyConfiguration struct {
+	Namespace *string `json:"namespace,omitempty"`
+	Name      *string `json:"name,omitempty"`
+	Values    *string `json:"values,omitempty"`
+}
+
+// PropertySetElementApplyConfiguration constructs an declarative configuration of the PropertySetElement type for use with
+// apply.
+func PropertySetElement() *PropertySetElementApplyConfiguration {
+	return &PropertySetElementApplyConfiguration{}
+}
+
+// WithNamespace sets the Namespace field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Namespace field is set to the value of the last call.
+func (b *PropertySetElementApplyConfiguration) WithNamespace(value string) *PropertySetElementApplyConfiguration {
+	b.Namespace = &value
+	return b
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *PropertySetElementApplyConfiguration) WithName(value string) *PropertySetElementApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithValues sets the Values field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Values field is set to the value of the last call.
+func (b *PropertySetElementApplyConfiguration) WithValues(value string) *PropertySetElementApplyConfiguration {
+	b.Values = &value
+	return b
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+// PropertySetGeneratorApplyConfiguration represents an declarative configuration of the PropertySetGenerator type for use
+// with apply.
+type PropertySetGeneratorApplyConfiguration struct {
+	List       *ListGeneratorApplyConfiguration      `json:"list,omitempty"`
+	Namespaces *NamespaceGeneratorApplyConfiguration `json:"namespaces,omitempty"`
+	Matrix     *MatrixGeneratorApplyConfiguration    `json:"matrix,omitempty"`
+}
+
+// PropertySetGeneratorApplyConfiguration constructs an declarative configuration of the PropertySetGenerator type for use with
+// apply.
+func PropertySetGenerator() *PropertySetGeneratorApplyConfiguration {
+	return &PropertySetGeneratorApplyConfiguration{}
+}
+
+// WithList sets the List field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the List field is set to the value of the last call.
+func (b *PropertySetGeneratorApplyConfiguration) WithList(value *ListGeneratorApplyConfiguration) *PropertySetGeneratorApplyConfiguration {
+	b.List = value
+	return b
+}
+
+// WithNamespaces sets the Namespaces field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Namespaces field is set to the value of the last call.
+func (b *PropertySetGeneratorApplyConfiguration) WithNamespaces(value *NamespaceGeneratorApplyConfiguration) *PropertySetGeneratorApplyConfiguration {
+	b.Namespaces = value
+	return b
+}
+
+// WithMatrix sets the Matrix field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Matrix field is set to the value of the last call.
+func (b *PropertySetGeneratorApplyConfiguration) WithMatrix(value *MatrixGeneratorApplyConfiguration) *PropertySetGeneratorApplyConfiguration {
+	b.Matrix = value
+	return b
+}
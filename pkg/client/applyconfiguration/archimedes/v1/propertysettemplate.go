@@ -0,0 +1,47 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+// PropertySetTemplateApplyConfiguration represents an declarative configuration of the PropertySetTemplate type for use
+// with apply.
+type PropertySetTemplateApplyConfiguration struct {
+	Metadata *PropertySetTemplateMetaApplyConfiguration `json:"metadata,omitempty"`
+	Spec     *ArchimedesPropertySpecApplyConfiguration  `json:"spec,omitempty"`
+}
+
+// PropertySetTemplateApplyConfiguration constructs an declarative configuration of the PropertySetTemplate type for use with
+// apply.
+func PropertySetTemplate() *PropertySetTemplateApplyConfiguration {
+	return &PropertySetTemplateApplyConfiguration{}
+}
+
+// WithMetadata sets the Metadata field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Metadata field is set to the value of the last call.
+func (b *PropertySetTemplateApplyConfiguration) WithMetadata(value *PropertySetTemplateMetaApplyConfiguration) *PropertySetTemplateApplyConfiguration {
+	b.Metadata = value
+	return b
+}
+
+// WithSpec sets the Spec field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Spec field is set to the value of the last call.
+func (b *PropertySetTemplateApplyConfiguration) WithSpec(value *ArchimedesPropertySpecApplyConfiguration) *PropertySetTemplateApplyConfiguration {
+	b.Spec = value
+	return b
+}
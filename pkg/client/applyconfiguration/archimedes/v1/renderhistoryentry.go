@@ -0,0 +1,78 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RenderHistoryEntryApplyConfiguration represents an declarative configuration of the RenderHistoryEntry type for use
+// with apply.
+type RenderHistoryEntryApplyConfiguration struct {
+	Commit     *string  `json:"commit,omitempty"`
+	ValuesHash *string  `json:"valuesHash,omitempty"`
+	DataHash   *string  `json:"dataHash,omitempty"`
+	Timestamp  *v1.Time `json:"timestamp,omitempty"`
+	Snapshot   *string  `json:"snapshot,omitempty"`
+}
+
+// RenderHistoryEntryApplyConfiguration constructs an declarative configuration of the RenderHistoryEntry type for use with
+// apply.
+func RenderHistoryEntry() *RenderHistoryEntryApplyConfiguration {
+	return &RenderHistoryEntryApplyConfiguration{}
+}
+
+// WithCommit sets the Commit field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Commit field is set to the value of the last call.
+func (b *RenderHistoryEntryApplyConfiguration) WithCommit(value string) *RenderHistoryEntryApplyConfiguration {
+	b.Commit = &value
+	return b
+}
+
+// WithValuesHash sets the ValuesHash field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ValuesHash field is set to the value of the last call.
+func (b *RenderHistoryEntryApplyConfiguration) WithValuesHash(value string) *RenderHistoryEntryApplyConfiguration {
+	b.ValuesHash = &value
+	return b
+}
+
+// WithDataHash sets the DataHash field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DataHash field is set to the value of the last call.
+func (b *RenderHistoryEntryApplyConfiguration) WithDataHash(value string) *RenderHistoryEntryApplyConfiguration {
+	b.DataHash = &value
+	return b
+}
+
+// WithTimestamp sets the Timestamp field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Timestamp field is set to the value of the last call.
+func (b *RenderHistoryEntryApplyConfiguration) WithTimestamp(value v1.Time) *RenderHistoryEntryApplyConfiguration {
+	b.Timestamp = &value
+	return b
+}
+
+// WithSnapshot sets the Snapshot field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Snapshot field is set to the value of the last call.
+func (b *RenderHistoryEntryApplyConfiguration) WithSnapshot(value string) *RenderHistoryEntryApplyConfiguration {
+	b.Snapshot = &value
+	return b
+}
@@ -0,0 +1,47 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+// RollbackApplyConfiguration represents an declarative configuration of the Rollback type for use
+// with apply.
+type RollbackApplyConfiguration struct {
+	Index  *int32  `json:"index,omitempty"`
+	Commit *string `json:"commit,omitempty"`
+}
+
+// RollbackApplyConfiguration constructs an declarative configuration of the Rollback type for use with
+// apply.
+func Rollback() *RollbackApplyConfiguration {
+	return &RollbackApplyConfiguration{}
+}
+
+// WithIndex sets the Index field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Index field is set to the value of the last call.
+func (b *RollbackApplyConfiguration) WithIndex(value int32) *RollbackApplyConfiguration {
+	b.Index = &value
+	return b
+}
+
+// WithCommit sets the Commit field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Commit field is set to the value of the last call.
+func (b *RollbackApplyConfiguration) WithCommit(value string) *RollbackApplyConfiguration {
+	b.Commit = &value
+	return b
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "k8s.io/client-go/applyconfigurations/meta/v1"
+)
+
+// RolloutTargetApplyConfiguration represents an declarative configuration of the RolloutTarget type for use
+// with apply.
+type RolloutTargetApplyConfiguration struct {
+	Kind     *string                             `json:"kind,omitempty"`
+	Name     *string                             `json:"name,omitempty"`
+	Selector *v1.LabelSelectorApplyConfiguration `json:"selector,omitempty"`
+}
+
+// RolloutTargetApplyConfiguration constructs an declarative configuration of the RolloutTarget type for use with
+// apply.
+func RolloutTarget() *RolloutTargetApplyConfiguration {
+	return &RolloutTargetApplyConfiguration{}
+}
+
+// WithKind sets the Kind field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Kind field is set to the value of the last call.
+func (b *RolloutTargetApplyConfiguration) WithKind(value string) *RolloutTargetApplyConfiguration {
+	b.Kind = &value
+	return b
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *RolloutTargetApplyConfiguration) WithName(value string) *RolloutTargetApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithSelector sets the Selector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Selector field is set to the value of the last call.
+func (b *RolloutTargetApplyConfiguration) WithSelector(value *v1.LabelSelectorApplyConfiguration) *RolloutTargetApplyConfiguration {
+	b.Selector = value
+	return b
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SourceCommitApplyConfiguration represents an declarative configuration of the SourceCommit type for use
+// with apply.
+type SourceCommitApplyConfiguration struct {
+	Hash      *string  `json:"hash,omitempty"`
+	Author    *string  `json:"author,omitempty"`
+	Message   *string  `json:"message,omitempty"`
+	Timestamp *v1.Time `json:"timestamp,omitempty"`
+	Tag       *string  `json:"tag,omitempty"`
+}
+
+// SourceCommitApplyConfiguration constructs an declarative configuration of the SourceCommit type for use with
+// apply.
+func SourceCommit() *SourceCommitApplyConfiguration {
+	return &SourceCommitApplyConfiguration{}
+}
+
+// WithHash sets the Hash field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Hash field is set to the value of the last call.
+func (b *SourceCommitApplyConfiguration) WithHash(value string) *SourceCommitApplyConfiguration {
+	b.Hash = &value
+	return b
+}
+
+// WithAuthor sets the Author field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Author field is set to the value of the last call.
+func (b *SourceCommitApplyConfiguration) WithAuthor(value string) *SourceCommitApplyConfiguration {
+	b.Author = &value
+	return b
+}
+
+// WithMessage sets the Message field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Message field is set to the value of the last call.
+func (b *SourceCommitApplyConfiguration) WithMessage(value string) *SourceCommitApplyConfiguration {
+	b.Message = &value
+	return b
+}
+
+// WithTimestamp sets the Timestamp field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Timestamp field is set to the value of the last call.
+func (b *SourceCommitApplyConfiguration) WithTimestamp(value v1.Time) *SourceCommitApplyConfiguration {
+	b.Timestamp = &value
+	return b
+}
+
+// WithTag sets the Tag field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Tag field is set to the value of the last call.
+func (b *SourceCommitApplyConfiguration) WithTag(value string) *SourceCommitApplyConfiguration {
+	b.Tag = &value
+	return b
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SyncWindowApplyConfiguration represents an declarative configuration of the SyncWindow type for use
+// with apply.
+type SyncWindowApplyConfiguration struct {
+	Kind     *string      `json:"kind,omitempty"`
+	Schedule *string      `json:"schedule,omitempty"`
+	Duration *v1.Duration `json:"duration,omitempty"`
+}
+
+// SyncWindowApplyConfiguration constructs an declarative configuration of the SyncWindow type for use with
+// apply.
+func SyncWindow() *SyncWindowApplyConfiguration {
+	return &SyncWindowApplyConfiguration{}
+}
+
+// WithKind sets the Kind field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Kind field is set to the value of the last call.
+func (b *SyncWindowApplyConfiguration) WithKind(value string) *SyncWindowApplyConfiguration {
+	b.Kind = &value
+	return b
+}
+
+// WithSchedule sets the Schedule field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Schedule field is set to the value of the last call.
+func (b *SyncWindowApplyConfiguration) WithSchedule(value string) *SyncWindowApplyConfiguration {
+	b.Schedule = &value
+	return b
+}
+
+// WithDuration sets the Duration field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Duration field is set to the value of the last call.
+func (b *SyncWindowApplyConfiguration) WithDuration(value v1.Duration) *SyncWindowApplyConfiguration {
+	b.Duration = &value
+	return b
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+// TemplateLibraryApplyConfiguration represents an declarative configuration of the TemplateLibrary type for use
+// with apply.
+type TemplateLibraryApplyConfiguration struct {
+	RepoUrl  *string  `json:"repoUrl,omitempty"`
+	Revision *string  `json:"revision,omitempty"`
+	CAPath   *string  `json:"caPath,omitempty"`
+	Paths    []string `json:"paths,omitempty"`
+}
+
+// TemplateLibraryApplyConfiguration constructs an declarative configuration of the TemplateLibrary type for use with
+// apply.
+func TemplateLibrary() *TemplateLibraryApplyConfiguration {
+	return &TemplateLibraryApplyConfiguration{}
+}
+
+// WithRepoUrl sets the RepoUrl field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RepoUrl field is set to the value of the last call.
+func (b *TemplateLibraryApplyConfiguration) WithRepoUrl(value string) *TemplateLibraryApplyConfiguration {
+	b.RepoUrl = &value
+	return b
+}
+
+// WithRevision sets the Revision field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Revision field is set to the value of the last call.
+func (b *TemplateLibraryApplyConfiguration) WithRevision(value string) *TemplateLibraryApplyConfiguration {
+	b.Revision = &value
+	return b
+}
+
+// WithCAPath sets the CAPath field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CAPath field is set to the value of the last call.
+func (b *TemplateLibraryApplyConfiguration) WithCAPath(value string) *TemplateLibraryApplyConfiguration {
+	b.CAPath = &value
+	return b
+}
+
+// WithPaths adds the given value to the Paths field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Paths field.
+func (b *TemplateLibraryApplyConfiguration) WithPaths(values ...string) *TemplateLibraryApplyConfiguration {
+	for i := range values {
+		b.Paths = append(b.Paths, values[i])
+	}
+	return b
+}
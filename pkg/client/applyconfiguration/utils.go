@@ -0,0 +1,104 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package applyconfiguration
+
+import (
+	v1 "github.com/backwoods-devops/archimedes/api/v1"
+	archimedesv1 "github.com/backwoods-devops/archimedes/pkg/client/applyconfiguration/archimedes/v1"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// ForKind returns an apply configuration type for the given GroupVersionKind, or nil if no
+// apply configuration type exists for the given GroupVersionKind.
+func ForKind(kind schema.GroupVersionKind) interface{} {
+	switch kind {
+	// Group=archimedes.backwoods-devops.io, Version=v1
+	case v1.SchemeGroupVersion.WithKind("ArchimedesProperty"):
+		return &archimedesv1.ArchimedesPropertyApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ArchimedesPropertySet"):
+		return &archimedesv1.ArchimedesPropertySetApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ArchimedesPropertySetSpec"):
+		return &archimedesv1.ArchimedesPropertySetSpecApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ArchimedesPropertySetStatus"):
+		return &archimedesv1.ArchimedesPropertySetStatusApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ArchimedesPropertySpec"):
+		return &archimedesv1.ArchimedesPropertySpecApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ArchimedesPropertyStatus"):
+		return &archimedesv1.ArchimedesPropertyStatusApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ArchimedesSource"):
+		return &archimedesv1.ArchimedesSourceApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ArchimedesSourceSpec"):
+		return &archimedesv1.ArchimedesSourceSpecApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ArchimedesSourceStatus"):
+		return &archimedesv1.ArchimedesSourceStatusApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ArchimedesValues"):
+		return &archimedesv1.ArchimedesValuesApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ArchimedesValuesSpec"):
+		return &archimedesv1.ArchimedesValuesSpecApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ClusterArchimedesValues"):
+		return &archimedesv1.ClusterArchimedesValuesApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ClusterArchimedesValuesSpec"):
+		return &archimedesv1.ClusterArchimedesValuesSpecApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ConfigMapSource"):
+		return &archimedesv1.ConfigMapSourceApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("HTTPSource"):
+		return &archimedesv1.HTTPSourceApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ListGenerator"):
+		return &archimedesv1.ListGeneratorApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("MatrixGenerator"):
+		return &archimedesv1.MatrixGeneratorApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("NamespaceGenerator"):
+		return &archimedesv1.NamespaceGeneratorApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("NestedGenerator"):
+		return &archimedesv1.NestedGeneratorApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("OutputStatus"):
+		return &archimedesv1.OutputStatusApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("PendingChange"):
+		return &archimedesv1.PendingChangeApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("PropertySetElement"):
+		return &archimedesv1.PropertySetElementApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("PropertySetGenerator"):
+		return &archimedesv1.PropertySetGeneratorApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("PropertySetTemplate"):
+		return &archimedesv1.PropertySetTemplateApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("PropertySetTemplateMeta"):
+		return &archimedesv1.PropertySetTemplateMetaApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("Provenance"):
+		return &archimedesv1.ProvenanceApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RenderHistoryEntry"):
+		return &archimedesv1.RenderHistoryEntryApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("Rollback"):
+		return &archimedesv1.RollbackApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RolloutTarget"):
+		return &archimedesv1.RolloutTargetApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("SecretReference"):
+		return &archimedesv1.SecretReferenceApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("SourceCommit"):
+		return &archimedesv1.SourceCommitApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("SourceReference"):
+		return &archimedesv1.SourceReferenceApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("SyncWindow"):
+		return &archimedesv1.SyncWindowApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("TemplateLibrary"):
+		return &archimedesv1.TemplateLibraryApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ValuesReference"):
+		return &archimedesv1.ValuesReferenceApplyConfiguration{}
+
+	}
+	return nil
+}
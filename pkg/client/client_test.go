@@ -0,0 +1,103 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	backwoodsv1 "github.com/backwoods-devops/archimedes/api/v1"
+	applyv1 "github.com/backwoods-devops/archimedes/pkg/client/applyconfiguration/archimedes/v1"
+	"github.com/backwoods-devops/archimedes/pkg/client/clientset/versioned/fake"
+	"github.com/backwoods-devops/archimedes/pkg/client/informers/externalversions"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestClientset(t *testing.T) {
+	cs := fake.NewSimpleClientset(
+		&backwoodsv1.ArchimedesProperty{
+			ObjectMeta: metav1.ObjectMeta{Name: "trees", Namespace: "forest"},
+			Spec:       backwoodsv1.ArchimedesPropertySpec{ConfigMapName: "trees-config"},
+		},
+		&backwoodsv1.ClusterArchimedesValues{
+			ObjectMeta: metav1.ObjectMeta{Name: "shared"},
+			Spec:       backwoodsv1.ClusterArchimedesValuesSpec{Values: "env: prod\n"},
+		},
+	)
+	ctx := context.Background()
+
+	property, err := cs.ArchimedesV1().ArchimedesProperties("forest").Get(ctx, "trees", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if property.Spec.ConfigMapName != "trees-config" {
+		t.Errorf("configMapName = %q", property.Spec.ConfigMapName)
+	}
+
+	values, err := cs.ArchimedesV1().ClusterArchimedesValues().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(values.Items) != 1 || values.Items[0].Spec.Values != "env: prod\n" {
+		t.Errorf("cluster values = %+v", values.Items)
+	}
+}
+
+func TestInformers(t *testing.T) {
+	cs := fake.NewSimpleClientset(&backwoodsv1.ArchimedesValues{
+		ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: "forest"},
+		Spec:       backwoodsv1.ArchimedesValuesSpec{Values: "env: staging\n"},
+	})
+	factory := externalversions.NewSharedInformerFactory(cs, time.Minute)
+	lister := factory.Archimedes().V1().ArchimedesValues().Lister()
+
+	stop := make(chan struct{})
+	defer close(stop)
+	factory.Start(stop)
+	for typ, synced := range factory.WaitForCacheSync(stop) {
+		if !synced {
+			t.Fatalf("%s informer did not sync", typ)
+		}
+	}
+
+	values, err := lister.ArchimedesValues("forest").Get("shared")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if values.Spec.Values != "env: staging\n" {
+		t.Errorf("values = %q", values.Spec.Values)
+	}
+}
+
+func TestApplyConfiguration(t *testing.T) {
+	property := applyv1.ArchimedesProperty("trees", "forest").
+		WithLabels(map[string]string{"app": "trees"}).
+		WithSpec(applyv1.ArchimedesPropertySpec().
+			WithConfigMapName("trees-config").
+			WithPropertyType("kvp"))
+
+	out, err := json.Marshal(property)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"kind":"ArchimedesProperty","apiVersion":"archimedes.backwoods-devops.io/v1","metadata":{"name":"trees","namespace":"forest","labels":{"app":"trees"}},"spec":{"configMapName":"trees-config","propertyType":"kvp"}}`
+	if string(out) != want {
+		t.Errorf("got %s\nwant %s", out, want)
+	}
+}
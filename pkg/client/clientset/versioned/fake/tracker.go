@@ -0,0 +1,49 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Copied into the fake clientset by hack/update-codegen.sh, edit hack/fake_tracker.go.txt.
+
+package fake
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/testing"
+)
+
+// resourceNames are the resources of the kinds whose +resourceName the tracker cannot guess
+var resourceNames = map[string]string{
+	"ArchimedesValues":        "archimedesvalues",
+	"ClusterArchimedesValues": "clusterarchimedesvalues",
+}
+
+// addObject adds an object passed to NewSimpleClientset to the tracker. The tracker guesses
+// archimedesvalueses as the resource of values, so they are created under their resourceName.
+func addObject(o testing.ObjectTracker, obj runtime.Object) error {
+	gvks, _, err := scheme.ObjectKinds(obj)
+	if err != nil {
+		return err
+	}
+	resource, ok := resourceNames[gvks[0].Kind]
+	if !ok || meta.IsListType(obj) {
+		return o.Add(obj)
+	}
+	objMeta, err := meta.Accessor(obj)
+	if err != nil {
+		return err
+	}
+	return o.Create(gvks[0].GroupVersion().WithResource(resource), obj, objMeta.GetNamespace())
+}
@@ -0,0 +1,255 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"context"
+	json "encoding/json"
+	"fmt"
+	"time"
+
+	v1 "github.com/backwoods-devops/archimedes/api/v1"
+	archimedesv1 "github.com/backwoods-devops/archimedes/pkg/client/applyconfiguration/archimedes/v1"
+	scheme "github.com/backwoods-devops/archimedes/pkg/client/clientset/versioned/scheme"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// ArchimedesPropertySetsGetter has a method to return a ArchimedesPropertySetInterface.
+// A group's client should implement this interface.
+type ArchimedesPropertySetsGetter interface {
+	ArchimedesPropertySets(namespace string) ArchimedesPropertySetInterface
+}
+
+// ArchimedesPropertySetInterface has methods to work with ArchimedesPropertySet resources.
+type ArchimedesPropertySetInterface interface {
+	Create(ctx context.Context, archimedesPropertySet *v1.ArchimedesPropertySet, opts metav1.CreateOptions) (*v1.ArchimedesPropertySet, error)
+	Update(ctx context.Context, archimedesPropertySet *v1.ArchimedesPropertySet, opts metav1.UpdateOptions) (*v1.ArchimedesPropertySet, error)
+	UpdateStatus(ctx context.Context, archimedesPropertySet *v1.ArchimedesPropertySet, opts metav1.UpdateOptions) (*v1.ArchimedesPropertySet, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.ArchimedesPropertySet, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*v1.ArchimedesPropertySetList, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.ArchimedesPropertySet, err error)
+	Apply(ctx context.Context, archimedesPropertySet *archimedesv1.ArchimedesPropertySetApplyConfiguration, opts metav1.ApplyOptions) (result *v1.ArchimedesPropertySet, err error)
+	ApplyStatus(ctx context.Context, archimedesPropertySet *archimedesv1.ArchimedesPropertySetApplyConfiguration, opts metav1.ApplyOptions) (result *v1.ArchimedesPropertySet, err error)
+	ArchimedesPropertySetExpansion
+}
+
+// archimedesPropertySets implements ArchimedesPropertySetInterface
+type archimedesPropertySets struct {
+	client rest.Interface
+	ns     string
+}
+
+// newArchimedesPropertySets returns a ArchimedesPropertySets
+func newArchimedesPropertySets(c *ArchimedesV1Client, namespace string) *archimedesPropertySets {
+	return &archimedesPropertySets{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the archimedesPropertySet, and returns the corresponding archimedesPropertySet object, and an error if there is any.
+func (c *archimedesPropertySets) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.ArchimedesPropertySet, err error) {
+	result = &v1.ArchimedesPropertySet{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("archimedespropertysets").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of ArchimedesPropertySets that match those selectors.
+func (c *archimedesPropertySets) List(ctx context.Context, opts metav1.ListOptions) (result *v1.ArchimedesPropertySetList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1.ArchimedesPropertySetList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("archimedespropertysets").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested archimedesPropertySets.
+func (c *archimedesPropertySets) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("archimedespropertysets").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a archimedesPropertySet and creates it.  Returns the server's representation of the archimedesPropertySet, and an error, if there is any.
+func (c *archimedesPropertySets) Create(ctx context.Context, archimedesPropertySet *v1.ArchimedesPropertySet, opts metav1.CreateOptions) (result *v1.ArchimedesPropertySet, err error) {
+	result = &v1.ArchimedesPropertySet{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("archimedespropertysets").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(archimedesPropertySet).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a archimedesPropertySet and updates it. Returns the server's representation of the archimedesPropertySet, and an error, if there is any.
+func (c *archimedesPropertySets) Update(ctx context.Context, archimedesPropertySet *v1.ArchimedesPropertySet, opts metav1.UpdateOptions) (result *v1.ArchimedesPropertySet, err error) {
+	result = &v1.ArchimedesPropertySet{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("archimedespropertysets").
+		Name(archimedesPropertySet.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(archimedesPropertySet).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *archimedesPropertySets) UpdateStatus(ctx context.Context, archimedesPropertySet *v1.ArchimedesPropertySet, opts metav1.UpdateOptions) (result *v1.ArchimedesPropertySet, err error) {
+	result = &v1.ArchimedesPropertySet{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("archimedespropertysets").
+		Name(archimedesPropertySet.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(archimedesPropertySet).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the archimedesPropertySet and deletes it. Returns an error if one occurs.
+func (c *archimedesPropertySets) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("archimedespropertysets").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *archimedesPropertySets) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("archimedespropertysets").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched archimedesPropertySet.
+func (c *archimedesPropertySets) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.ArchimedesPropertySet, err error) {
+	result = &v1.ArchimedesPropertySet{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("archimedespropertysets").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied archimedesPropertySet.
+func (c *archimedesPropertySets) Apply(ctx context.Context, archimedesPropertySet *archimedesv1.ArchimedesPropertySetApplyConfiguration, opts metav1.ApplyOptions) (result *v1.ArchimedesPropertySet, err error) {
+	if archimedesPropertySet == nil {
+		return nil, fmt.Errorf("archimedesPropertySet provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(archimedesPropertySet)
+	if err != nil {
+		return nil, err
+	}
+	name := archimedesPropertySet.Name
+	if name == nil {
+		return nil, fmt.Errorf("archimedesPropertySet.Name must be provided to Apply")
+	}
+	result = &v1.ArchimedesPropertySet{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Namespace(c.ns).
+		Resource("archimedespropertysets").
+		Name(*name).
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *archimedesPropertySets) ApplyStatus(ctx context.Context, archimedesPropertySet *archimedesv1.ArchimedesPropertySetApplyConfiguration, opts metav1.ApplyOptions) (result *v1.ArchimedesPropertySet, err error) {
+	if archimedesPropertySet == nil {
+		return nil, fmt.Errorf("archimedesPropertySet provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(archimedesPropertySet)
+	if err != nil {
+		return nil, err
+	}
+
+	name := archimedesPropertySet.Name
+	if name == nil {
+		return nil, fmt.Errorf("archimedesPropertySet.Name must be provided to Apply")
+	}
+
+	result = &v1.ArchimedesPropertySet{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Namespace(c.ns).
+		Resource("archimedespropertysets").
+		Name(*name).
+		SubResource("status").
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -0,0 +1,255 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"context"
+	json "encoding/json"
+	"fmt"
+	"time"
+
+	v1 "github.com/backwoods-devops/archimedes/api/v1"
+	archimedesv1 "github.com/backwoods-devops/archimedes/pkg/client/applyconfiguration/archimedes/v1"
+	scheme "github.com/backwoods-devops/archimedes/pkg/client/clientset/versioned/scheme"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// ArchimedesSourcesGetter has a method to return a ArchimedesSourceInterface.
+// A group's client should implement this interface.
+type ArchimedesSourcesGetter interface {
+	ArchimedesSources(namespace string) ArchimedesSourceInterface
+}
+
+// ArchimedesSourceInterface has methods to work with ArchimedesSource resources.
+type ArchimedesSourceInterface interface {
+	Create(ctx context.Context, archimedesSource *v1.ArchimedesSource, opts metav1.CreateOptions) (*v1.ArchimedesSource, error)
+	Update(ctx context.Context, archimedesSource *v1.ArchimedesSource, opts metav1.UpdateOptions) (*v1.ArchimedesSource, error)
+	UpdateStatus(ctx context.Context, archimedesSource *v1.ArchimedesSource, opts metav1.UpdateOptions) (*v1.ArchimedesSource, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.ArchimedesSource, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*v1.ArchimedesSourceList, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.ArchimedesSource, err error)
+	Apply(ctx context.Context, archimedesSource *archimedesv1.ArchimedesSourceApplyConfiguration, opts metav1.ApplyOptions) (result *v1.ArchimedesSource, err error)
+	ApplyStatus(ctx context.Context, archimedesSource *archimedesv1.ArchimedesSourceApplyConfiguration, opts metav1.ApplyOptions) (result *v1.ArchimedesSource, err error)
+	ArchimedesSourceExpansion
+}
+
+// archimedesSources implements ArchimedesSourceInterface
+type archimedesSources struct {
+	client rest.Interface
+	ns     string
+}
+
+// newArchimedesSources returns a ArchimedesSources
+func newArchimedesSources(c *ArchimedesV1Client, namespace string) *archimedesSources {
+	return &archimedesSources{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the archimedesSource, and returns the corresponding archimedesSource object, and an error if there is any.
+func (c *archimedesSources) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.ArchimedesSource, err error) {
+	result = &v1.ArchimedesSource{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("archimedessources").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of ArchimedesSources that match those selectors.
+func (c *archimedesSources) List(ctx context.Context, opts metav1.ListOptions) (result *v1.ArchimedesSourceList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1.ArchimedesSourceList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("archimedessources").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested archimedesSources.
+func (c *archimedesSources) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("archimedessources").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a archimedesSource and creates it.  Returns the server's representation of the archimedesSource, and an error, if there is any.
+func (c *archimedesSources) Create(ctx context.Context, archimedesSource *v1.ArchimedesSource, opts metav1.CreateOptions) (result *v1.ArchimedesSource, err error) {
+	result = &v1.ArchimedesSource{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("archimedessources").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(archimedesSource).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a archimedesSource and updates it. Returns the server's representation of the archimedesSource, and an error, if there is any.
+func (c *archimedesSources) Update(ctx context.Context, archimedesSource *v1.ArchimedesSource, opts metav1.UpdateOptions) (result *v1.ArchimedesSource, err error) {
+	result = &v1.ArchimedesSource{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("archimedessources").
+		Name(archimedesSource.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(archimedesSource).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *archimedesSources) UpdateStatus(ctx context.Context, archimedesSource *v1.ArchimedesSource, opts metav1.UpdateOptions) (result *v1.ArchimedesSource, err error) {
+	result = &v1.ArchimedesSource{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("archimedessources").
+		Name(archimedesSource.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(archimedesSource).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the archimedesSource and deletes it. Returns an error if one occurs.
+func (c *archimedesSources) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("archimedessources").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *archimedesSources) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("archimedessources").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched archimedesSource.
+func (c *archimedesSources) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.ArchimedesSource, err error) {
+	result = &v1.ArchimedesSource{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("archimedessources").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied archimedesSource.
+func (c *archimedesSources) Apply(ctx context.Context, archimedesSource *archimedesv1.ArchimedesSourceApplyConfiguration, opts metav1.ApplyOptions) (result *v1.ArchimedesSource, err error) {
+	if archimedesSource == nil {
+		return nil, fmt.Errorf("archimedesSource provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(archimedesSource)
+	if err != nil {
+		return nil, err
+	}
+	name := archimedesSource.Name
+	if name == nil {
+		return nil, fmt.Errorf("archimedesSource.Name must be provided to Apply")
+	}
+	result = &v1.ArchimedesSource{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Namespace(c.ns).
+		Resource("archimedessources").
+		Name(*name).
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *archimedesSources) ApplyStatus(ctx context.Context, archimedesSource *archimedesv1.ArchimedesSourceApplyConfiguration, opts metav1.ApplyOptions) (result *v1.ArchimedesSource, err error) {
+	if archimedesSource == nil {
+		return nil, fmt.Errorf("archimedesSource provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(archimedesSource)
+	if err != nil {
+		return nil, err
+	}
+
+	name := archimedesSource.Name
+	if name == nil {
+		return nil, fmt.Errorf("archimedesSource.Name must be provided to Apply")
+	}
+
+	result = &v1.ArchimedesSource{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Namespace(c.ns).
+		Resource("archimedessources").
+		Name(*name).
+		SubResource("status").
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
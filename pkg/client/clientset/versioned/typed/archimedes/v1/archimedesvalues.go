@@ -0,0 +1,207 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"context"
+	json "encoding/json"
+	"fmt"
+	"time"
+
+	v1 "github.com/backwoods-devops/archimedes/api/v1"
+	archimedesv1 "github.com/backwoods-devops/archimedes/pkg/client/applyconfiguration/archimedes/v1"
+	scheme "github.com/backwoods-devops/archimedes/pkg/client/clientset/versioned/scheme"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// ArchimedesValuesGetter has a method to return a ArchimedesValuesInterface.
+// A group's client should implement this interface.
+type ArchimedesValuesGetter interface {
+	ArchimedesValues(namespace string) ArchimedesValuesInterface
+}
+
+// ArchimedesValuesInterface has methods to work with ArchimedesValues resources.
+type ArchimedesValuesInterface interface {
+	Create(ctx context.Context, archimedesValues *v1.ArchimedesValues, opts metav1.CreateOptions) (*v1.ArchimedesValues, error)
+	Update(ctx context.Context, archimedesValues *v1.ArchimedesValues, opts metav1.UpdateOptions) (*v1.ArchimedesValues, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.ArchimedesValues, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*v1.ArchimedesValuesList, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.ArchimedesValues, err error)
+	Apply(ctx context.Context, archimedesValues *archimedesv1.ArchimedesValuesApplyConfiguration, opts metav1.ApplyOptions) (result *v1.ArchimedesValues, err error)
+	ArchimedesValuesExpansion
+}
+
+// archimedesValues implements ArchimedesValuesInterface
+type archimedesValues struct {
+	client rest.Interface
+	ns     string
+}
+
+// newArchimedesValues returns a ArchimedesValues
+func newArchimedesValues(c *ArchimedesV1Client, namespace string) *archimedesValues {
+	return &archimedesValues{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the archimedesValues, and returns the corresponding archimedesValues object, and an error if there is any.
+func (c *archimedesValues) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.ArchimedesValues, err error) {
+	result = &v1.ArchimedesValues{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("archimedesvalues").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of ArchimedesValues that match those selectors.
+func (c *archimedesValues) List(ctx context.Context, opts metav1.ListOptions) (result *v1.ArchimedesValuesList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1.ArchimedesValuesList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("archimedesvalues").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested archimedesValues.
+func (c *archimedesValues) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("archimedesvalues").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a archimedesValues and creates it.  Returns the server's representation of the archimedesValues, and an error, if there is any.
+func (c *archimedesValues) Create(ctx context.Context, archimedesValues *v1.ArchimedesValues, opts metav1.CreateOptions) (result *v1.ArchimedesValues, err error) {
+	result = &v1.ArchimedesValues{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("archimedesvalues").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(archimedesValues).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a archimedesValues and updates it. Returns the server's representation of the archimedesValues, and an error, if there is any.
+func (c *archimedesValues) Update(ctx context.Context, archimedesValues *v1.ArchimedesValues, opts metav1.UpdateOptions) (result *v1.ArchimedesValues, err error) {
+	result = &v1.ArchimedesValues{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("archimedesvalues").
+		Name(archimedesValues.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(archimedesValues).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the archimedesValues and deletes it. Returns an error if one occurs.
+func (c *archimedesValues) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("archimedesvalues").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *archimedesValues) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("archimedesvalues").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched archimedesValues.
+func (c *archimedesValues) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.ArchimedesValues, err error) {
+	result = &v1.ArchimedesValues{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("archimedesvalues").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied archimedesValues.
+func (c *archimedesValues) Apply(ctx context.Context, archimedesValues *archimedesv1.ArchimedesValuesApplyConfiguration, opts metav1.ApplyOptions) (result *v1.ArchimedesValues, err error) {
+	if archimedesValues == nil {
+		return nil, fmt.Errorf("archimedesValues provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(archimedesValues)
+	if err != nil {
+		return nil, err
+	}
+	name := archimedesValues.Name
+	if name == nil {
+		return nil, fmt.Errorf("archimedesValues.Name must be provided to Apply")
+	}
+	result = &v1.ArchimedesValues{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Namespace(c.ns).
+		Resource("archimedesvalues").
+		Name(*name).
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"context"
+	json "encoding/json"
+	"fmt"
+	"time"
+
+	v1 "github.com/backwoods-devops/archimedes/api/v1"
+	archimedesv1 "github.com/backwoods-devops/archimedes/pkg/client/applyconfiguration/archimedes/v1"
+	scheme "github.com/backwoods-devops/archimedes/pkg/client/clientset/versioned/scheme"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// ClusterArchimedesValuesGetter has a method to return a ClusterArchimedesValuesInterface.
+// A group's client should implement this interface.
+type ClusterArchimedesValuesGetter interface {
+	ClusterArchimedesValues() ClusterArchimedesValuesInterface
+}
+
+// ClusterArchimedesValuesInterface has methods to work with ClusterArchimedesValues resources.
+type ClusterArchimedesValuesInterface interface {
+	Create(ctx context.Context, clusterArchimedesValues *v1.ClusterArchimedesValues, opts metav1.CreateOptions) (*v1.ClusterArchimedesValues, error)
+	Update(ctx context.Context, clusterArchimedesValues *v1.ClusterArchimedesValues, opts metav1.UpdateOptions) (*v1.ClusterArchimedesValues, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.ClusterArchimedesValues, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*v1.ClusterArchimedesValuesList, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.ClusterArchimedesValues, err error)
+	Apply(ctx context.Context, clusterArchimedesValues *archimedesv1.ClusterArchimedesValuesApplyConfiguration, opts metav1.ApplyOptions) (result *v1.ClusterArchimedesValues, err error)
+	ClusterArchimedesValuesExpansion
+}
+
+// clusterArchimedesValues implements ClusterArchimedesValuesInterface
+type clusterArchimedesValues struct {
+	client rest.Interface
+}
+
+// newClusterArchimedesValues returns a ClusterArchimedesValues
+func newClusterArchimedesValues(c *ArchimedesV1Client) *clusterArchimedesValues {
+	return &clusterArchimedesValues{
+		client: c.RESTClient(),
+	}
+}
+
+// Get takes name of the clusterArchimedesValues, and returns the corresponding clusterArchimedesValues object, and an error if there is any.
+func (c *clusterArchimedesValues) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.ClusterArchimedesValues, err error) {
+	result = &v1.ClusterArchimedesValues{}
+	err = c.client.Get().
+		Resource("clusterarchimedesvalues").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of ClusterArchimedesValues that match those selectors.
+func (c *clusterArchimedesValues) List(ctx context.Context, opts metav1.ListOptions) (result *v1.ClusterArchimedesValuesList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1.ClusterArchimedesValuesList{}
+	err = c.client.Get().
+		Resource("clusterarchimedesvalues").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested clusterArchimedesValues.
+func (c *clusterArchimedesValues) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Resource("clusterarchimedesvalues").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a clusterArchimedesValues and creates it.  Returns the server's representation of the clusterArchimedesValues, and an error, if there is any.
+func (c *clusterArchimedesValues) Create(ctx context.Context, clusterArchimedesValues *v1.ClusterArchimedesValues, opts metav1.CreateOptions) (result *v1.ClusterArchimedesValues, err error) {
+	result = &v1.ClusterArchimedesValues{}
+	err = c.client.Post().
+		Resource("clusterarchimedesvalues").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(clusterArchimedesValues).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a clusterArchimedesValues and updates it. Returns the server's representation of the clusterArchimedesValues, and an error, if there is any.
+func (c *clusterArchimedesValues) Update(ctx context.Context, clusterArchimedesValues *v1.ClusterArchimedesValues, opts metav1.UpdateOptions) (result *v1.ClusterArchimedesValues, err error) {
+	result = &v1.ClusterArchimedesValues{}
+	err = c.client.Put().
+		Resource("clusterarchimedesvalues").
+		Name(clusterArchimedesValues.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(clusterArchimedesValues).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the clusterArchimedesValues and deletes it. Returns an error if one occurs.
+func (c *clusterArchimedesValues) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return c.client.Delete().
+		Resource("clusterarchimedesvalues").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *clusterArchimedesValues) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Resource("clusterarchimedesvalues").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched clusterArchimedesValues.
+func (c *clusterArchimedesValues) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.ClusterArchimedesValues, err error) {
+	result = &v1.ClusterArchimedesValues{}
+	err = c.client.Patch(pt).
+		Resource("clusterarchimedesvalues").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied clusterArchimedesValues.
+func (c *clusterArchimedesValues) Apply(ctx context.Context, clusterArchimedesValues *archimedesv1.ClusterArchimedesValuesApplyConfiguration, opts metav1.ApplyOptions) (result *v1.ClusterArchimedesValues, err error) {
+	if clusterArchimedesValues == nil {
+		return nil, fmt.Errorf("clusterArchimedesValues provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(clusterArchimedesValues)
+	if err != nil {
+		return nil, err
+	}
+	name := clusterArchimedesValues.Name
+	if name == nil {
+		return nil, fmt.Errorf("clusterArchimedesValues.Name must be provided to Apply")
+	}
+	result = &v1.ClusterArchimedesValues{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Resource("clusterarchimedesvalues").
+		Name(*name).
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
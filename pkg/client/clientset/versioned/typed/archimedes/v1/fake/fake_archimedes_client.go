@@ -0,0 +1,55 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1 "github.com/backwoods-devops/archimedes/pkg/client/clientset/versioned/typed/archimedes/v1"
+	rest "k8s.io/client-go/rest"
+	testing "k8s.io/client-go/testing"
+)
+
+type FakeArchimedesV1 struct {
+	*testing.Fake
+}
+
+func (c *FakeArchimedesV1) ArchimedesProperties(namespace string) v1.ArchimedesPropertyInterface {
+	return &FakeArchimedesProperties{c, namespace}
+}
+
+func (c *FakeArchimedesV1) ArchimedesPropertySets(namespace string) v1.ArchimedesPropertySetInterface {
+	return &FakeArchimedesPropertySets{c, namespace}
+}
+
+func (c *FakeArchimedesV1) ArchimedesSources(namespace string) v1.ArchimedesSourceInterface {
+	return &FakeArchimedesSources{c, namespace}
+}
+
+func (c *FakeArchimedesV1) ArchimedesValues(namespace string) v1.ArchimedesValuesInterface {
+	return &FakeArchimedesValues{c, namespace}
+}
+
+func (c *FakeArchimedesV1) ClusterArchimedesValues() v1.ClusterArchimedesValuesInterface {
+	return &FakeClusterArchimedesValues{c}
+}
+
+// RESTClient returns a RESTClient that is used to communicate
+// with API server by this client implementation.
+func (c *FakeArchimedesV1) RESTClient() rest.Interface {
+	var ret *rest.RESTClient
+	return ret
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+	json "encoding/json"
+	"fmt"
+
+	archimedesv1 "github.com/backwoods-devops/archimedes/api/v1"
+	applyconfigurationarchimedesv1 "github.com/backwoods-devops/archimedes/pkg/client/applyconfiguration/archimedes/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeArchimedesProperties implements ArchimedesPropertyInterface
+type FakeArchimedesProperties struct {
+	Fake *FakeArchimedesV1
+	ns   string
+}
+
+var archimedespropertiesResource = schema.GroupVersionResource{Group: "archimedes.backwoods-devops.io", Version: "v1", Resource: "archimedesproperties"}
+
+var archimedespropertiesKind = schema.GroupVersionKind{Group: "archimedes.backwoods-devops.io", Version: "v1", Kind: "ArchimedesProperty"}
+
+// Get takes name of the archimedesProperty, and returns the corresponding archimedesProperty object, and an error if there is any.
+func (c *FakeArchimedesProperties) Get(ctx context.Context, name string, options v1.GetOptions) (result *archimedesv1.ArchimedesProperty, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(archimedespropertiesResource, c.ns, name), &archimedesv1.ArchimedesProperty{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*archimedesv1.ArchimedesProperty), err
+}
+
+// List takes label and field selectors, and returns the list of ArchimedesProperties that match those selectors.
+func (c *FakeArchimedesProperties) List(ctx context.Context, opts v1.ListOptions) (result *archimedesv1.ArchimedesPropertyList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(archimedespropertiesResource, archimedespropertiesKind, c.ns, opts), &archimedesv1.ArchimedesPropertyList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &archimedesv1.ArchimedesPropertyList{ListMeta: obj.(*archimedesv1.ArchimedesPropertyList).ListMeta}
+	for _, item := range obj.(*archimedesv1.ArchimedesPropertyList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested archimedesProperties.
+func (c *FakeArchimedesProperties) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(archimedespropertiesResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a archimedesProperty and creates it.  Returns the server's representation of the archimedesProperty, and an error, if there is any.
+func (c *FakeArchimedesProperties) Create(ctx context.Context, archimedesProperty *archimedesv1.ArchimedesProperty, opts v1.CreateOptions) (result *archimedesv1.ArchimedesProperty, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(archimedespropertiesResource, c.ns, archimedesProperty), &archimedesv1.ArchimedesProperty{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*archimedesv1.ArchimedesProperty), err
+}
+
+// Update takes the representation of a archimedesProperty and updates it. Returns the server's representation of the archimedesProperty, and an error, if there is any.
+func (c *FakeArchimedesProperties) Update(ctx context.Context, archimedesProperty *archimedesv1.ArchimedesProperty, opts v1.UpdateOptions) (result *archimedesv1.ArchimedesProperty, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(archimedespropertiesResource, c.ns, archimedesProperty), &archimedesv1.ArchimedesProperty{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*archimedesv1.ArchimedesProperty), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeArchimedesProperties) UpdateStatus(ctx context.Context, archimedesProperty *archimedesv1.ArchimedesProperty, opts v1.UpdateOptions) (*archimedesv1.ArchimedesProperty, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(archimedespropertiesResource, "status", c.ns, archimedesProperty), &archimedesv1.ArchimedesProperty{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*archimedesv1.ArchimedesProperty), err
+}
+
+// Delete takes name of the archimedesProperty and deletes it. Returns an error if one occurs.
+func (c *FakeArchimedesProperties) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(archimedespropertiesResource, c.ns, name), &archimedesv1.ArchimedesProperty{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeArchimedesProperties) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(archimedespropertiesResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &archimedesv1.ArchimedesPropertyList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched archimedesProperty.
+func (c *FakeArchimedesProperties) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *archimedesv1.ArchimedesProperty, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(archimedespropertiesResource, c.ns, name, pt, data, subresources...), &archimedesv1.ArchimedesProperty{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*archimedesv1.ArchimedesProperty), err
+}
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied archimedesProperty.
+func (c *FakeArchimedesProperties) Apply(ctx context.Context, archimedesProperty *applyconfigurationarchimedesv1.ArchimedesPropertyApplyConfiguration, opts v1.ApplyOptions) (result *archimedesv1.ArchimedesProperty, err error) {
+	if archimedesProperty == nil {
+		return nil, fmt.Errorf("archimedesProperty provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(archimedesProperty)
+	if err != nil {
+		return nil, err
+	}
+	name := archimedesProperty.Name
+	if name == nil {
+		return nil, fmt.Errorf("archimedesProperty.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(archimedespropertiesResource, c.ns, *name, types.ApplyPatchType, data), &archimedesv1.ArchimedesProperty{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*archimedesv1.ArchimedesProperty), err
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *FakeArchimedesProperties) ApplyStatus(ctx context.Context, archimedesProperty *applyconfigurationarchimedesv1.ArchimedesPropertyApplyConfiguration, opts v1.ApplyOptions) (result *archimedesv1.ArchimedesProperty, err error) {
+	if archimedesProperty == nil {
+		return nil, fmt.Errorf("archimedesProperty provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(archimedesProperty)
+	if err != nil {
+		return nil, err
+	}
+	name := archimedesProperty.Name
+	if name == nil {
+		return nil, fmt.Errorf("archimedesProperty.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(archimedespropertiesResource, c.ns, *name, types.ApplyPatchType, data, "status"), &archimedesv1.ArchimedesProperty{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*archimedesv1.ArchimedesProperty), err
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+	json "encoding/json"
+	"fmt"
+
+	archimedesv1 "github.com/backwoods-devops/archimedes/api/v1"
+	applyconfigurationarchimedesv1 "github.com/backwoods-devops/archimedes/pkg/client/applyconfiguration/archimedes/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeArchimedesPropertySets implements ArchimedesPropertySetInterface
+type FakeArchimedesPropertySets struct {
+	Fake *FakeArchimedesV1
+	ns   string
+}
+
+var archimedespropertysetsResource = schema.GroupVersionResource{Group: "archimedes.backwoods-devops.io", Version: "v1", Resource: "archimedespropertysets"}
+
+var archimedespropertysetsKind = schema.GroupVersionKind{Group: "archimedes.backwoods-devops.io", Version: "v1", Kind: "ArchimedesPropertySet"}
+
+// Get takes name of the archimedesPropertySet, and returns the corresponding archimedesPropertySet object, and an error if there is any.
+func (c *FakeArchimedesPropertySets) Get(ctx context.Context, name string, options v1.GetOptions) (result *archimedesv1.ArchimedesPropertySet, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(archimedespropertysetsResource, c.ns, name), &archimedesv1.ArchimedesPropertySet{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*archimedesv1.ArchimedesPropertySet), err
+}
+
+// List takes label and field selectors, and returns the list of ArchimedesPropertySets that match those selectors.
+func (c *FakeArchimedesPropertySets) List(ctx context.Context, opts v1.ListOptions) (result *archimedesv1.ArchimedesPropertySetList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(archimedespropertysetsResource, archimedespropertysetsKind, c.ns, opts), &archimedesv1.ArchimedesPropertySetList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &archimedesv1.ArchimedesPropertySetList{ListMeta: obj.(*archimedesv1.ArchimedesPropertySetList).ListMeta}
+	for _, item := range obj.(*archimedesv1.ArchimedesPropertySetList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested archimedesPropertySets.
+func (c *FakeArchimedesPropertySets) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(archimedespropertysetsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a archimedesPropertySet and creates it.  Returns the server's representation of the archimedesPropertySet, and an error, if there is any.
+func (c *FakeArchimedesPropertySets) Create(ctx context.Context, archimedesPropertySet *archimedesv1.ArchimedesPropertySet, opts v1.CreateOptions) (result *archimedesv1.ArchimedesPropertySet, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(archimedespropertysetsResource, c.ns, archimedesPropertySet), &archimedesv1.ArchimedesPropertySet{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*archimedesv1.ArchimedesPropertySet), err
+}
+
+// Update takes the representation of a archimedesPropertySet and updates it. Returns the server's representation of the archimedesPropertySet, and an error, if there is any.
+func (c *FakeArchimedesPropertySets) Update(ctx context.Context, archimedesPropertySet *archimedesv1.ArchimedesPropertySet, opts v1.UpdateOptions) (result *archimedesv1.ArchimedesPropertySet, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(archimedespropertysetsResource, c.ns, archimedesPropertySet), &archimedesv1.ArchimedesPropertySet{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*archimedesv1.ArchimedesPropertySet), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeArchimedesPropertySets) UpdateStatus(ctx context.Context, archimedesPropertySet *archimedesv1.ArchimedesPropertySet, opts v1.UpdateOptions) (*archimedesv1.ArchimedesPropertySet, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(archimedespropertysetsResource, "status", c.ns, archimedesPropertySet), &archimedesv1.ArchimedesPropertySet{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*archimedesv1.ArchimedesPropertySet), err
+}
+
+// Delete takes name of the archimedesPropertySet and deletes it. Returns an error if one occurs.
+func (c *FakeArchimedesPropertySets) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(archimedespropertysetsResource, c.ns, name), &archimedesv1.ArchimedesPropertySet{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeArchimedesPropertySets) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(archimedespropertysetsResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &archimedesv1.ArchimedesPropertySetList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched archimedesPropertySet.
+func (c *FakeArchimedesPropertySets) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *archimedesv1.ArchimedesPropertySet, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(archimedespropertysetsResource, c.ns, name, pt, data, subresources...), &archimedesv1.ArchimedesPropertySet{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*archimedesv1.ArchimedesPropertySet), err
+}
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied archimedesPropertySet.
+func (c *FakeArchimedesPropertySets) Apply(ctx context.Context, archimedesPropertySet *applyconfigurationarchimedesv1.ArchimedesPropertySetApplyConfiguration, opts v1.ApplyOptions) (result *archimedesv1.ArchimedesPropertySet, err error) {
+	if archimedesPropertySet == nil {
+		return nil, fmt.Errorf("archimedesPropertySet provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(archimedesPropertySet)
+	if err != nil {
+		return nil, err
+	}
+	name := archimedesPropertySet.Name
+	if name == nil {
+		return nil, fmt.Errorf("archimedesPropertySet.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(archimedespropertysetsResource, c.ns, *name, types.ApplyPatchType, data), &archimedesv1.ArchimedesPropertySet{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*archimedesv1.ArchimedesPropertySet), err
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *FakeArchimedesPropertySets) ApplyStatus(ctx context.Context, archimedesPropertySet *applyconfigurationarchimedesv1.ArchimedesPropertySetApplyConfiguration, opts v1.ApplyOptions) (result *archimedesv1.ArchimedesPropertySet, err error) {
+	if archimedesPropertySet == nil {
+		return nil, fmt.Errorf("archimedesPropertySet provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(archimedesPropertySet)
+	if err != nil {
+		return nil, err
+	}
+	name := archimedesPropertySet.Name
+	if name == nil {
+		return nil, fmt.Errorf("archimedesPropertySet.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(archimedespropertysetsResource, c.ns, *name, types.ApplyPatchType, data, "status"), &archimedesv1.ArchimedesPropertySet{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*archimedesv1.ArchimedesPropertySet), err
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+	json "encoding/json"
+	"fmt"
+
+	archimedesv1 "github.com/backwoods-devops/archimedes/api/v1"
+	applyconfigurationarchimedesv1 "github.com/backwoods-devops/archimedes/pkg/client/applyconfiguration/archimedes/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeArchimedesSources implements ArchimedesSourceInterface
+type FakeArchimedesSources struct {
+	Fake *FakeArchimedesV1
+	ns   string
+}
+
+var archimedessourcesResource = schema.GroupVersionResource{Group: "archimedes.backwoods-devops.io", Version: "v1", Resource: "archimedessources"}
+
+var archimedessourcesKind = schema.GroupVersionKind{Group: "archimedes.backwoods-devops.io", Version: "v1", Kind: "ArchimedesSource"}
+
+// Get takes name of the archimedesSource, and returns the corresponding archimedesSource object, and an error if there is any.
+func (c *FakeArchimedesSources) Get(ctx context.Context, name string, options v1.GetOptions) (result *archimedesv1.ArchimedesSource, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(archimedessourcesResource, c.ns, name), &archimedesv1.ArchimedesSource{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*archimedesv1.ArchimedesSource), err
+}
+
+// List takes label and field selectors, and returns the list of ArchimedesSources that match those selectors.
+func (c *FakeArchimedesSources) List(ctx context.Context, opts v1.ListOptions) (result *archimedesv1.ArchimedesSourceList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(archimedessourcesResource, archimedessourcesKind, c.ns, opts), &archimedesv1.ArchimedesSourceList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &archimedesv1.ArchimedesSourceList{ListMeta: obj.(*archimedesv1.ArchimedesSourceList).ListMeta}
+	for _, item := range obj.(*archimedesv1.ArchimedesSourceList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested archimedesSources.
+func (c *FakeArchimedesSources) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(archimedessourcesResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a archimedesSource and creates it.  Returns the server's representation of the archimedesSource, and an error, if there is any.
+func (c *FakeArchimedesSources) Create(ctx context.Context, archimedesSource *archimedesv1.ArchimedesSource, opts v1.CreateOptions) (result *archimedesv1.ArchimedesSource, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(archimedessourcesResource, c.ns, archimedesSource), &archimedesv1.ArchimedesSource{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*archimedesv1.ArchimedesSource), err
+}
+
+// Update takes the representation of a archimedesSource and updates it. Returns the server's representation of the archimedesSource, and an error, if there is any.
+func (c *FakeArchimedesSources) Update(ctx context.Context, archimedesSource *archimedesv1.ArchimedesSource, opts v1.UpdateOptions) (result *archimedesv1.ArchimedesSource, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(archimedessourcesResource, c.ns, archimedesSource), &archimedesv1.ArchimedesSource{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*archimedesv1.ArchimedesSource), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeArchimedesSources) UpdateStatus(ctx context.Context, archimedesSource *archimedesv1.ArchimedesSource, opts v1.UpdateOptions) (*archimedesv1.ArchimedesSource, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(archimedessourcesResource, "status", c.ns, archimedesSource), &archimedesv1.ArchimedesSource{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*archimedesv1.ArchimedesSource), err
+}
+
+// Delete takes name of the archimedesSource and deletes it. Returns an error if one occurs.
+func (c *FakeArchimedesSources) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(archimedessourcesResource, c.ns, name), &archimedesv1.ArchimedesSource{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeArchimedesSources) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(archimedessourcesResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &archimedesv1.ArchimedesSourceList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched archimedesSource.
+func (c *FakeArchimedesSources) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *archimedesv1.ArchimedesSource, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(archimedessourcesResource, c.ns, name, pt, data, subresources...), &archimedesv1.ArchimedesSource{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*archimedesv1.ArchimedesSource), err
+}
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied archimedesSource.
+func (c *FakeArchimedesSources) Apply(ctx context.Context, archimedesSource *applyconfigurationarchimedesv1.ArchimedesSourceApplyConfiguration, opts v1.ApplyOptions) (result *archimedesv1.ArchimedesSource, err error) {
+	if archimedesSource == nil {
+		return nil, fmt.Errorf("archimedesSource provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(archimedesSource)
+	if err != nil {
+		return nil, err
+	}
+	name := archimedesSource.Name
+	if name == nil {
+		return nil, fmt.Errorf("archimedesSource.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(archimedessourcesResource, c.ns, *name, types.ApplyPatchType, data), &archimedesv1.ArchimedesSource{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*archimedesv1.ArchimedesSource), err
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *FakeArchimedesSources) ApplyStatus(ctx context.Context, archimedesSource *applyconfigurationarchimedesv1.ArchimedesSourceApplyConfiguration, opts v1.ApplyOptions) (result *archimedesv1.ArchimedesSource, err error) {
+	if archimedesSource == nil {
+		return nil, fmt.Errorf("archimedesSource provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(archimedesSource)
+	if err != nil {
+		return nil, err
+	}
+	name := archimedesSource.Name
+	if name == nil {
+		return nil, fmt.Errorf("archimedesSource.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(archimedessourcesResource, c.ns, *name, types.ApplyPatchType, data, "status"), &archimedesv1.ArchimedesSource{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*archimedesv1.ArchimedesSource), err
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+	json "encoding/json"
+	"fmt"
+
+	archimedesv1 "github.com/backwoods-devops/archimedes/api/v1"
+	applyconfigurationarchimedesv1 "github.com/backwoods-devops/archimedes/pkg/client/applyconfiguration/archimedes/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeArchimedesValues implements ArchimedesValuesInterface
+type FakeArchimedesValues struct {
+	Fake *FakeArchimedesV1
+	ns   string
+}
+
+var archimedesvaluesResource = schema.GroupVersionResource{Group: "archimedes.backwoods-devops.io", Version: "v1", Resource: "archimedesvalues"}
+
+var archimedesvaluesKind = schema.GroupVersionKind{Group: "archimedes.backwoods-devops.io", Version: "v1", Kind: "ArchimedesValues"}
+
+// Get takes name of the archimedesValues, and returns the corresponding archimedesValues object, and an error if there is any.
+func (c *FakeArchimedesValues) Get(ctx context.Context, name string, options v1.GetOptions) (result *archimedesv1.ArchimedesValues, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(archimedesvaluesResource, c.ns, name), &archimedesv1.ArchimedesValues{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*archimedesv1.ArchimedesValues), err
+}
+
+// List takes label and field selectors, and returns the list of ArchimedesValues that match those selectors.
+func (c *FakeArchimedesValues) List(ctx context.Context, opts v1.ListOptions) (result *archimedesv1.ArchimedesValuesList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(archimedesvaluesResource, archimedesvaluesKind, c.ns, opts), &archimedesv1.ArchimedesValuesList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &archimedesv1.ArchimedesValuesList{ListMeta: obj.(*archimedesv1.ArchimedesValuesList).ListMeta}
+	for _, item := range obj.(*archimedesv1.ArchimedesValuesList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested archimedesValues.
+func (c *FakeArchimedesValues) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(archimedesvaluesResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a archimedesValues and creates it.  Returns the server's representation of the archimedesValues, and an error, if there is any.
+func (c *FakeArchimedesValues) Create(ctx context.Context, archimedesValues *archimedesv1.ArchimedesValues, opts v1.CreateOptions) (result *archimedesv1.ArchimedesValues, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(archimedesvaluesResource, c.ns, archimedesValues), &archimedesv1.ArchimedesValues{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*archimedesv1.ArchimedesValues), err
+}
+
+// Update takes the representation of a archimedesValues and updates it. Returns the server's representation of the archimedesValues, and an error, if there is any.
+func (c *FakeArchimedesValues) Update(ctx context.Context, archimedesValues *archimedesv1.ArchimedesValues, opts v1.UpdateOptions) (result *archimedesv1.ArchimedesValues, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(archimedesvaluesResource, c.ns, archimedesValues), &archimedesv1.ArchimedesValues{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*archimedesv1.ArchimedesValues), err
+}
+
+// Delete takes name of the archimedesValues and deletes it. Returns an error if one occurs.
+func (c *FakeArchimedesValues) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(archimedesvaluesResource, c.ns, name), &archimedesv1.ArchimedesValues{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeArchimedesValues) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(archimedesvaluesResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &archimedesv1.ArchimedesValuesList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched archimedesValues.
+func (c *FakeArchimedesValues) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *archimedesv1.ArchimedesValues, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(archimedesvaluesResource, c.ns, name, pt, data, subresources...), &archimedesv1.ArchimedesValues{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*archimedesv1.ArchimedesValues), err
+}
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied archimedesValues.
+func (c *FakeArchimedesValues) Apply(ctx context.Context, archimedesValues *applyconfigurationarchimedesv1.ArchimedesValuesApplyConfiguration, opts v1.ApplyOptions) (result *archimedesv1.ArchimedesValues, err error) {
+	if archimedesValues == nil {
+		return nil, fmt.Errorf("archimedesValues provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(archimedesValues)
+	if err != nil {
+		return nil, err
+	}
+	name := archimedesValues.Name
+	if name == nil {
+		return nil, fmt.Errorf("archimedesValues.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(archimedesvaluesResource, c.ns, *name, types.ApplyPatchType, data), &archimedesv1.ArchimedesValues{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*archimedesv1.ArchimedesValues), err
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+	json "encoding/json"
+	"fmt"
+
+	archimedesv1 "github.com/backwoods-devops/archimedes/api/v1"
+	applyconfigurationarchimedesv1 "github.com/backwoods-devops/archimedes/pkg/client/applyconfiguration/archimedes/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeClusterArchimedesValues implements ClusterArchimedesValuesInterface
+type FakeClusterArchimedesValues struct {
+	Fake *FakeArchimedesV1
+}
+
+var clusterarchimedesvaluesResource = schema.GroupVersionResource{Group: "archimedes.backwoods-devops.io", Version: "v1", Resource: "clusterarchimedesvalues"}
+
+var clusterarchimedesvaluesKind = schema.GroupVersionKind{Group: "archimedes.backwoods-devops.io", Version: "v1", Kind: "ClusterArchimedesValues"}
+
+// Get takes name of the clusterArchimedesValues, and returns the corresponding clusterArchimedesValues object, and an error if there is any.
+func (c *FakeClusterArchimedesValues) Get(ctx context.Context, name string, options v1.GetOptions) (result *archimedesv1.ClusterArchimedesValues, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(clusterarchimedesvaluesResource, name), &archimedesv1.ClusterArchimedesValues{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*archimedesv1.ClusterArchimedesValues), err
+}
+
+// List takes label and field selectors, and returns the list of ClusterArchimedesValues that match those selectors.
+func (c *FakeClusterArchimedesValues) List(ctx context.Context, opts v1.ListOptions) (result *archimedesv1.ClusterArchimedesValuesList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(clusterarchimedesvaluesResource, clusterarchimedesvaluesKind, opts), &archimedesv1.ClusterArchimedesValuesList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &archimedesv1.ClusterArchimedesValuesList{ListMeta: obj.(*archimedesv1.ClusterArchimedesValuesList).ListMeta}
+	for _, item := range obj.(*archimedesv1.ClusterArchimedesValuesList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested clusterArchimedesValues.
+func (c *FakeClusterArchimedesValues) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(clusterarchimedesvaluesResource, opts))
+}
+
+// Create takes the representation of a clusterArchimedesValues and creates it.  Returns the server's representation of the clusterArchimedesValues, and an error, if there is any.
+func (c *FakeClusterArchimedesValues) Create(ctx context.Context, clusterArchimedesValues *archimedesv1.ClusterArchimedesValues, opts v1.CreateOptions) (result *archimedesv1.ClusterArchimedesValues, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(clusterarchimedesvaluesResource, clusterArchimedesValues), &archimedesv1.ClusterArchimedesValues{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*archimedesv1.ClusterArchimedesValues), err
+}
+
+// Update takes the representation of a clusterArchimedesValues and updates it. Returns the server's representation of the clusterArchimedesValues, and an error, if there is any.
+func (c *FakeClusterArchimedesValues) Update(ctx context.Context, clusterArchimedesValues *archimedesv1.ClusterArchimedesValues, opts v1.UpdateOptions) (result *archimedesv1.ClusterArchimedesValues, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(clusterarchimedesvaluesResource, clusterArchimedesValues), &archimedesv1.ClusterArchimedesValues{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*archimedesv1.ClusterArchimedesValues), err
+}
+
+// Delete takes name of the clusterArchimedesValues and deletes it. Returns an error if one occurs.
+func (c *FakeClusterArchimedesValues) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteAction(clusterarchimedesvaluesResource, name), &archimedesv1.ClusterArchimedesValues{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeClusterArchimedesValues) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(clusterarchimedesvaluesResource, listOpts)
+
+	_, err := c.Fake.Invokes(action, &archimedesv1.ClusterArchimedesValuesList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched clusterArchimedesValues.
+func (c *FakeClusterArchimedesValues) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *archimedesv1.ClusterArchimedesValues, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(clusterarchimedesvaluesResource, name, pt, data, subresources...), &archimedesv1.ClusterArchimedesValues{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*archimedesv1.ClusterArchimedesValues), err
+}
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied clusterArchimedesValues.
+func (c *FakeClusterArchimedesValues) Apply(ctx context.Context, clusterArchimedesValues *applyconfigurationarchimedesv1.ClusterArchimedesValuesApplyConfiguration, opts v1.ApplyOptions) (result *archimedesv1.ClusterArchimedesValues, err error) {
+	if clusterArchimedesValues == nil {
+		return nil, fmt.Errorf("clusterArchimedesValues provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(clusterArchimedesValues)
+	if err != nil {
+		return nil, err
+	}
+	name := clusterArchimedesValues.Name
+	if name == nil {
+		return nil, fmt.Errorf("clusterArchimedesValues.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(clusterarchimedesvaluesResource, *name, types.ApplyPatchType, data), &archimedesv1.ClusterArchimedesValues{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*archimedesv1.ClusterArchimedesValues), err
+}
@@ -0,0 +1,26 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package client holds the clientset, shared informers, listers and apply configurations of the
+// archimedes.backwoods-devops.io/v1 API, for programs that use client-go rather than
+// controller-runtime. The subpackages are generated by hack/update-codegen.sh.
+//
+//	cs, err := versioned.NewForConfig(config)
+//	factory := externalversions.NewSharedInformerFactory(cs, 10*time.Minute)
+//	properties := factory.Archimedes().V1().ArchimedesProperties().Lister()
+//
+// Tests can use the clientset of the fake package in place of versioned.
+package client
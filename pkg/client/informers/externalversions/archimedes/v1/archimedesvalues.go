@@ -0,0 +1,89 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"context"
+	time "time"
+
+	archimedesv1 "github.com/backwoods-devops/archimedes/api/v1"
+	versioned "github.com/backwoods-devops/archimedes/pkg/client/clientset/versioned"
+	internalinterfaces "github.com/backwoods-devops/archimedes/pkg/client/informers/externalversions/internalinterfaces"
+	v1 "github.com/backwoods-devops/archimedes/pkg/client/listers/archimedes/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// ArchimedesValuesInformer provides access to a shared informer and lister for
+// ArchimedesValues.
+type ArchimedesValuesInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1.ArchimedesValuesLister
+}
+
+type archimedesValuesInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewArchimedesValuesInformer constructs a new informer for ArchimedesValues type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewArchimedesValuesInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredArchimedesValuesInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredArchimedesValuesInformer constructs a new informer for ArchimedesValues type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredArchimedesValuesInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.ArchimedesV1().ArchimedesValues(namespace).List(context.TODO(), options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.ArchimedesV1().ArchimedesValues(namespace).Watch(context.TODO(), options)
+			},
+		},
+		&archimedesv1.ArchimedesValues{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *archimedesValuesInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredArchimedesValuesInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *archimedesValuesInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&archimedesv1.ArchimedesValues{}, f.defaultInformer)
+}
+
+func (f *archimedesValuesInformer) Lister() v1.ArchimedesValuesLister {
+	return v1.NewArchimedesValuesLister(f.Informer().GetIndexer())
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	internalinterfaces "github.com/backwoods-devops/archimedes/pkg/client/informers/externalversions/internalinterfaces"
+)
+
+// Interface provides access to all the informers in this group version.
+type Interface interface {
+	// ArchimedesProperties returns a ArchimedesPropertyInformer.
+	ArchimedesProperties() ArchimedesPropertyInformer
+	// ArchimedesPropertySets returns a ArchimedesPropertySetInformer.
+	ArchimedesPropertySets() ArchimedesPropertySetInformer
+	// ArchimedesSources returns a ArchimedesSourceInformer.
+	ArchimedesSources() ArchimedesSourceInformer
+	// ArchimedesValues returns a ArchimedesValuesInformer.
+	ArchimedesValues() ArchimedesValuesInformer
+	// ClusterArchimedesValues returns a ClusterArchimedesValuesInformer.
+	ClusterArchimedesValues() ClusterArchimedesValuesInformer
+}
+
+type version struct {
+	factory          internalinterfaces.SharedInformerFactory
+	namespace        string
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+}
+
+// New returns a new Interface.
+func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakListOptions internalinterfaces.TweakListOptionsFunc) Interface {
+	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
+}
+
+// ArchimedesProperties returns a ArchimedesPropertyInformer.
+func (v *version) ArchimedesProperties() ArchimedesPropertyInformer {
+	return &archimedesPropertyInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// ArchimedesPropertySets returns a ArchimedesPropertySetInformer.
+func (v *version) ArchimedesPropertySets() ArchimedesPropertySetInformer {
+	return &archimedesPropertySetInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// ArchimedesSources returns a ArchimedesSourceInformer.
+func (v *version) ArchimedesSources() ArchimedesSourceInformer {
+	return &archimedesSourceInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// ArchimedesValues returns a ArchimedesValuesInformer.
+func (v *version) ArchimedesValues() ArchimedesValuesInformer {
+	return &archimedesValuesInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// ClusterArchimedesValues returns a ClusterArchimedesValuesInformer.
+func (v *version) ClusterArchimedesValues() ClusterArchimedesValuesInformer {
+	return &clusterArchimedesValuesInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "github.com/backwoods-devops/archimedes/api/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ArchimedesPropertyLister helps list ArchimedesProperties.
+// All objects returned here must be treated as read-only.
+type ArchimedesPropertyLister interface {
+	// List lists all ArchimedesProperties in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.ArchimedesProperty, err error)
+	// ArchimedesProperties returns an object that can list and get ArchimedesProperties.
+	ArchimedesProperties(namespace string) ArchimedesPropertyNamespaceLister
+	ArchimedesPropertyListerExpansion
+}
+
+// archimedesPropertyLister implements the ArchimedesPropertyLister interface.
+type archimedesPropertyLister struct {
+	indexer cache.Indexer
+}
+
+// NewArchimedesPropertyLister returns a new ArchimedesPropertyLister.
+func NewArchimedesPropertyLister(indexer cache.Indexer) ArchimedesPropertyLister {
+	return &archimedesPropertyLister{indexer: indexer}
+}
+
+// List lists all ArchimedesProperties in the indexer.
+func (s *archimedesPropertyLister) List(selector labels.Selector) (ret []*v1.ArchimedesProperty, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.ArchimedesProperty))
+	})
+	return ret, err
+}
+
+// ArchimedesProperties returns an object that can list and get ArchimedesProperties.
+func (s *archimedesPropertyLister) ArchimedesProperties(namespace string) ArchimedesPropertyNamespaceLister {
+	return archimedesPropertyNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// ArchimedesPropertyNamespaceLister helps list and get ArchimedesProperties.
+// All objects returned here must be treated as read-only.
+type ArchimedesPropertyNamespaceLister interface {
+	// List lists all ArchimedesProperties in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.ArchimedesProperty, err error)
+	// Get retrieves the ArchimedesProperty from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1.ArchimedesProperty, error)
+	ArchimedesPropertyNamespaceListerExpansion
+}
+
+// archimedesPropertyNamespaceLister implements the ArchimedesPropertyNamespaceLister
+// interface.
+type archimedesPropertyNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all ArchimedesProperties in the indexer for a given namespace.
+func (s archimedesPropertyNamespaceLister) List(selector labels.Selector) (ret []*v1.ArchimedesProperty, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.ArchimedesProperty))
+	})
+	return ret, err
+}
+
+// Get retrieves the ArchimedesProperty from the indexer for a given namespace and name.
+func (s archimedesPropertyNamespaceLister) Get(name string) (*v1.ArchimedesProperty, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1.Resource("archimedesproperty"), name)
+	}
+	return obj.(*v1.ArchimedesProperty), nil
+}
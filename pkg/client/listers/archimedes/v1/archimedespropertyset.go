@@ -0,0 +1,98 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "github.com/backwoods-devops/archimedes/api/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ArchimedesPropertySetLister helps list ArchimedesPropertySets.
+// All objects returned here must be treated as read-only.
+type ArchimedesPropertySetLister interface {
+	// List lists all ArchimedesPropertySets in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.ArchimedesPropertySet, err error)
+	// ArchimedesPropertySets returns an object that can list and get ArchimedesPropertySets.
+	ArchimedesPropertySets(namespace string) ArchimedesPropertySetNamespaceLister
+	ArchimedesPropertySetListerExpansion
+}
+
+// archimedesPropertySetLister implements the ArchimedesPropertySetLister interface.
+type archimedesPropertySetLister struct {
+	indexer cache.Indexer
+}
+
+// NewArchimedesPropertySetLister returns a new ArchimedesPropertySetLister.
+func NewArchimedesPropertySetLister(indexer cache.Indexer) ArchimedesPropertySetLister {
+	return &archimedesPropertySetLister{indexer: indexer}
+}
+
+// List lists all ArchimedesPropertySets in the indexer.
+func (s *archimedesPropertySetLister) List(selector labels.Selector) (ret []*v1.ArchimedesPropertySet, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.ArchimedesPropertySet))
+	})
+	return ret, err
+}
+
+// ArchimedesPropertySets returns an object that can list and get ArchimedesPropertySets.
+func (s *archimedesPropertySetLister) ArchimedesPropertySets(namespace string) ArchimedesPropertySetNamespaceLister {
+	return archimedesPropertySetNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// ArchimedesPropertySetNamespaceLister helps list and get ArchimedesPropertySets.
+// All objects returned here must be treated as read-only.
+type ArchimedesPropertySetNamespaceLister interface {
+	// List lists all ArchimedesPropertySets in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.ArchimedesPropertySet, err error)
+	// Get retrieves the ArchimedesPropertySet from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1.ArchimedesPropertySet, error)
+	ArchimedesPropertySetNamespaceListerExpansion
+}
+
+// archimedesPropertySetNamespaceLister implements the ArchimedesPropertySetNamespaceLister
+// interface.
+type archimedesPropertySetNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all ArchimedesPropertySets in the indexer for a given namespace.
+func (s archimedesPropertySetNamespaceLister) List(selector labels.Selector) (ret []*v1.ArchimedesPropertySet, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.ArchimedesPropertySet))
+	})
+	return ret, err
+}
+
+// Get retrieves the ArchimedesPropertySet from the indexer for a given namespace and name.
+func (s archimedesPropertySetNamespaceLister) Get(name string) (*v1.ArchimedesPropertySet, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1.Resource("archimedespropertyset"), name)
+	}
+	return obj.(*v1.ArchimedesPropertySet), nil
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "github.com/backwoods-devops/archimedes/api/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ArchimedesSourceLister helps list ArchimedesSources.
+// All objects returned here must be treated as read-only.
+type ArchimedesSourceLister interface {
+	// List lists all ArchimedesSources in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.ArchimedesSource, err error)
+	// ArchimedesSources returns an object that can list and get ArchimedesSources.
+	ArchimedesSources(namespace string) ArchimedesSourceNamespaceLister
+	ArchimedesSourceListerExpansion
+}
+
+// archimedesSourceLister implements the ArchimedesSourceLister interface.
+type archimedesSourceLister struct {
+	indexer cache.Indexer
+}
+
+// NewArchimedesSourceLister returns a new ArchimedesSourceLister.
+func NewArchimedesSourceLister(indexer cache.Indexer) ArchimedesSourceLister {
+	return &archimedesSourceLister{indexer: indexer}
+}
+
+// List lists all ArchimedesSources in the indexer.
+func (s *archimedesSourceLister) List(selector labels.Selector) (ret []*v1.ArchimedesSource, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.ArchimedesSource))
+	})
+	return ret, err
+}
+
+// ArchimedesSources returns an object that can list and get ArchimedesSources.
+func (s *archimedesSourceLister) ArchimedesSources(namespace string) ArchimedesSourceNamespaceLister {
+	return archimedesSourceNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// ArchimedesSourceNamespaceLister helps list and get ArchimedesSources.
+// All objects returned here must be treated as read-only.
+type ArchimedesSourceNamespaceLister interface {
+	// List lists all ArchimedesSources in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.ArchimedesSource, err error)
+	// Get retrieves the ArchimedesSource from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1.ArchimedesSource, error)
+	ArchimedesSourceNamespaceListerExpansion
+}
+
+// archimedesSourceNamespaceLister implements the ArchimedesSourceNamespaceLister
+// interface.
+type archimedesSourceNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all ArchimedesSources in the indexer for a given namespace.
+func (s archimedesSourceNamespaceLister) List(selector labels.Selector) (ret []*v1.ArchimedesSource, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.ArchimedesSource))
+	})
+	return ret, err
+}
+
+// Get retrieves the ArchimedesSource from the indexer for a given namespace and name.
+func (s archimedesSourceNamespaceLister) Get(name string) (*v1.ArchimedesSource, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1.Resource("archimedessource"), name)
+	}
+	return obj.(*v1.ArchimedesSource), nil
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "github.com/backwoods-devops/archimedes/api/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ArchimedesValuesLister helps list ArchimedesValues.
+// All objects returned here must be treated as read-only.
+type ArchimedesValuesLister interface {
+	// List lists all ArchimedesValues in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.ArchimedesValues, err error)
+	// ArchimedesValues returns an object that can list and get ArchimedesValues.
+	ArchimedesValues(namespace string) ArchimedesValuesNamespaceLister
+	ArchimedesValuesListerExpansion
+}
+
+// archimedesValuesLister implements the ArchimedesValuesLister interface.
+type archimedesValuesLister struct {
+	indexer cache.Indexer
+}
+
+// NewArchimedesValuesLister returns a new ArchimedesValuesLister.
+func NewArchimedesValuesLister(indexer cache.Indexer) ArchimedesValuesLister {
+	return &archimedesValuesLister{indexer: indexer}
+}
+
+// List lists all ArchimedesValues in the indexer.
+func (s *archimedesValuesLister) List(selector labels.Selector) (ret []*v1.ArchimedesValues, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.ArchimedesValues))
+	})
+	return ret, err
+}
+
+// ArchimedesValues returns an object that can list and get ArchimedesValues.
+func (s *archimedesValuesLister) ArchimedesValues(namespace string) ArchimedesValuesNamespaceLister {
+	return archimedesValuesNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// ArchimedesValuesNamespaceLister helps list and get ArchimedesValues.
+// All objects returned here must be treated as read-only.
+type ArchimedesValuesNamespaceLister interface {
+	// List lists all ArchimedesValues in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.ArchimedesValues, err error)
+	// Get retrieves the ArchimedesValues from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1.ArchimedesValues, error)
+	ArchimedesValuesNamespaceListerExpansion
+}
+
+// archimedesValuesNamespaceLister implements the ArchimedesValuesNamespaceLister
+// interface.
+type archimedesValuesNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all ArchimedesValues in the indexer for a given namespace.
+func (s archimedesValuesNamespaceLister) List(selector labels.Selector) (ret []*v1.ArchimedesValues, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.ArchimedesValues))
+	})
+	return ret, err
+}
+
+// Get retrieves the ArchimedesValues from the indexer for a given namespace and name.
+func (s archimedesValuesNamespaceLister) Get(name string) (*v1.ArchimedesValues, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1.Resource("archimedesvalues"), name)
+	}
+	return obj.(*v1.ArchimedesValues), nil
+}
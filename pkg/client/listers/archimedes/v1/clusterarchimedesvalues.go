@@ -0,0 +1,67 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "github.com/backwoods-devops/archimedes/api/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ClusterArchimedesValuesLister helps list ClusterArchimedesValues.
+// All objects returned here must be treated as read-only.
+type ClusterArchimedesValuesLister interface {
+	// List lists all ClusterArchimedesValues in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.ClusterArchimedesValues, err error)
+	// Get retrieves the ClusterArchimedesValues from the index for a given name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1.ClusterArchimedesValues, error)
+	ClusterArchimedesValuesListerExpansion
+}
+
+// clusterArchimedesValuesLister implements the ClusterArchimedesValuesLister interface.
+type clusterArchimedesValuesLister struct {
+	indexer cache.Indexer
+}
+
+// NewClusterArchimedesValuesLister returns a new ClusterArchimedesValuesLister.
+func NewClusterArchimedesValuesLister(indexer cache.Indexer) ClusterArchimedesValuesLister {
+	return &clusterArchimedesValuesLister{indexer: indexer}
+}
+
+// List lists all ClusterArchimedesValues in the indexer.
+func (s *clusterArchimedesValuesLister) List(selector labels.Selector) (ret []*v1.ClusterArchimedesValues, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.ClusterArchimedesValues))
+	})
+	return ret, err
+}
+
+// Get retrieves the ClusterArchimedesValues from the index for a given name.
+func (s *clusterArchimedesValuesLister) Get(name string) (*v1.ClusterArchimedesValues, error) {
+	obj, exists, err := s.indexer.GetByKey(name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1.Resource("clusterarchimedesvalues"), name)
+	}
+	return obj.(*v1.ClusterArchimedesValues), nil
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+// ArchimedesPropertyListerExpansion allows custom methods to be added to
+// ArchimedesPropertyLister.
+type ArchimedesPropertyListerExpansion interface{}
+
+// ArchimedesPropertyNamespaceListerExpansion allows custom methods to be added to
+// ArchimedesPropertyNamespaceLister.
+type ArchimedesPropertyNamespaceListerExpansion interface{}
+
+// ArchimedesPropertySetListerExpansion allows custom methods to be added to
+// ArchimedesPropertySetLister.
+type ArchimedesPropertySetListerExpansion interface{}
+
+// ArchimedesPropertySetNamespaceListerExpansion allows custom methods to be added to
+// ArchimedesPropertySetNamespaceLister.
+type ArchimedesPropertySetNamespaceListerExpansion interface{}
+
+// ArchimedesSourceListerExpansion allows custom methods to be added to
+// ArchimedesSourceLister.
+type ArchimedesSourceListerExpansion interface{}
+
+// ArchimedesSourceNamespaceListerExpansion allows custom methods to be added to
+// ArchimedesSourceNamespaceLister.
+type ArchimedesSourceNamespaceListerExpansion interface{}
+
+// ArchimedesValuesListerExpansion allows custom methods to be added to
+// ArchimedesValuesLister.
+type ArchimedesValuesListerExpansion interface{}
+
+// ArchimedesValuesNamespaceListerExpansion allows custom methods to be added to
+// ArchimedesValuesNamespaceLister.
+type ArchimedesValuesNamespaceListerExpansion interface{}
+
+// ClusterArchimedesValuesListerExpansion allows custom methods to be added to
+// ClusterArchimedesValuesLister.
+type ClusterArchimedesValuesListerExpansion interface{}
@@ -0,0 +1,83 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+
+	backwoodsv1 "github.com/backwoods-devops/archimedes/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ConfigMapOptions configure a ConfigMapSource
+type ConfigMapOptions struct {
+	// Reader reads the ConfigMaps holding templates
+	Reader client.Reader
+	// Git configures the clones of template libraries
+	Git GitOptions
+}
+
+// ConfigMapSource reads the templates of properties from a ConfigMap in their namespace.
+// The propertiesPath of a property is the key of its template and its includePaths are
+// patterns matching the keys of the templates it includes.
+type ConfigMapSource struct {
+	Options ConfigMapOptions
+}
+
+// NewConfigMapSource returns a Source reading the templates of properties from ConfigMaps
+func NewConfigMapSource(opts ConfigMapOptions) *ConfigMapSource {
+	return &ConfigMapSource{Options: opts}
+}
+
+// Fetch reads the template of a property from its templateConfigMap. The commit of the
+// template is a hash of the data of the ConfigMap.
+func (s *ConfigMapSource) Fetch(ctx context.Context, r *backwoodsv1.ArchimedesProperty) (*Template, error) {
+	if r.Spec.TemplateConfigMap == nil || r.Spec.TemplateConfigMap.Name == "" {
+		return nil, &FetchError{Err: errors.New("the property has no templateConfigMap")}
+	}
+	name := fmt.Sprintf("ConfigMap/%s", r.Spec.TemplateConfigMap.Name)
+
+	cm := &corev1.ConfigMap{}
+	err := s.Options.Reader.Get(ctx, client.ObjectKey{Name: r.Spec.TemplateConfigMap.Name, Namespace: r.Namespace}, cm)
+	if err != nil {
+		return nil, &FetchError{RepoUrl: name, Err: err}
+	}
+
+	src := &Template{RepoUrl: name, Commit: Commit{Hash: HashData(cm.Data)}, Partials: map[string][]byte{}}
+	content, ok := cm.Data[r.Spec.PropertiesPath]
+	if !ok {
+		return src, &FetchError{RepoUrl: name, Path: r.Spec.PropertiesPath, Err: errors.New("no such key")}
+	}
+	src.Content = []byte(content)
+	for _, pattern := range r.Spec.IncludePaths {
+		for key, value := range cm.Data {
+			matched, err := path.Match(pattern, key)
+			if err != nil {
+				return src, &FetchError{RepoUrl: name, Err: err}
+			}
+			if matched {
+				src.Partials[key] = []byte(value)
+			}
+		}
+	}
+	err = readLibrary(r, s.Options.Git, src.Partials)
+	return src, err
+}
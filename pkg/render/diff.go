@@ -0,0 +1,55 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"sort"
+)
+
+// Diff lists the keys that differ between two versions of the data of a ConfigMap
+type Diff struct {
+	Added   []string
+	Removed []string
+	Changed []string
+}
+
+// DiffData returns the keys added, removed and changed going from the old to the new data
+func DiffData(old, new map[string]string) Diff {
+	diff := Diff{Added: []string{}, Removed: []string{}, Changed: []string{}}
+	for k, v := range new {
+		oldValue, ok := old[k]
+		if !ok {
+			diff.Added = append(diff.Added, k)
+		} else if oldValue != v {
+			diff.Changed = append(diff.Changed, k)
+		}
+	}
+	for k := range old {
+		if _, ok := new[k]; !ok {
+			diff.Removed = append(diff.Removed, k)
+		}
+	}
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Removed)
+	sort.Strings(diff.Changed)
+	return diff
+}
+
+// Empty reports whether the data did not change
+func (d Diff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"errors"
+	"fmt"
+)
+
+// FetchError is returned when the template of a property cannot be fetched from its source
+type FetchError struct {
+	// RepoUrl and Revision locate the template that could not be fetched, the url of an
+	// http source or the ConfigMap of a configMap source have no revision
+	RepoUrl  string
+	Revision string
+	// Path is the file that could not be read, empty when the repo could not be fetched
+	Path string
+	Err  error
+}
+
+func (e *FetchError) Error() string {
+	location := e.RepoUrl
+	if e.Revision != "" {
+		location = fmt.Sprintf("%s at %s", e.RepoUrl, e.Revision)
+	}
+	if e.Path != "" {
+		return fmt.Sprintf("could not read %s from %s: %s", e.Path, location, e.Err)
+	}
+	return fmt.Sprintf("could not fetch %s: %s", location, e.Err)
+}
+
+func (e *FetchError) Unwrap() error {
+	return e.Err
+}
+
+// ValuesError is returned when a document of values cannot be parsed or read
+type ValuesError struct {
+	// Source names the values, such as sourceConfig or ArchimedesValues/shared
+	Source string
+	// Read is set when the values could not be read rather than parsed
+	Read bool
+	Err  error
+}
+
+func (e *ValuesError) Error() string {
+	if e.Read {
+		return fmt.Sprintf("could not read %s: %s", e.Source, e.Err)
+	}
+	return fmt.Sprintf("could not parse %s: %s", e.Source, e.Err)
+}
+
+func (e *ValuesError) Unwrap() error {
+	return e.Err
+}
+
+// TemplateError is returned when a template cannot be parsed or executed
+type TemplateError struct {
+	// Name is the properties template or the path of the partial that failed
+	Name string
+	Err  error
+}
+
+func (e *TemplateError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *TemplateError) Unwrap() error {
+	return e.Err
+}
+
+// LimitError is returned when a render is aborted for exceeding one of its Limits
+type LimitError struct {
+	Message string
+}
+
+func (e *LimitError) Error() string {
+	return e.Message
+}
+
+// IsLimitError reports whether err was caused by a render exceeding its limits
+func IsLimitError(err error) bool {
+	var limitErr *LimitError
+	return errors.As(err, &limitErr)
+}
+
+// FormatError is returned when rendered output cannot be converted into ConfigMap data
+type FormatError struct {
+	PropertyType string
+	Message      string
+}
+
+func (e *FormatError) Error() string {
+	return e.Message
+}
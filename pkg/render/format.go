@@ -0,0 +1,98 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+)
+
+const (
+	// PropertyTypeKvp stores every key=value line of the output as a key of the ConfigMap
+	PropertyTypeKvp = "kvp"
+	// PropertyTypeKey stores the whole output under the keyName of the property
+	PropertyTypeKey = "key"
+)
+
+// Formatter converts rendered output into ConfigMap data
+type Formatter func(output string, opts FormatOptions) (map[string]string, error)
+
+// FormatOptions configure the conversion of rendered output into ConfigMap data
+type FormatOptions struct {
+	// PropertyType selects the format of the output
+	PropertyType string
+	// KeyName is the key holding the output with the key property type
+	KeyName string
+	// Formats are added to the kvp and key formats keyed by property type, so tools can
+	// convert output the operator does not support
+	Formats map[string]Formatter
+}
+
+// formats are the property types supported by the operator
+var formats = map[string]Formatter{
+	PropertyTypeKvp: formatKvp,
+	PropertyTypeKey: formatKey,
+}
+
+// Format converts rendered output into ConfigMap data according to a property type. With
+// the kvp property type every line is a key=value pair, with the key type the whole output
+// is stored under keyName.
+func Format(output string, opts FormatOptions) (map[string]string, error) {
+	format, ok := opts.Formats[opts.PropertyType]
+	if !ok {
+		format, ok = formats[opts.PropertyType]
+	}
+	if !ok {
+		return nil, &FormatError{
+			PropertyType: opts.PropertyType,
+			Message:      fmt.Sprintf("invalid propertyType %q, valid types are kvp and key", opts.PropertyType),
+		}
+	}
+	return format(output, opts)
+}
+
+func formatKvp(output string, opts FormatOptions) (map[string]string, error) {
+	data := map[string]string{}
+	output = strings.TrimSpace(output)
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	// a line may be as long as the whole output, the size of which is bounded by the render limits
+	scanner.Buffer(nil, len(output)+1)
+	line := 0
+	for scanner.Scan() {
+		line++
+		s := strings.SplitN(scanner.Text(), "=", 2)
+		if len(s) < 2 {
+			return nil, &FormatError{
+				PropertyType: opts.PropertyType,
+				Message:      fmt.Sprintf("line %d of the output is not a key=value pair: %q", line, scanner.Text()),
+			}
+		}
+		data[s[0]] = s[1]
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, &FormatError{PropertyType: opts.PropertyType, Message: fmt.Sprintf("could not read line %d of the output: %v", line+1, err)}
+	}
+	return data, nil
+}
+
+func formatKey(output string, opts FormatOptions) (map[string]string, error) {
+	if opts.KeyName == "" {
+		return nil, &FormatError{PropertyType: opts.PropertyType, Message: "keyName is required when propertyType is key"}
+	}
+	return map[string]string{opts.KeyName: strings.TrimSpace(output)}, nil
+}
@@ -0,0 +1,234 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	backwoodsv1 "github.com/backwoods-devops/archimedes/api/v1"
+	git "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+)
+
+// ReadTemplate reads the properties template of a property and the templates it
+// includes from the repo checked out in dir, cloning its library when it has one
+func ReadTemplate(dir, repoUrl, revision string, commit Commit, r *backwoodsv1.ArchimedesProperty, opts GitOptions) (*Template, error) {
+	src := &Template{RepoUrl: repoUrl, Revision: revision, Commit: commit, Partials: map[string][]byte{}}
+	name, err := filepath.Rel(dir, filepath.Join(dir, r.Spec.PropertiesPath))
+	if err != nil {
+		return src, &FetchError{RepoUrl: repoUrl, Revision: revision, Path: r.Spec.PropertiesPath, Err: err}
+	}
+	if !isInside(name) {
+		return src, &FetchError{RepoUrl: repoUrl, Revision: revision, Path: r.Spec.PropertiesPath, Err: errors.New("the properties path is outside of the repo")}
+	}
+	src.Content, err = readFileIn(dir, name)
+	if err != nil {
+		return src, &FetchError{RepoUrl: repoUrl, Revision: revision, Path: r.Spec.PropertiesPath, Err: err}
+	}
+	err = ReadPartials(dir, r.Spec.IncludePaths, src.Partials)
+	if err != nil {
+		return src, &FetchError{RepoUrl: repoUrl, Revision: revision, Err: err}
+	}
+	err = readLibrary(r, opts, src.Partials)
+	return src, err
+}
+
+// readLibrary clones the library of a property, if it has one, and reads its templates
+// into partials
+func readLibrary(r *backwoodsv1.ArchimedesProperty, opts GitOptions, partials map[string][]byte) error {
+	lib := r.Spec.Library
+	if lib == nil {
+		return nil
+	}
+	libDir, err := ioutil.TempDir(opts.tempDir(), "archimedes_lib_")
+	if err != nil {
+		return &FetchError{RepoUrl: lib.RepoUrl, Revision: lib.Revision, Err: err}
+	}
+	defer os.RemoveAll(libDir)
+
+	certs, err := ReadCA(lib.CAPath)
+	if err != nil {
+		return &FetchError{RepoUrl: lib.RepoUrl, Revision: lib.Revision, Err: err}
+	}
+	_, err = CloneRepo(libDir, lib.RepoUrl, lib.Revision, opts.auth(), certs)
+	if err != nil {
+		return &FetchError{RepoUrl: lib.RepoUrl, Revision: lib.Revision, Err: err}
+	}
+	err = ReadPartials(libDir, lib.Paths, partials)
+	if err != nil {
+		return &FetchError{RepoUrl: lib.RepoUrl, Revision: lib.Revision, Err: err}
+	}
+	return nil
+}
+
+// EnvAuth returns the credentials for git repos from the USER and PASS environment variables
+func EnvAuth() *http.BasicAuth {
+	return &http.BasicAuth{
+		Username: os.Getenv("USER"),
+		Password: os.Getenv("PASS"),
+	}
+}
+
+// UserGitOptions returns the options cloning repos as user with the password read from
+// the passwordEnv environment variable, or anonymously when user is empty
+func UserGitOptions(user, passwordEnv string) (GitOptions, error) {
+	if user == "" {
+		if passwordEnv != "" {
+			return GitOptions{}, fmt.Errorf("a git password needs a git user")
+		}
+		return GitOptions{Anonymous: true}, nil
+	}
+	auth := &http.BasicAuth{Username: user}
+	if passwordEnv != "" {
+		auth.Password = os.Getenv(passwordEnv)
+		if auth.Password == "" {
+			return GitOptions{}, fmt.Errorf("the git password variable %s is not set", passwordEnv)
+		}
+	}
+	return GitOptions{Auth: auth}, nil
+}
+
+// ReadCA reads the CA certificate at caPath, it returns nothing when the file does not exist
+func ReadCA(caPath string) ([]byte, error) {
+	if _, err := os.Stat(caPath); err != nil {
+		return nil, nil
+	}
+	return ioutil.ReadFile(caPath)
+}
+
+// CloneRepo clones a single branch of a repo into dir and returns its head commit
+func CloneRepo(dir, url, revision string, auth *http.BasicAuth, certs []byte) (*Commit, error) {
+	// a nil auth clones anonymously, it must not be passed as a typed nil AuthMethod
+	var method transport.AuthMethod
+	if auth != nil {
+		method = auth
+	}
+	repo, err := git.PlainClone(dir, false, &git.CloneOptions{
+		URL:               url,
+		Auth:              method,
+		ReferenceName:     plumbing.ReferenceName(fmt.Sprintf("refs/heads/%s", revision)),
+		SingleBranch:      true,
+		RecurseSubmodules: git.DefaultSubmoduleRecursionDepth,
+		CABundle:          certs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	ref, err := repo.Head()
+	if err != nil {
+		return nil, err
+	}
+	return DescribeCommit(repo, ref.Hash())
+}
+
+// DescribeCommit returns the details of a commit of repo and the tag pointing at it
+func DescribeCommit(repo *git.Repository, hash plumbing.Hash) (*Commit, error) {
+	commit, err := repo.CommitObject(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &Commit{
+		Hash:      commit.Hash.String(),
+		Author:    commit.Author.Name,
+		Message:   strings.TrimSpace(commit.Message),
+		Timestamp: commit.Committer.When,
+	}
+
+	tags, err := repo.Tags()
+	if err != nil {
+		return nil, err
+	}
+	defer tags.Close()
+	err = tags.ForEach(func(ref *plumbing.Reference) error {
+		hash := ref.Hash()
+		if tag, err := repo.TagObject(hash); err == nil {
+			hash = tag.Target
+		}
+		if hash == commit.Hash {
+			info.Tag = ref.Name().Short()
+			return storer.ErrStop
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return info, nil
+}
+
+// ReadPartials reads every file in dir matching the glob patterns into partials,
+// keyed by the path of the file relative to dir
+func ReadPartials(dir string, patterns []string, partials map[string][]byte) error {
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err != nil {
+			return err
+		}
+		for _, match := range matches {
+			name, err := filepath.Rel(dir, match)
+			if err != nil {
+				return err
+			}
+			if !isInside(name) {
+				return fmt.Errorf("include path %q is outside of the repo", pattern)
+			}
+			content, err := readFileIn(dir, name)
+			if err != nil {
+				return err
+			}
+			partials[filepath.ToSlash(name)] = content
+		}
+	}
+	return nil
+}
+
+// readFileIn reads the file name relative to dir. Symlinks are followed as long as they
+// resolve to a file inside dir, a link out of a repo or mounted path is an error.
+func readFileIn(dir, name string) ([]byte, error) {
+	root, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return nil, err
+	}
+	path, err := filepath.EvalSymlinks(filepath.Join(dir, name))
+	if err != nil {
+		return nil, err
+	}
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return nil, err
+	}
+	if !isInside(rel) {
+		return nil, fmt.Errorf("%s links outside of the repo", filepath.ToSlash(name))
+	}
+	return ioutil.ReadFile(path)
+}
+
+// isInside reports whether a path relative to a directory, as returned by filepath.Rel,
+// stays inside the directory
+func isInside(rel string) bool {
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,249 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	neturl "net/url"
+	"strings"
+	"sync"
+	"time"
+
+	backwoodsv1 "github.com/backwoods-devops/archimedes/api/v1"
+)
+
+const (
+	// DefaultMaxTemplateBytes is the largest template an HTTPSource downloads by default
+	DefaultMaxTemplateBytes = 1024 * 1024
+	// DefaultHTTPTimeout is how long an HTTPSource waits for a template by default
+	DefaultHTTPTimeout = 30 * time.Second
+)
+
+// HTTPOptions configure an HTTPSource
+type HTTPOptions struct {
+	// Client sends the requests, a client trusting the http.caPath of the property when nil
+	Client *http.Client
+	// MaxBytes is the largest template downloaded, DefaultMaxTemplateBytes when 0
+	MaxBytes int64
+	// Timeout limits the download of a template, DefaultHTTPTimeout when 0
+	Timeout time.Duration
+	// URLPrefixes are the urls templates may be downloaded from, redirects included, see
+	// URLHasPrefix. Templates are downloaded from any url when empty.
+	URLPrefixes []string
+	// Git configures the clones of template libraries
+	Git GitOptions
+}
+
+func (o HTTPOptions) timeout() time.Duration {
+	if o.Timeout <= 0 {
+		return DefaultHTTPTimeout
+	}
+	return o.Timeout
+}
+
+func (o HTTPOptions) maxBytes() int64 {
+	if o.MaxBytes <= 0 {
+		return DefaultMaxTemplateBytes
+	}
+	return o.MaxBytes
+}
+
+// HTTPSource downloads the templates of properties from their http.url. The last template
+// of every url is kept with its ETag and Last-Modified headers, so the server can answer
+// Not Modified instead of sending an unchanged template again.
+type HTTPSource struct {
+	Options HTTPOptions
+
+	mu    sync.Mutex
+	cache map[string]*httpTemplate
+}
+
+// httpTemplate is the last template downloaded from a url
+type httpTemplate struct {
+	etag         string
+	lastModified string
+	content      []byte
+	commit       Commit
+}
+
+// NewHTTPSource returns a Source downloading the templates of properties over http
+func NewHTTPSource(opts HTTPOptions) *HTTPSource {
+	return &HTTPSource{Options: opts, cache: map[string]*httpTemplate{}}
+}
+
+// Fetch downloads the template of a property, or reuses the last one downloaded from its
+// url when the server reports it has not changed. The commit of the template is a hash of
+// its content, timestamped with its Last-Modified header.
+func (s *HTTPSource) Fetch(ctx context.Context, r *backwoodsv1.ArchimedesProperty) (*Template, error) {
+	if r.Spec.HTTP == nil || r.Spec.HTTP.Url == "" {
+		return nil, &FetchError{Err: errors.New("the property has no http.url")}
+	}
+	url := r.Spec.HTTP.Url
+	if err := s.checkURL(url); err != nil {
+		return nil, &FetchError{RepoUrl: url, Err: err}
+	}
+
+	cached := s.cached(url)
+	tpl, err := s.download(ctx, url, r.Spec.HTTP.CAPath, cached)
+	if err != nil {
+		return nil, &FetchError{RepoUrl: url, Err: err}
+	}
+	if tpl != cached {
+		s.mu.Lock()
+		if s.cache == nil {
+			s.cache = map[string]*httpTemplate{}
+		}
+		s.cache[url] = tpl
+		s.mu.Unlock()
+	}
+
+	src := &Template{RepoUrl: url, Commit: tpl.commit, Content: tpl.content, Partials: map[string][]byte{}}
+	err = readLibrary(r, s.Options.Git, src.Partials)
+	return src, err
+}
+
+func (s *HTTPSource) cached(url string) *httpTemplate {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cache[url]
+}
+
+// download requests the template at url, conditionally on the validators of the cached
+// template, and returns the cached template when the server answers Not Modified
+func (s *HTTPSource) download(ctx context.Context, url, caPath string, cached *httpTemplate) (*httpTemplate, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.Options.timeout())
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if cached != nil {
+		if cached.etag != "" {
+			req.Header.Set("If-None-Match", cached.etag)
+		}
+		if cached.lastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.lastModified)
+		}
+	}
+
+	client, err := s.client(caPath)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		return cached, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	content, err := ioutil.ReadAll(io.LimitReader(resp.Body, s.Options.maxBytes()+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(content)) > s.Options.maxBytes() {
+		return nil, fmt.Errorf("the template is larger than %d bytes", s.Options.maxBytes())
+	}
+
+	tpl := &httpTemplate{
+		etag:         resp.Header.Get("ETag"),
+		lastModified: resp.Header.Get("Last-Modified"),
+		content:      content,
+	}
+	tpl.commit.Hash = contentHash(&Template{Content: content})
+	if modified, err := http.ParseTime(tpl.lastModified); err == nil {
+		tpl.commit.Timestamp = modified
+	} else {
+		tpl.commit.Timestamp = time.Now()
+	}
+	return tpl, nil
+}
+
+// checkURL returns an error when the options do not allow downloading from url
+func (s *HTTPSource) checkURL(url string) error {
+	if len(s.Options.URLPrefixes) > 0 && !URLHasPrefix(url, s.Options.URLPrefixes) {
+		return fmt.Errorf("%s does not start with an allowed url prefix", url)
+	}
+	return nil
+}
+
+// client returns the client of the options, or one trusting the CA certificate at caPath
+// that only follows redirects to allowed urls
+func (s *HTTPSource) client(caPath string) (*http.Client, error) {
+	if s.Options.Client != nil {
+		return s.Options.Client, nil
+	}
+	client := &http.Client{
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= 10 {
+				return errors.New("stopped after 10 redirects")
+			}
+			return s.checkURL(req.URL.String())
+		},
+	}
+	certs, err := ReadCA(caPath)
+	if err != nil {
+		return nil, err
+	}
+	if certs == nil {
+		return client, nil
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(certs) {
+		return nil, fmt.Errorf("no certificates found in %s", caPath)
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	client.Transport = transport
+	return client, nil
+}
+
+// URLHasPrefix reports whether rawURL has the scheme and host of one of prefixes and a path
+// under its path. Paths are compared by segment, so https://example.com/templates allows
+// https://example.com/templates/app.tpl but not https://example.com/templates-old/app.tpl.
+func URLHasPrefix(rawURL string, prefixes []string) bool {
+	u, err := neturl.Parse(rawURL)
+	if err != nil || u.User != nil {
+		return false
+	}
+	for _, prefix := range prefixes {
+		p, err := neturl.Parse(prefix)
+		if err != nil || p.Host == "" || !strings.EqualFold(u.Scheme, p.Scheme) || !strings.EqualFold(u.Host, p.Host) {
+			continue
+		}
+		dir := strings.TrimSuffix(p.Path, "/")
+		if u.Path == dir || strings.HasPrefix(u.Path, dir+"/") {
+			return true
+		}
+	}
+	return false
+}
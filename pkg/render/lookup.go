@@ -0,0 +1,172 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ClusterLookup provides template functions that read live cluster data at render time.
+// Lookups are limited to the namespace of the property unless the operator allows more.
+type ClusterLookup struct {
+	ctx       context.Context
+	reader    client.Reader
+	namespace string
+	// allowedNamespaces are the other namespaces lookups may read from, "*" allows all
+	allowedNamespaces []string
+	clusterDomain     string
+}
+
+// NewClusterLookup returns the lookups for a property in namespace reading with reader.
+// allowedNamespaces are the other namespaces lookups may read from, "*" allows all.
+func NewClusterLookup(ctx context.Context, reader client.Reader, namespace string, allowedNamespaces []string, clusterDomain string) *ClusterLookup {
+	return &ClusterLookup{
+		ctx:               ctx,
+		reader:            reader,
+		namespace:         namespace,
+		allowedNamespaces: allowedNamespaces,
+		clusterDomain:     clusterDomain,
+	}
+}
+
+// Funcs returns the lookup functions to be added to a properties template
+func (l *ClusterLookup) Funcs() template.FuncMap {
+	return template.FuncMap{
+		"serviceHost":          l.serviceHost,
+		"servicePort":          l.servicePort,
+		"ingressHosts":         l.ingressHosts,
+		"namespaceLabels":      l.namespaceLabels,
+		"namespaceAnnotations": l.namespaceAnnotations,
+		"configMapValue":       l.configMapValue,
+	}
+}
+
+// objectKey resolves a "name" or "namespace/name" reference, checking the namespace is allowed
+func (l *ClusterLookup) objectKey(ref string) (types.NamespacedName, error) {
+	key := types.NamespacedName{Namespace: l.namespace, Name: ref}
+	if i := strings.Index(ref, "/"); i >= 0 {
+		key.Namespace, key.Name = ref[:i], ref[i+1:]
+	}
+	if key.Namespace == l.namespace {
+		return key, nil
+	}
+	for _, ns := range l.allowedNamespaces {
+		if ns == "*" || ns == key.Namespace {
+			return key, nil
+		}
+	}
+	return key, fmt.Errorf("lookup in namespace %q is not allowed from namespace %q", key.Namespace, l.namespace)
+}
+
+// serviceHost returns the cluster DNS name of a Service
+func (l *ClusterLookup) serviceHost(ref string) (string, error) {
+	key, err := l.objectKey(ref)
+	if err != nil {
+		return "", err
+	}
+	svc := &corev1.Service{}
+	err = l.reader.Get(l.ctx, key, svc)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s.%s.svc.%s", svc.Name, svc.Namespace, l.clusterDomain), nil
+}
+
+// servicePort returns the port number of a named port of a Service
+func (l *ClusterLookup) servicePort(ref, portName string) (int32, error) {
+	key, err := l.objectKey(ref)
+	if err != nil {
+		return 0, err
+	}
+	svc := &corev1.Service{}
+	err = l.reader.Get(l.ctx, key, svc)
+	if err != nil {
+		return 0, err
+	}
+	for _, port := range svc.Spec.Ports {
+		if port.Name == portName {
+			return port.Port, nil
+		}
+	}
+	return 0, fmt.Errorf("service %s has no port named %q", key, portName)
+}
+
+// ingressHosts returns the hosts routed by an Ingress
+func (l *ClusterLookup) ingressHosts(ref string) ([]string, error) {
+	key, err := l.objectKey(ref)
+	if err != nil {
+		return nil, err
+	}
+	ing := &networkingv1.Ingress{}
+	err = l.reader.Get(l.ctx, key, ing)
+	if err != nil {
+		return nil, err
+	}
+	hosts := []string{}
+	for _, rule := range ing.Spec.Rules {
+		if rule.Host != "" {
+			hosts = append(hosts, rule.Host)
+		}
+	}
+	return hosts, nil
+}
+
+// namespaceLabels returns the labels of the property's namespace
+func (l *ClusterLookup) namespaceLabels() (map[string]string, error) {
+	ns := &corev1.Namespace{}
+	err := l.reader.Get(l.ctx, types.NamespacedName{Name: l.namespace}, ns)
+	if err != nil {
+		return nil, err
+	}
+	return ns.Labels, nil
+}
+
+// namespaceAnnotations returns the annotations of the property's namespace
+func (l *ClusterLookup) namespaceAnnotations() (map[string]string, error) {
+	ns := &corev1.Namespace{}
+	err := l.reader.Get(l.ctx, types.NamespacedName{Name: l.namespace}, ns)
+	if err != nil {
+		return nil, err
+	}
+	return ns.Annotations, nil
+}
+
+// configMapValue returns the value of a key in a ConfigMap
+func (l *ClusterLookup) configMapValue(ref, key string) (string, error) {
+	name, err := l.objectKey(ref)
+	if err != nil {
+		return "", err
+	}
+	cm := &corev1.ConfigMap{}
+	err = l.reader.Get(l.ctx, name, cm)
+	if err != nil {
+		return "", err
+	}
+	value, ok := cm.Data[key]
+	if !ok {
+		return "", fmt.Errorf("configmap %s has no key %q", name, key)
+	}
+	return value, nil
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// lookupObjects returns a Service, Ingress and ConfigMap named db in each namespace
+func lookupObjects(namespaces ...string) []client.Object {
+	objs := []client.Object{}
+	for _, ns := range namespaces {
+		meta := metav1.ObjectMeta{Name: "db", Namespace: ns}
+		objs = append(objs,
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: ns, Labels: map[string]string{"team": ns}, Annotations: map[string]string{"owner": ns}}},
+			&corev1.Service{ObjectMeta: meta, Spec: corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "postgres", Port: 5432}}}},
+			&networkingv1.Ingress{ObjectMeta: meta, Spec: networkingv1.IngressSpec{Rules: []networkingv1.IngressRule{{Host: "db." + ns + ".example.com"}, {}}}},
+			&corev1.ConfigMap{ObjectMeta: meta, Data: map[string]string{"url": "postgres://db." + ns}},
+		)
+	}
+	return objs
+}
+
+func newTestLookup(t *testing.T, allowed []string) *ClusterLookup {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := networkingv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(lookupObjects("forest", "shared", "secret")...).Build()
+	return NewClusterLookup(context.Background(), c, "forest", allowed, "cluster.local")
+}
+
+func TestClusterLookupNamespaces(t *testing.T) {
+	tests := []struct {
+		name    string
+		allowed []string
+		ref     string
+		wantNs  string
+		wantErr bool
+	}{
+		{name: "own namespace", ref: "db", wantNs: "forest"},
+		{name: "own namespace by name", ref: "forest/db", wantNs: "forest"},
+		{name: "allowed namespace", allowed: []string{"shared"}, ref: "shared/db", wantNs: "shared"},
+		{name: "every namespace", allowed: []string{"*"}, ref: "secret/db", wantNs: "secret"},
+		{name: "denied namespace", allowed: []string{"shared"}, ref: "secret/db", wantErr: true},
+		{name: "no other namespaces", ref: "shared/db", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newTestLookup(t, tt.allowed)
+
+			host, err := l.serviceHost(tt.ref)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("serviceHost() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if want := "db." + tt.wantNs + ".svc.cluster.local"; !tt.wantErr && host != want {
+				t.Errorf("serviceHost() = %q, want %q", host, want)
+			}
+
+			port, err := l.servicePort(tt.ref, "postgres")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("servicePort() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && port != 5432 {
+				t.Errorf("servicePort() = %d, want 5432", port)
+			}
+
+			hosts, err := l.ingressHosts(tt.ref)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ingressHosts() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if want := []string{"db." + tt.wantNs + ".example.com"}; !tt.wantErr && !reflect.DeepEqual(hosts, want) {
+				t.Errorf("ingressHosts() = %v, want %v", hosts, want)
+			}
+
+			value, err := l.configMapValue(tt.ref, "url")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("configMapValue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if want := "postgres://db." + tt.wantNs; !tt.wantErr && value != want {
+				t.Errorf("configMapValue() = %q, want %q", value, want)
+			}
+		})
+	}
+}
+
+func TestClusterLookupErrors(t *testing.T) {
+	l := newTestLookup(t, []string{"*"})
+	if _, err := l.serviceHost("shared/cache"); err == nil {
+		t.Error("serviceHost() of a missing service error = nil")
+	}
+	if _, err := l.servicePort("db", "http"); err == nil {
+		t.Error("servicePort() of a missing port error = nil")
+	}
+	if _, err := l.configMapValue("db", "password"); err == nil {
+		t.Error("configMapValue() of a missing key error = nil")
+	}
+}
+
+func TestClusterLookupNamespaceMetadata(t *testing.T) {
+	// the namespace lookups only read the namespace of the property
+	l := newTestLookup(t, []string{"*"})
+	labels, err := l.namespaceLabels()
+	if err != nil || !reflect.DeepEqual(labels, map[string]string{"team": "forest"}) {
+		t.Errorf("namespaceLabels() = %v, %v", labels, err)
+	}
+	annotations, err := l.namespaceAnnotations()
+	if err != nil || !reflect.DeepEqual(annotations, map[string]string{"owner": "forest"}) {
+		t.Errorf("namespaceAnnotations() = %v, %v", annotations, err)
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"sort"
+
+	backwoodsv1 "github.com/backwoods-devops/archimedes/api/v1"
+)
+
+const (
+	provenancePlacementData        = "data"
+	provenancePlacementAnnotations = "annotations"
+
+	// ProvenanceAnnotationPrefix prefixes the provenance keys when they are placed in annotations
+	ProvenanceAnnotationPrefix = "archimedes.backwoods-devops.io/"
+)
+
+// provenance returns the keys recording where the template of a property came from
+func provenance(r *backwoodsv1.ArchimedesProperty, src *Template) map[string]string {
+	return map[string]string{
+		"commit":   src.Commit.Hash,
+		"repoUrl":  src.RepoUrl,
+		"revision": src.Revision,
+		"path":     r.Spec.PropertiesPath,
+	}
+}
+
+// AddProvenance records the provenance of a property in data, or returns it as annotations
+// when the property places provenance in annotations. Template keys in data that collide
+// with a provenance key keep their template value and are returned as collisions.
+func AddProvenance(r *backwoodsv1.ArchimedesProperty, src *Template, data map[string]string) (map[string]string, []string) {
+	placement := provenancePlacementData
+	prefix := ""
+	if r.Spec.Provenance != nil {
+		if r.Spec.Provenance.Placement != "" {
+			placement = r.Spec.Provenance.Placement
+		}
+		prefix = r.Spec.Provenance.KeyPrefix
+	}
+
+	annotations := map[string]string{}
+	collisions := []string{}
+	for k, v := range provenance(r, src) {
+		if placement == provenancePlacementAnnotations {
+			annotations[ProvenanceAnnotationPrefix+k] = v
+			continue
+		}
+		if _, ok := data[prefix+k]; ok {
+			collisions = append(collisions, prefix+k)
+			continue
+		}
+		data[prefix+k] = v
+	}
+	sort.Strings(collisions)
+	return annotations, collisions
+}
+
+// ProvenanceKeys returns the keys the provenance of a property is recorded under in its
+// data, none when the property places provenance in annotations
+func ProvenanceKeys(r *backwoodsv1.ArchimedesProperty) []string {
+	if r.Spec.Provenance != nil && r.Spec.Provenance.Placement == provenancePlacementAnnotations {
+		return nil
+	}
+	prefix := ""
+	if r.Spec.Provenance != nil {
+		prefix = r.Spec.Provenance.KeyPrefix
+	}
+	keys := []string{}
+	for k := range provenance(r, &Template{}) {
+		keys = append(keys, prefix+k)
+	}
+	sort.Strings(keys)
+	return keys
+}
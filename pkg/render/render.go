@@ -0,0 +1,223 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package render turns an ArchimedesProperty, its template and its values into the data
+// of a ConfigMap. It is shared by the controller and the archimedes CLIs so every tool
+// renders properties the same way, and may be imported by other tools.
+//
+// A render fetches the template of a property from a Source, merges its values with
+// ResolveValues, executes the template and formats the output with Render, and builds
+// the ConfigMap with ConfigMap. Failures are reported as FetchError, ValuesError,
+// TemplateError, LimitError and FormatError.
+package render
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"html/template"
+	"sort"
+	"strings"
+
+	backwoodsv1 "github.com/backwoods-devops/archimedes/api/v1"
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RenderOptions configure a render
+type RenderOptions struct {
+	// Limits bound the resources of the render
+	Limits Limits
+	// Funcs are added to the functions available to the templates
+	Funcs template.FuncMap
+	// Formats are added to the property types the output can be formatted as
+	Formats map[string]Formatter
+}
+
+// Result is a rendered property
+type Result struct {
+	// Data is the data of the ConfigMap, including provenance placed in data
+	Data map[string]string
+	// Annotations are the provenance annotations of the ConfigMap
+	Annotations map[string]string
+	// Collisions are the template keys colliding with provenance keys
+	Collisions []string
+	// ValuesHash is the hash of the values the template was rendered with
+	ValuesHash string
+	// Output is the output of the template the data was formatted from
+	Output string
+}
+
+// Render merges values into the template of a property and converts the output into
+// ConfigMap data according to the property type of the property. The template reads the
+// Metadata of the render at MetadataKey, which replaces a top-level value of that name.
+// values is not modified.
+func Render(r *backwoodsv1.ArchimedesProperty, src *Template, values map[string]interface{}, opts RenderOptions) (*Result, error) {
+	valuesHash, err := HashValues(values)
+	if err != nil {
+		return nil, err
+	}
+
+	withMetadata := make(map[string]interface{}, len(values)+1)
+	for k, v := range values {
+		withMetadata[k] = v
+	}
+	withMetadata[MetadataKey] = NewMetadata(r, src)
+	output, err := Execute(src, withMetadata, opts)
+	if err != nil {
+		return nil, err
+	}
+	return RenderOutput(r, src, &Result{Output: output, ValuesHash: valuesHash}, opts)
+}
+
+// RenderOutput formats the output of a render as the ConfigMap data of r according to its
+// property type, without executing the template again. It renders each output of a v2
+// property from the render of the first.
+func RenderOutput(r *backwoodsv1.ArchimedesProperty, src *Template, rendered *Result, opts RenderOptions) (*Result, error) {
+	data, err := Format(rendered.Output, FormatOptions{PropertyType: r.Spec.PropertyType, KeyName: r.Spec.KeyName, Formats: opts.Formats})
+	if err != nil {
+		return nil, err
+	}
+	annotations, collisions := AddProvenance(r, src, data)
+	return &Result{
+		Data:        data,
+		Annotations: annotations,
+		Collisions:  collisions,
+		ValuesHash:  rendered.ValuesHash,
+		Output:      rendered.Output,
+	}, nil
+}
+
+// ConfigMap returns the ConfigMap of a rendered property. The labels and annotations of the
+// property are copied, except the annotations the operator keeps on the property under the
+// archimedes.backwoods-devops.io domain and its subdomains, such as the requestedAt
+// annotation, which would change the ConfigMap whenever they are set.
+func ConfigMap(r *backwoodsv1.ArchimedesProperty, result *Result) *corev1.ConfigMap {
+	labels := map[string]string{
+		"created-by": "archimedes-property-operator",
+	}
+	for k, v := range r.ObjectMeta.Labels {
+		labels[k] = v
+	}
+	annotations := map[string]string{}
+	for k, v := range r.ObjectMeta.Annotations {
+		if operatorAnnotation(k) {
+			continue
+		}
+		annotations[k] = v
+	}
+	for k, v := range result.Annotations {
+		annotations[k] = v
+	}
+
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        r.Spec.ConfigMapName,
+			Namespace:   r.Namespace,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Data: result.Data,
+	}
+}
+
+// operatorAnnotation reports whether an annotation key is under the domain of the operator
+// or one of its subdomains
+func operatorAnnotation(key string) bool {
+	i := strings.Index(key, "/")
+	if i < 0 {
+		return false
+	}
+	domain := strings.TrimSuffix(ProvenanceAnnotationPrefix, "/")
+	return key[:i] == domain || strings.HasSuffix(key[:i], "."+domain)
+}
+
+// HashValues returns a hash of the values a template is rendered with
+func HashValues(values map[string]interface{}) (string, error) {
+	out, err := yaml.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(out)), nil
+}
+
+// HashData returns a hash of the content of the data of a ConfigMap
+func HashData(data map[string]string) string {
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	h := sha256.New()
+	for _, k := range keys {
+		fmt.Fprintf(h, "%s=%s\n", k, data[k])
+	}
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+// ParseValues parses yaml values
+func ParseValues(doc string) (map[string]interface{}, error) {
+	values := map[string]interface{}{}
+	err := yaml.Unmarshal([]byte(doc), &values)
+	return values, err
+}
+
+// MergeValues deep merges src into dst. Maps are merged key by key, any other value in
+// src replaces the value in dst.
+func MergeValues(dst map[string]interface{}, src map[string]interface{}) {
+	for k, v := range src {
+		srcMap, srcIsMap := toValuesMap(v)
+		dstMap, dstIsMap := toValuesMap(dst[k])
+		if srcIsMap && dstIsMap {
+			MergeValues(dstMap, srcMap)
+			dst[k] = dstMap
+			continue
+		}
+		dst[k] = v
+	}
+}
+
+// ValueAt returns the value at a path of nested keys in values
+func ValueAt(values map[string]interface{}, path []string) (interface{}, bool) {
+	var value interface{} = values
+	for _, k := range path {
+		m, ok := toValuesMap(value)
+		if !ok {
+			return nil, false
+		}
+		value, ok = m[k]
+		if !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// toValuesMap returns a nested map parsed from yaml keyed by strings
+func toValuesMap(v interface{}) (map[string]interface{}, bool) {
+	switch m := v.(type) {
+	case map[string]interface{}:
+		return m, true
+	case map[interface{}]interface{}:
+		converted := make(map[string]interface{}, len(m))
+		for k, v := range m {
+			converted[fmt.Sprint(k)] = v
+		}
+		return converted, true
+	}
+	return nil, false
+}
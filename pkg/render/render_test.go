@@ -0,0 +1,270 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"errors"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	backwoodsv1 "github.com/backwoods-devops/archimedes/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRender(t *testing.T) {
+	r := &backwoodsv1.ArchimedesProperty{
+		ObjectMeta: metav1.ObjectMeta{Name: "trees-app", Namespace: "default"},
+		Spec: backwoodsv1.ArchimedesPropertySpec{
+			ConfigMapName:  "trees-app",
+			PropertiesPath: "config/properties.tpl",
+			PropertyType:   "kvp",
+		},
+	}
+	src := &Template{
+		RepoUrl:  "https://github.com/backwoods-devops/archimedes.git",
+		Revision: "main",
+		Commit:   Commit{Hash: "abc123"},
+		Content:  []byte("app={{ .Archimedes.Name }}\ndb={{ .env.dbname }}\n"),
+	}
+	values, err := ParseValues("env:\n  dbname: forest-data\nArchimedes: trees\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := Render(r, src, values, RenderOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"app":      "trees-app",
+		"db":       "forest-data",
+		"commit":   "abc123",
+		"repoUrl":  "https://github.com/backwoods-devops/archimedes.git",
+		"revision": "main",
+		"path":     "config/properties.tpl",
+	}
+	if !reflect.DeepEqual(result.Data, want) {
+		t.Errorf("data = %v, want %v", result.Data, want)
+	}
+	if values[MetadataKey] != "trees" || len(values) != 2 {
+		t.Errorf("values were modified: %v", values)
+	}
+
+	configmap := ConfigMap(r, result)
+	if configmap.Name != "trees-app" || configmap.Labels["created-by"] != "archimedes-property-operator" {
+		t.Errorf("unexpected configmap metadata %v", configmap.ObjectMeta)
+	}
+
+	// another output formats the same render as its own property type
+	output := r.DeepCopy()
+	output.Spec.ConfigMapName = "trees-app-file"
+	output.Spec.PropertyType = "key"
+	output.Spec.KeyName = "app.properties"
+	output.Spec.Provenance = &backwoodsv1.Provenance{Placement: "annotations"}
+	formatted, err := RenderOutput(output, src, result, RenderOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]string{"app.properties": "app=trees-app\ndb=forest-data"}; !reflect.DeepEqual(formatted.Data, want) {
+		t.Errorf("output data = %v, want %v", formatted.Data, want)
+	}
+	if formatted.Annotations[ProvenanceAnnotationPrefix+"commit"] != "abc123" || formatted.ValuesHash != result.ValuesHash {
+		t.Errorf("output annotations = %v, valuesHash = %s", formatted.Annotations, formatted.ValuesHash)
+	}
+}
+
+func TestConfigMapAnnotations(t *testing.T) {
+	r := &backwoodsv1.ArchimedesProperty{
+		ObjectMeta: metav1.ObjectMeta{Name: "trees-app", Namespace: "default", Annotations: map[string]string{
+			"team":                               "trees",
+			backwoodsv1.OutputsAnnotation:        `[{"configMapName":"birch"}]`,
+			ProvenanceAnnotationPrefix + "owner": "forest",
+			"reconcile.archimedes.backwoods-devops.io/requestedAt": "2021-06-01T10:00:00Z",
+			"archimedes.backwoods-devops.io.example.com/team":      "birch",
+		}},
+		Spec: backwoodsv1.ArchimedesPropertySpec{ConfigMapName: "trees-app"},
+	}
+	result := &Result{Annotations: map[string]string{ProvenanceAnnotationPrefix + "commit": "abc123"}}
+	want := map[string]string{
+		"team": "trees",
+		"archimedes.backwoods-devops.io.example.com/team": "birch",
+		ProvenanceAnnotationPrefix + "commit":             "abc123",
+	}
+	if got := ConfigMap(r, result).Annotations; !reflect.DeepEqual(got, want) {
+		t.Errorf("annotations = %v, want %v", got, want)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	upper := func(output string, opts FormatOptions) (map[string]string, error) {
+		return map[string]string{opts.KeyName: strings.ToUpper(output)}, nil
+	}
+	tests := []struct {
+		name         string
+		output       string
+		propertyType string
+		keyName      string
+		want         map[string]string
+		wantErr      bool
+	}{
+		{name: "kvp", output: "a=1\nb=2\n", propertyType: "kvp", want: map[string]string{"a": "1", "b": "2"}},
+		{name: "kvp without value", output: "a=1\nb\n", propertyType: "kvp", wantErr: true},
+		{name: "kvp value with equals", output: "db.url=jdbc:postgresql://db/forest?ssl=true&user=trees\nb==\n", propertyType: "kvp",
+			want: map[string]string{"db.url": "jdbc:postgresql://db/forest?ssl=true&user=trees", "b": "="}},
+		{name: "kvp line longer than 64KB", output: "a=1\ncert=" + strings.Repeat("x", 100000) + "\nb=2\n", propertyType: "kvp",
+			want: map[string]string{"a": "1", "cert": strings.Repeat("x", 100000), "b": "2"}},
+		{name: "key", output: "a=1\nb=2\n", propertyType: "key", keyName: "app.properties", want: map[string]string{"app.properties": "a=1\nb=2"}},
+		{name: "key without keyName", output: "a=1\n", propertyType: "key", wantErr: true},
+		{name: "invalid type", output: "a=1\n", propertyType: "file", wantErr: true},
+		{name: "custom format", output: "a=1", propertyType: "upper", keyName: "app", want: map[string]string{"app": "A=1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Format(tt.output, FormatOptions{
+				PropertyType: tt.propertyType,
+				KeyName:      tt.keyName,
+				Formats:      map[string]Formatter{"upper": upper},
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			var formatErr *FormatError
+			if tt.wantErr && !errors.As(err, &formatErr) {
+				t.Errorf("err = %T, want a FormatError", err)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("data = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderErrors(t *testing.T) {
+	r := &backwoodsv1.ArchimedesProperty{Spec: backwoodsv1.ArchimedesPropertySpec{PropertyType: "kvp"}}
+	tests := []struct {
+		name     string
+		template string
+		opts     RenderOptions
+		check    func(error) bool
+	}{
+		{
+			name:     "parse",
+			template: "a={{ .a ",
+			check: func(err error) bool {
+				var templateErr *TemplateError
+				return errors.As(err, &templateErr)
+			},
+		},
+		{
+			name:     "execute",
+			template: "a={{ template \"missing\" }}",
+			check: func(err error) bool {
+				var templateErr *TemplateError
+				return errors.As(err, &templateErr)
+			},
+		},
+		{
+			name:     "output limit",
+			template: "a={{ .a }}{{ .a }}",
+			opts:     RenderOptions{Limits: Limits{MaxOutputBytes: 4}},
+			check:    IsLimitError,
+		},
+		{
+			name:     "timeout",
+			template: "{{ range .items }}{{ range $.items }}{{ range $.items }}{{ end }}{{ end }}{{ end }}a=1",
+			opts:     RenderOptions{Limits: Limits{Timeout: 50 * time.Millisecond}},
+			check:    IsLimitError,
+		},
+		{
+			name:     "include depth",
+			template: `{{ define "tree" }}{{ include "tree" . }}{{ end }}a={{ include "tree" . }}`,
+			opts:     RenderOptions{Limits: Limits{MaxIncludeDepth: 4}},
+			check:    IsLimitError,
+		},
+		{
+			name:     "template depth",
+			template: `{{ define "tree" }}{{ template "tree" . }}{{ end }}a={{ template "tree" . }}`,
+			opts:     RenderOptions{Limits: Limits{MaxIncludeDepth: 4}},
+			check:    IsLimitError,
+		},
+	}
+	values := map[string]interface{}{"a": "trees", "items": make([]interface{}, 1000)}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Render(r, &Template{Content: []byte(tt.template)}, values, tt.opts)
+			if err == nil || !tt.check(err) {
+				t.Errorf("err = %#v", err)
+			}
+		})
+	}
+}
+
+func TestExecuteLimits(t *testing.T) {
+	src := &Template{
+		Content:  []byte(`a={{ include "branch" . }}`),
+		Partials: map[string][]byte{"partials.tpl": []byte(`{{ define "branch" }}{{ template "leaf" . }}{{ end }}{{ define "leaf" }}{{ .a }}{{ end }}`)},
+	}
+	got, err := Execute(src, map[string]interface{}{"a": "trees"}, RenderOptions{Limits: Limits{MaxIncludeDepth: 2}})
+	if err != nil || got != "a=trees" {
+		t.Fatalf("Execute() = %q, %v", got, err)
+	}
+	_, err = Execute(src, map[string]interface{}{"a": "trees"}, RenderOptions{Limits: Limits{MaxIncludeDepth: 1}})
+	if !IsLimitError(err) {
+		t.Errorf("Execute() with a depth of 1 error = %v, want a LimitError", err)
+	}
+
+	// a render writing nothing stops running once it times out
+	before := runtime.NumGoroutine()
+	runaway := &Template{Content: []byte("{{ range .items }}{{ range $.items }}{{ range $.items }}{{ end }}{{ end }}{{ end }}")}
+	_, err = Execute(runaway, map[string]interface{}{"items": make([]interface{}, 1000)}, RenderOptions{Limits: Limits{Timeout: 20 * time.Millisecond}})
+	if !IsLimitError(err) {
+		t.Fatalf("Execute() error = %v, want a LimitError", err)
+	}
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if n := runtime.NumGoroutine(); n > before {
+		t.Errorf("%d goroutines are running after the timeout, want %d", n, before)
+	}
+}
+
+func TestMergeValues(t *testing.T) {
+	dst, err := ParseValues("env:\n  name: staging\n  dbport: 5432\nteam: trees\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	src, err := ParseValues("env:\n  name: prod\nteam: [trees, forest]\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	MergeValues(dst, src)
+
+	env, ok := toValuesMap(dst["env"])
+	if !ok {
+		t.Fatalf("env = %v, want a map", dst["env"])
+	}
+	if env["name"] != "prod" || env["dbport"] != 5432 {
+		t.Errorf("env = %v, want name prod and dbport 5432", env)
+	}
+	if !reflect.DeepEqual(dst["team"], []interface{}{"trees", "forest"}) {
+		t.Errorf("team = %v, want the list of src", dst["team"])
+	}
+}
@@ -0,0 +1,274 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	backwoodsv1 "github.com/backwoods-devops/archimedes/api/v1"
+	git "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Source fetches the template of a property and the templates it includes
+type Source interface {
+	Fetch(ctx context.Context, r *backwoodsv1.ArchimedesProperty) (*Template, error)
+}
+
+// SourceOptions configure the Source SourceFor picks for a property
+type SourceOptions struct {
+	// Git configures the clones of repos and template libraries
+	Git GitOptions
+	// HTTP fetches the templates of http properties, an HTTPSource with Git when nil
+	HTTP Source
+	// HTTPURL returns an error when an http property may not download its url, every url is
+	// downloaded when nil
+	HTTPURL func(url string) error
+	// Reader reads the templates of configMap properties and the ArchimedesSources of
+	// sourceRef properties
+	Reader client.Reader
+	// LocalPath returns an error when a path property may not read its localPath, every
+	// localPath is read when nil
+	LocalPath func(path string) error
+	// SourceRef returns the source of a git property with a sourceRef instead of cloning the
+	// repo of its ArchimedesSource when set
+	SourceRef func(ctx context.Context, r *backwoodsv1.ArchimedesProperty) (Source, error)
+	// Repo returns the source of a git property instead of cloning its repo when it returns
+	// one, such as a local checkout of the repo
+	Repo func(r *backwoodsv1.ArchimedesProperty) Source
+}
+
+// SourceFor returns the Source the template of a property is fetched from by its source
+// type. The sourceRef of a git property is resolved in place to the repoUrl, revision and
+// caPath of its ArchimedesSource unless opts.SourceRef is set.
+func SourceFor(ctx context.Context, r *backwoodsv1.ArchimedesProperty, opts SourceOptions) (Source, error) {
+	switch r.Spec.SourceType {
+	case backwoodsv1.SourceTypeHTTP:
+		if opts.HTTPURL != nil && r.Spec.HTTP != nil {
+			if err := opts.HTTPURL(r.Spec.HTTP.Url); err != nil {
+				return nil, err
+			}
+		}
+		if opts.HTTP == nil {
+			return NewHTTPSource(HTTPOptions{Git: opts.Git}), nil
+		}
+		return opts.HTTP, nil
+	case backwoodsv1.SourceTypeConfigMap:
+		if opts.Reader == nil {
+			return nil, &FetchError{Err: fmt.Errorf("the template ConfigMap of the property cannot be read without a client")}
+		}
+		return NewConfigMapSource(ConfigMapOptions{Reader: opts.Reader, Git: opts.Git}), nil
+	case backwoodsv1.SourceTypePath:
+		if opts.LocalPath != nil {
+			if err := opts.LocalPath(r.Spec.LocalPath); err != nil {
+				return nil, err
+			}
+		}
+		return NewLocalSource(LocalOptions{Dir: r.Spec.LocalPath, Mounted: true, Git: opts.Git}), nil
+	}
+
+	if r.Spec.SourceRef != nil {
+		if opts.SourceRef != nil {
+			return opts.SourceRef(ctx, r)
+		}
+		err := ResolveSourceRef(ctx, opts.Reader, r)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if opts.Repo != nil {
+		if source := opts.Repo(r); source != nil {
+			return source, nil
+		}
+	}
+	return NewGitSource(opts.Git), nil
+}
+
+// ResolveSourceRef replaces the sourceRef of a property with the repoUrl, revision and
+// caPath of its ArchimedesSource
+func ResolveSourceRef(ctx context.Context, c client.Reader, r *backwoodsv1.ArchimedesProperty) error {
+	ref := r.Spec.SourceRef
+	if ref == nil {
+		return nil
+	}
+	if c == nil {
+		return &FetchError{RepoUrl: "ArchimedesSource/" + ref.Name, Err: fmt.Errorf("the ArchimedesSource cannot be read without a client")}
+	}
+	source := &backwoodsv1.ArchimedesSource{}
+	err := c.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: r.Namespace}, source)
+	if err != nil {
+		return &FetchError{RepoUrl: "ArchimedesSource/" + ref.Name, Err: err}
+	}
+	r.Spec.SourceRef = nil
+	r.Spec.RepoUrl = source.Spec.RepoUrl
+	r.Spec.Revision = source.Spec.Revision
+	r.Spec.CAPath = source.Spec.CAPath
+	if r.Spec.Revision == "" {
+		r.Spec.Revision = backwoodsv1.DefaultRevision
+	}
+	return nil
+}
+
+// Template holds the files fetched from a source needed to render a property
+type Template struct {
+	// RepoUrl and Revision locate the repo the template was fetched from
+	RepoUrl  string
+	Revision string
+	// Commit is the commit the template was fetched from
+	Commit Commit
+	// Content is the properties template
+	Content []byte
+	// Partials are the included templates keyed by their path in the repo
+	Partials map[string][]byte
+}
+
+// Commit describes the commit a template was fetched from
+type Commit struct {
+	Hash      string
+	Author    string
+	Message   string
+	Timestamp time.Time
+	Tag       string
+}
+
+// GitOptions configure the clones of the repo and library of a property
+type GitOptions struct {
+	// Auth are the credentials of the repos, EnvAuth when nil unless Anonymous is set
+	Auth *http.BasicAuth
+	// Anonymous clones the repos without credentials when Auth is nil
+	Anonymous bool
+	// TempDir is the directory repos are cloned in, /tmp when empty
+	TempDir string
+}
+
+func (o GitOptions) auth() *http.BasicAuth {
+	if o.Auth == nil && !o.Anonymous {
+		return EnvAuth()
+	}
+	return o.Auth
+}
+
+func (o GitOptions) tempDir() string {
+	if o.TempDir == "" {
+		return "/tmp"
+	}
+	return o.TempDir
+}
+
+// GitSource fetches templates by cloning the repoUrl and revision of a property
+type GitSource struct {
+	Options GitOptions
+}
+
+// NewGitSource returns a Source cloning the repo of every property it fetches
+func NewGitSource(opts GitOptions) *GitSource {
+	return &GitSource{Options: opts}
+}
+
+// Fetch clones the repo of a property and reads its template from it
+func (s *GitSource) Fetch(ctx context.Context, r *backwoodsv1.ArchimedesProperty) (*Template, error) {
+	dir, err := ioutil.TempDir(s.Options.tempDir(), "archimedes_")
+	if err != nil {
+		return nil, &FetchError{RepoUrl: r.Spec.RepoUrl, Revision: r.Spec.Revision, Err: err}
+	}
+	defer os.RemoveAll(dir)
+
+	certs, err := ReadCA(r.Spec.CAPath)
+	if err != nil {
+		return nil, &FetchError{RepoUrl: r.Spec.RepoUrl, Revision: r.Spec.Revision, Err: err}
+	}
+	commit, err := CloneRepo(dir, r.Spec.RepoUrl, r.Spec.Revision, s.Options.auth(), certs)
+	if err != nil {
+		return nil, &FetchError{RepoUrl: r.Spec.RepoUrl, Revision: r.Spec.Revision, Err: err}
+	}
+	return ReadTemplate(dir, r.Spec.RepoUrl, r.Spec.Revision, *commit, r, s.Options)
+}
+
+// LocalOptions configure a LocalSource
+type LocalOptions struct {
+	// Dir is a checkout of the repo of the properties, their paths are relative to it
+	Dir string
+	// Commit is recorded as the commit of the template when Dir is not in a git repo
+	Commit Commit
+	// Git configures the clones of template libraries
+	Git GitOptions
+	// Mounted marks Dir as a volume mounted in the operator rather than a checkout of the
+	// repo of the properties. Dir is recorded as the repoUrl of templates, and a hash of the
+	// template files as their commit when Dir is not in a git repo.
+	Mounted bool
+}
+
+// LocalSource reads templates from a local checkout, such as a mounted volume or the
+// working copy of a CLI
+type LocalSource struct {
+	Options LocalOptions
+}
+
+// NewLocalSource returns a Source reading the templates of properties from a directory
+func NewLocalSource(opts LocalOptions) *LocalSource {
+	return &LocalSource{Options: opts}
+}
+
+// Fetch reads the template of a property from the directory of the source. When the
+// directory is inside a git repo its HEAD commit is recorded as the commit of the template.
+func (s *LocalSource) Fetch(ctx context.Context, r *backwoodsv1.ArchimedesProperty) (*Template, error) {
+	commit := s.Options.Commit
+	repo, err := git.PlainOpenWithOptions(s.Options.Dir, &git.PlainOpenOptions{DetectDotGit: true})
+	if err == nil {
+		if head, err := repo.Head(); err == nil {
+			if info, err := DescribeCommit(repo, head.Hash()); err == nil {
+				commit = *info
+			}
+		}
+	}
+	repoUrl, revision := r.Spec.RepoUrl, r.Spec.Revision
+	if s.Options.Mounted {
+		repoUrl, revision = "file://"+filepath.ToSlash(s.Options.Dir), ""
+	}
+	src, err := ReadTemplate(s.Options.Dir, repoUrl, revision, commit, r, s.Options.Git)
+	if err == nil && s.Options.Mounted && src.Commit.Hash == "" {
+		src.Commit.Hash = contentHash(src)
+	}
+	return src, err
+}
+
+// contentHash returns a hash of the properties template and its partials, recorded as the
+// commit of templates that are not fetched from a git repo
+func contentHash(src *Template) string {
+	names := make([]string, 0, len(src.Partials))
+	for name := range src.Partials {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	h := sha256.New()
+	h.Write(src.Content)
+	for _, name := range names {
+		fmt.Fprintf(h, "\x00%s\x00", name)
+		h.Write(src.Partials[name])
+	}
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
@@ -0,0 +1,482 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	backwoodsv1 "github.com/backwoods-devops/archimedes/api/v1"
+	"github.com/backwoods-devops/archimedes/internal/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestLocalSource(t *testing.T) {
+	files := map[string]string{
+		"config/properties.tpl": "app={{ include \"config/_helpers.tpl\" . }}\n",
+		"config/_helpers.tpl":   "trees",
+	}
+	dir := testutil.WriteFiles(t, files)
+
+	var source Source = NewLocalSource(LocalOptions{Dir: dir, Commit: Commit{Hash: "abc123"}})
+	r := &backwoodsv1.ArchimedesProperty{Spec: backwoodsv1.ArchimedesPropertySpec{
+		RepoUrl:        "https://github.com/backwoods-devops/archimedes.git",
+		Revision:       "main",
+		PropertiesPath: "config/properties.tpl",
+		IncludePaths:   []string{"config/_*.tpl"},
+	}}
+	src, err := source.Fetch(context.Background(), r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(src.Content) != files["config/properties.tpl"] || string(src.Partials["config/_helpers.tpl"]) != "trees" {
+		t.Errorf("template = %q, partials = %v", src.Content, src.Partials)
+	}
+	if src.Commit.Hash != "abc123" || src.RepoUrl != r.Spec.RepoUrl {
+		t.Errorf("commit = %v, repoUrl = %s", src.Commit, src.RepoUrl)
+	}
+
+	r.Spec.PropertiesPath = "config/missing.tpl"
+	_, err = source.Fetch(context.Background(), r)
+	var fetchErr *FetchError
+	if !errors.As(err, &fetchErr) || fetchErr.Path != "config/missing.tpl" {
+		t.Errorf("err = %#v, want a FetchError for config/missing.tpl", err)
+	}
+}
+
+func TestReadTemplateSymlinks(t *testing.T) {
+	dir := testutil.WriteFiles(t, map[string]string{
+		"templates/trees/..data/properties.tpl": "env=staging\n",
+		"templates/trees/..data/_helpers.tpl":   "trees",
+		"secrets/token":                         "secret",
+	})
+	root := filepath.Join(dir, "templates", "trees")
+	links := map[string]string{
+		// ConfigMap volumes link their files to a data directory inside the mount
+		"properties.tpl": filepath.Join("..data", "properties.tpl"),
+		"_helpers.tpl":   filepath.Join("..data", "_helpers.tpl"),
+		"token.tpl":      filepath.Join("..", "..", "secrets", "token"),
+		"absolute.tpl":   filepath.Join(dir, "secrets", "token"),
+	}
+	for name, target := range links {
+		if err := os.Symlink(target, filepath.Join(root, name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	mount := filepath.Join(dir, "mount")
+	if err := os.Symlink(root, mount); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		dir          string
+		path         string
+		includePaths []string
+		wantErr      bool
+	}{
+		{name: "link inside", dir: root, path: "properties.tpl", includePaths: []string{"_helpers.tpl"}},
+		{name: "linked directory", dir: mount, path: "properties.tpl", includePaths: []string{"_helpers.tpl"}},
+		{name: "relative link outside", dir: root, path: "token.tpl", wantErr: true},
+		{name: "absolute link outside", dir: root, path: "absolute.tpl", wantErr: true},
+		{name: "include path linked outside", dir: root, path: "properties.tpl", includePaths: []string{"*.tpl"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &backwoodsv1.ArchimedesProperty{Spec: backwoodsv1.ArchimedesPropertySpec{PropertiesPath: tt.path, IncludePaths: tt.includePaths}}
+			src, err := ReadTemplate(tt.dir, "file:///templates/trees", "", Commit{}, r, GitOptions{})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadTemplate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if strings.Contains(string(src.Content), "secret") || strings.Contains(fmt.Sprint(src.Partials), "secret") {
+					t.Errorf("ReadTemplate() read the linked file: %q, %q", src.Content, src.Partials)
+				}
+				return
+			}
+			if string(src.Content) != "env=staging\n" || string(src.Partials["_helpers.tpl"]) != "trees" {
+				t.Errorf("ReadTemplate() = %q, %q", src.Content, src.Partials)
+			}
+		})
+	}
+}
+
+func TestReadTemplateOutsideDir(t *testing.T) {
+	dir := testutil.WriteFiles(t, map[string]string{
+		"templates/trees/properties.tpl": "env=staging\n",
+		"secrets/token":                  "secret",
+	})
+	tests := []struct {
+		name    string
+		path    string
+		wantErr bool
+	}{
+		{name: "inside", path: "properties.tpl"},
+		{name: "inside with dots", path: "config/../properties.tpl"},
+		{name: "absolute is joined to the directory", path: "/properties.tpl"},
+		{name: "outside", path: "../../secrets/token", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &backwoodsv1.ArchimedesProperty{Spec: backwoodsv1.ArchimedesPropertySpec{PropertiesPath: tt.path}}
+			src, err := ReadTemplate(filepath.Join(dir, "templates", "trees"), "file:///templates/trees", "", Commit{}, r, GitOptions{})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadTemplate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var fetchErr *FetchError
+			if tt.wantErr && (!errors.As(err, &fetchErr) || len(src.Content) > 0) {
+				t.Errorf("ReadTemplate() = %q, %v, want a FetchError", src.Content, err)
+			}
+			if !tt.wantErr && string(src.Content) != "env=staging\n" {
+				t.Errorf("content = %q", src.Content)
+			}
+		})
+	}
+}
+
+func TestLocalSourceMounted(t *testing.T) {
+	dir := testutil.WriteFiles(t, map[string]string{"properties.tpl": "env=staging\n"})
+
+	source := NewLocalSource(LocalOptions{Dir: dir, Mounted: true})
+	r := &backwoodsv1.ArchimedesProperty{Spec: backwoodsv1.ArchimedesPropertySpec{
+		SourceType:     backwoodsv1.SourceTypePath,
+		LocalPath:      dir,
+		PropertiesPath: "properties.tpl",
+	}}
+	src, err := source.Fetch(context.Background(), r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if src.RepoUrl != "file://"+filepath.ToSlash(dir) || src.Commit.Hash == "" {
+		t.Errorf("repoUrl = %s, commit = %v", src.RepoUrl, src.Commit)
+	}
+
+	err = ioutil.WriteFile(filepath.Join(dir, "properties.tpl"), []byte("env=prod\n"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	changed, err := source.Fetch(context.Background(), r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changed.Commit.Hash == src.Commit.Hash {
+		t.Errorf("commit %s did not change with the template", changed.Commit.Hash)
+	}
+}
+
+func TestHTTPSource(t *testing.T) {
+	template := "env={{ .env }}\n"
+	downloads := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/properties.tpl" {
+			http.NotFound(w, req)
+			return
+		}
+		etag := fmt.Sprintf("%q", fmt.Sprint(len(template)))
+		if req.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		downloads++
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Last-Modified", "Wed, 21 Oct 2015 07:28:00 GMT")
+		fmt.Fprint(w, template)
+	}))
+	defer server.Close()
+
+	var source Source = NewHTTPSource(HTTPOptions{})
+	r := &backwoodsv1.ArchimedesProperty{Spec: backwoodsv1.ArchimedesPropertySpec{
+		SourceType: backwoodsv1.SourceTypeHTTP,
+		HTTP:       &backwoodsv1.HTTPSource{Url: server.URL + "/properties.tpl"},
+	}}
+	src, err := source.Fetch(context.Background(), r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(src.Content) != template || src.RepoUrl != r.Spec.HTTP.Url || src.Commit.Timestamp.Year() != 2015 {
+		t.Errorf("template = %q, repoUrl = %s, commit = %v", src.Content, src.RepoUrl, src.Commit)
+	}
+
+	cached, err := source.Fetch(context.Background(), r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if downloads != 1 || string(cached.Content) != template || cached.Commit.Hash != src.Commit.Hash {
+		t.Errorf("downloads = %d, template = %q, commit = %v", downloads, cached.Content, cached.Commit)
+	}
+
+	template = "env={{ .env }}\nregion={{ .region }}\n"
+	changed, err := source.Fetch(context.Background(), r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if downloads != 2 || string(changed.Content) != template || changed.Commit.Hash == src.Commit.Hash {
+		t.Errorf("downloads = %d, template = %q, commit = %v", downloads, changed.Content, changed.Commit)
+	}
+
+	r.Spec.HTTP.Url = server.URL + "/missing.tpl"
+	_, err = source.Fetch(context.Background(), r)
+	var fetchErr *FetchError
+	if !errors.As(err, &fetchErr) || !strings.Contains(err.Error(), "404") {
+		t.Errorf("err = %v, want a FetchError for the 404", err)
+	}
+}
+
+func TestHTTPSourceURLPrefixes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/teams/trees.tpl", "/private/token":
+			fmt.Fprint(w, "env={{ .env }}\n")
+		case "/teams/redirect.tpl":
+			http.Redirect(w, req, "/private/token", http.StatusFound)
+		case "/teams/slow.tpl":
+			time.Sleep(200 * time.Millisecond)
+			fmt.Fprint(w, "env={{ .env }}\n")
+		default:
+			http.NotFound(w, req)
+		}
+	}))
+	defer server.Close()
+
+	source := NewHTTPSource(HTTPOptions{URLPrefixes: []string{server.URL + "/teams"}, Timeout: 50 * time.Millisecond})
+	tests := []struct {
+		path    string
+		wantErr bool
+	}{
+		{path: "/teams/trees.tpl"},
+		{path: "/private/token", wantErr: true},
+		{path: "/teams/redirect.tpl", wantErr: true},
+		{path: "/teams/slow.tpl", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			r := &backwoodsv1.ArchimedesProperty{Spec: backwoodsv1.ArchimedesPropertySpec{
+				SourceType: backwoodsv1.SourceTypeHTTP,
+				HTTP:       &backwoodsv1.HTTPSource{Url: server.URL + tt.path},
+			}}
+			_, err := source.Fetch(context.Background(), r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Fetch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestURLHasPrefix(t *testing.T) {
+	prefixes := []string{"https://templates.example.com/teams", "http://mirror.example.com/"}
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{url: "https://templates.example.com/teams/trees.tpl", want: true},
+		{url: "https://TEMPLATES.example.com/teams/trees.tpl", want: true},
+		{url: "https://templates.example.com/teams", want: true},
+		{url: "http://mirror.example.com/trees.tpl", want: true},
+		{url: "https://templates.example.com/teams-old/trees.tpl"},
+		{url: "https://templates.example.com/private/token"},
+		{url: "http://templates.example.com/teams/trees.tpl"},
+		{url: "https://templates.example.com.evil.io/teams/trees.tpl"},
+		{url: "https://templates.example.com@evil.io/teams/trees.tpl"},
+		{url: "https://templates.example.com:8443/teams/trees.tpl"},
+		{url: "http://169.254.169.254/latest/meta-data"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			if got := URLHasPrefix(tt.url, prefixes); got != tt.want {
+				t.Errorf("URLHasPrefix() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfigMapSource(t *testing.T) {
+	c := fake.NewClientBuilder().WithObjects(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "trees-templates", Namespace: "forest"},
+		Data: map[string]string{
+			"properties.tpl": "app={{ include \"_helpers.tpl\" . }}\n",
+			"_helpers.tpl":   "trees",
+			"README.md":      "templates of the trees app",
+		},
+	}).Build()
+
+	var source Source = NewConfigMapSource(ConfigMapOptions{Reader: c})
+	r := &backwoodsv1.ArchimedesProperty{
+		ObjectMeta: metav1.ObjectMeta{Name: "trees", Namespace: "forest"},
+		Spec: backwoodsv1.ArchimedesPropertySpec{
+			SourceType:        backwoodsv1.SourceTypeConfigMap,
+			TemplateConfigMap: &backwoodsv1.ConfigMapSource{Name: "trees-templates"},
+			PropertiesPath:    "properties.tpl",
+			IncludePaths:      []string{"_*.tpl"},
+		},
+	}
+	src, err := source.Fetch(context.Background(), r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(src.Partials["_helpers.tpl"]) != "trees" || len(src.Partials) != 1 {
+		t.Errorf("partials = %v", src.Partials)
+	}
+	if src.RepoUrl != "ConfigMap/trees-templates" || src.Commit.Hash == "" {
+		t.Errorf("repoUrl = %s, commit = %v", src.RepoUrl, src.Commit)
+	}
+
+	r.Spec.PropertiesPath = "missing.tpl"
+	_, err = source.Fetch(context.Background(), r)
+	var fetchErr *FetchError
+	if !errors.As(err, &fetchErr) || fetchErr.Path != "missing.tpl" {
+		t.Errorf("err = %v, want a FetchError for missing.tpl", err)
+	}
+}
+
+func TestUserGitOptions(t *testing.T) {
+	os.Setenv("ARCHIMEDES_TEST_TOKEN", "secret")
+	defer os.Unsetenv("ARCHIMEDES_TEST_TOKEN")
+	tests := []struct {
+		name         string
+		user         string
+		passwordEnv  string
+		wantUser     string
+		wantPassword string
+		wantErr      bool
+	}{
+		{name: "anonymous"},
+		{name: "user", user: "ci", wantUser: "ci"},
+		{name: "user and password", user: "ci", passwordEnv: "ARCHIMEDES_TEST_TOKEN", wantUser: "ci", wantPassword: "secret"},
+		{name: "unset password", user: "ci", passwordEnv: "ARCHIMEDES_TEST_UNSET", wantErr: true},
+		{name: "password without user", passwordEnv: "ARCHIMEDES_TEST_TOKEN", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := UserGitOptions(tt.user, tt.passwordEnv)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UserGitOptions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			auth := opts.auth()
+			if tt.user == "" {
+				if auth != nil {
+					t.Errorf("auth() = %+v, want anonymous", auth)
+				}
+				return
+			}
+			if auth == nil || auth.Username != tt.wantUser || auth.Password != tt.wantPassword {
+				t.Errorf("auth() = %+v, want %s:%s", auth, tt.wantUser, tt.wantPassword)
+			}
+		})
+	}
+}
+
+func TestSourceFor(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := backwoodsv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&backwoodsv1.ArchimedesSource{
+		ObjectMeta: metav1.ObjectMeta{Name: "forest", Namespace: "default"},
+		Spec:       backwoodsv1.ArchimedesSourceSpec{RepoUrl: "https://github.com/backwoods-devops/forest.git", CAPath: "/etc/ca.pem"},
+	}).Build()
+	local := NewLocalSource(LocalOptions{Dir: "/src"})
+	tests := []struct {
+		name         string
+		spec         backwoodsv1.ArchimedesPropertySpec
+		opts         SourceOptions
+		want         string
+		wantRepo     string
+		wantErr      bool
+		wantFetchErr bool
+	}{
+		{name: "git", spec: backwoodsv1.ArchimedesPropertySpec{SourceType: backwoodsv1.SourceTypeGit, RepoUrl: "https://github.com/backwoods-devops/trees.git", Revision: "main"}, want: "*render.GitSource", wantRepo: "https://github.com/backwoods-devops/trees.git"},
+		{name: "http", spec: backwoodsv1.ArchimedesPropertySpec{SourceType: backwoodsv1.SourceTypeHTTP}, want: "*render.HTTPSource"},
+		{name: "configMap", spec: backwoodsv1.ArchimedesPropertySpec{SourceType: backwoodsv1.SourceTypeConfigMap}, opts: SourceOptions{Reader: c}, want: "*render.ConfigMapSource"},
+		{name: "configMap without a client", spec: backwoodsv1.ArchimedesPropertySpec{SourceType: backwoodsv1.SourceTypeConfigMap}, wantErr: true},
+		{name: "path", spec: backwoodsv1.ArchimedesPropertySpec{SourceType: backwoodsv1.SourceTypePath, LocalPath: "/templates"}, want: "*render.LocalSource"},
+		{
+			name:    "http not allowed",
+			spec:    backwoodsv1.ArchimedesPropertySpec{SourceType: backwoodsv1.SourceTypeHTTP, HTTP: &backwoodsv1.HTTPSource{Url: "http://169.254.169.254/latest"}},
+			opts:    SourceOptions{HTTPURL: func(string) error { return errors.New("not allowed") }},
+			wantErr: true,
+		},
+		{
+			name:    "path not allowed",
+			spec:    backwoodsv1.ArchimedesPropertySpec{SourceType: backwoodsv1.SourceTypePath, LocalPath: "/templates"},
+			opts:    SourceOptions{LocalPath: func(string) error { return errors.New("not allowed") }},
+			wantErr: true,
+		},
+		{
+			name:     "sourceRef",
+			spec:     backwoodsv1.ArchimedesPropertySpec{SourceType: backwoodsv1.SourceTypeGit, SourceRef: &backwoodsv1.SourceReference{Name: "forest"}},
+			opts:     SourceOptions{Reader: c},
+			want:     "*render.GitSource",
+			wantRepo: "https://github.com/backwoods-devops/forest.git",
+		},
+		{
+			name:         "missing sourceRef",
+			spec:         backwoodsv1.ArchimedesPropertySpec{SourceType: backwoodsv1.SourceTypeGit, SourceRef: &backwoodsv1.SourceReference{Name: "meadow"}},
+			opts:         SourceOptions{Reader: c},
+			wantErr:      true,
+			wantFetchErr: true,
+		},
+		{
+			name: "sourceRef hook",
+			spec: backwoodsv1.ArchimedesPropertySpec{SourceType: backwoodsv1.SourceTypeGit, SourceRef: &backwoodsv1.SourceReference{Name: "meadow"}},
+			opts: SourceOptions{SourceRef: func(context.Context, *backwoodsv1.ArchimedesProperty) (Source, error) { return local, nil }},
+			want: "*render.LocalSource",
+		},
+		{
+			name: "repo hook",
+			spec: backwoodsv1.ArchimedesPropertySpec{SourceType: backwoodsv1.SourceTypeGit, RepoUrl: "https://github.com/backwoods-devops/trees.git"},
+			opts: SourceOptions{Repo: func(*backwoodsv1.ArchimedesProperty) Source { return local }},
+			want: "*render.LocalSource",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &backwoodsv1.ArchimedesProperty{ObjectMeta: metav1.ObjectMeta{Name: "trees", Namespace: "default"}, Spec: tt.spec}
+			source, err := SourceFor(context.Background(), r, tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SourceFor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var fetchErr *FetchError
+			if tt.wantFetchErr && !errors.As(err, &fetchErr) {
+				t.Errorf("SourceFor() error = %v, want a FetchError", err)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := fmt.Sprintf("%T", source); got != tt.want {
+				t.Errorf("SourceFor() = %s, want %s", got, tt.want)
+			}
+			if tt.wantRepo != "" && (r.Spec.RepoUrl != tt.wantRepo || r.Spec.Revision == "" || r.Spec.SourceRef != nil) {
+				t.Errorf("repoUrl = %s, sourceRef = %v, want %s", r.Spec.RepoUrl, r.Spec.SourceRef, tt.wantRepo)
+			}
+		})
+	}
+}
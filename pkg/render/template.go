@@ -0,0 +1,261 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html/template"
+	"sort"
+	"strconv"
+	"text/template/parse"
+	"time"
+
+	backwoodsv1 "github.com/backwoods-devops/archimedes/api/v1"
+)
+
+// MetadataKey is the reserved top-level value holding the Metadata in templates
+const MetadataKey = "Archimedes"
+
+// Metadata describes the ArchimedesProperty being rendered and the commit
+// its template came from. It is available to templates as .Archimedes
+type Metadata struct {
+	Name        string
+	Namespace   string
+	Labels      map[string]string
+	Annotations map[string]string
+	RepoUrl     string
+	Revision    string
+	Path        string
+	Commit      Commit
+}
+
+// NewMetadata returns the Metadata of a property rendered from src
+func NewMetadata(r *backwoodsv1.ArchimedesProperty, src *Template) Metadata {
+	return Metadata{
+		Name:        r.Name,
+		Namespace:   r.Namespace,
+		Labels:      r.Labels,
+		Annotations: r.Annotations,
+		RepoUrl:     src.RepoUrl,
+		Revision:    src.Revision,
+		Path:        r.Spec.PropertiesPath,
+		Commit:      src.Commit,
+	}
+}
+
+// Limits bound the resources a single render of a properties template may use.
+// A zero value disables the corresponding limit.
+type Limits struct {
+	// Timeout is the longest a render may run
+	Timeout time.Duration
+	// MaxOutputBytes is the maximum size in bytes of the rendered template
+	MaxOutputBytes int
+	// MaxIncludeDepth is the maximum nesting of include and template calls
+	MaxIncludeDepth int
+}
+
+// limitedWriter buffers template output, failing once the output grows past max
+// bytes or the render has been cancelled
+type limitedWriter struct {
+	buf  bytes.Buffer
+	max  int
+	done <-chan struct{}
+}
+
+func (w *limitedWriter) Write(p []byte) (int, error) {
+	select {
+	case <-w.done:
+		return 0, &LimitError{Message: "template render was cancelled"}
+	default:
+	}
+	if w.max > 0 && w.buf.Len()+len(p) > w.max {
+		return 0, &LimitError{Message: fmt.Sprintf("template output exceeds %d bytes", w.max)}
+	}
+	return w.buf.Write(p)
+}
+
+// guard bounds the execution of a template set. Calls to its funcs are injected at the
+// start of every range body and around every template body, so a render stops on its
+// next iteration or template call once it is cancelled, even when it writes nothing.
+type guard struct {
+	done     <-chan struct{}
+	maxDepth int
+	depth    int
+}
+
+func (g *guard) tick() (bool, error) {
+	select {
+	case <-g.done:
+		return false, &LimitError{Message: "template render was cancelled"}
+	default:
+	}
+	return false, nil
+}
+
+func (g *guard) enter(name string) (bool, error) {
+	if _, err := g.tick(); err != nil {
+		return false, err
+	}
+	// the properties template itself is the first level
+	if g.maxDepth > 0 && g.depth > g.maxDepth {
+		return false, &LimitError{Message: fmt.Sprintf("template %q exceeds the maximum include depth of %d", name, g.maxDepth)}
+	}
+	g.depth++
+	return false, nil
+}
+
+func (g *guard) leave() (bool, error) {
+	g.depth--
+	return false, nil
+}
+
+func (g *guard) funcs() template.FuncMap {
+	return template.FuncMap{
+		"archimedesTick":  g.tick,
+		"archimedesEnter": g.enter,
+		"archimedesLeave": g.leave,
+	}
+}
+
+// guardCall parses an if action calling a guard func. An if action is used so the
+// call writes nothing and html/template adds no escaping to it.
+func guardCall(call string, funcs template.FuncMap) (parse.Node, error) {
+	trees, err := parse.Parse("guard", "{{if "+call+"}}{{end}}", "", "", funcs)
+	if err != nil {
+		return nil, err
+	}
+	return trees["guard"].Root.Nodes[0], nil
+}
+
+// guardTrees injects the calls of the guard into every template of t
+func guardTrees(t *template.Template, funcs template.FuncMap) error {
+	tick, err := guardCall("archimedesTick", funcs)
+	if err != nil {
+		return err
+	}
+	leave, err := guardCall("archimedesLeave", funcs)
+	if err != nil {
+		return err
+	}
+	for _, tpl := range t.Templates() {
+		if tpl.Tree == nil || tpl.Tree.Root == nil {
+			continue
+		}
+		enter, err := guardCall("archimedesEnter "+strconv.Quote(tpl.Name()), funcs)
+		if err != nil {
+			return err
+		}
+		guardRanges(tpl.Tree.Root, tick)
+		root := tpl.Tree.Root
+		root.Nodes = append([]parse.Node{enter}, append(root.Nodes, leave.Copy())...)
+	}
+	return nil
+}
+
+// guardRanges prepends a copy of tick to the body of every range under node
+func guardRanges(node parse.Node, tick parse.Node) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			guardRanges(child, tick)
+		}
+	case *parse.IfNode:
+		guardRanges(n.List, tick)
+		guardRanges(n.ElseList, tick)
+	case *parse.WithNode:
+		guardRanges(n.List, tick)
+		guardRanges(n.ElseList, tick)
+	case *parse.RangeNode:
+		guardRanges(n.List, tick)
+		guardRanges(n.ElseList, tick)
+		n.List.Nodes = append([]parse.Node{tick.Copy()}, n.List.Nodes...)
+	}
+}
+
+// Execute merges the values into the properties template. Every partial is
+// parsed into the same template set so its named templates can be used with
+// {{ template }} or {{ include }}. The funcs of opts are made available to the templates.
+func Execute(src *Template, values map[string]interface{}, opts RenderOptions) (string, error) {
+	limits := opts.Limits
+	done := make(chan struct{})
+	out := &limitedWriter{max: limits.MaxOutputBytes, done: done}
+
+	g := &guard{done: done, maxDepth: limits.MaxIncludeDepth}
+	t := template.New("properties").Funcs(opts.Funcs).Funcs(g.funcs())
+	t.Funcs(template.FuncMap{
+		"include": func(name string, data interface{}) (template.HTML, error) {
+			buf := &limitedWriter{max: limits.MaxOutputBytes, done: done}
+			err := t.ExecuteTemplate(buf, name, data)
+			return template.HTML(buf.buf.String()), err
+		},
+	})
+
+	_, err := t.Parse(string(src.Content))
+	if err != nil {
+		return "", &TemplateError{Name: "properties", Err: err}
+	}
+
+	names := make([]string, 0, len(src.Partials))
+	for name := range src.Partials {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		_, err = t.New(name).Parse(string(src.Partials[name]))
+		if err != nil {
+			return "", &TemplateError{Name: name, Err: err}
+		}
+	}
+	if err := guardTrees(t, g.funcs()); err != nil {
+		return "", &TemplateError{Name: "properties", Err: err}
+	}
+
+	// The template runs in its own goroutine so a runaway render can be abandoned.
+	// Closing done makes its next write, range iteration or template call fail, which
+	// stops the execution.
+	result := make(chan error, 1)
+	go func() {
+		result <- t.Execute(out, values)
+	}()
+
+	var timeout <-chan time.Time
+	if limits.Timeout > 0 {
+		timer := time.NewTimer(limits.Timeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	select {
+	case err = <-result:
+	case <-timeout:
+		close(done)
+		return "", &LimitError{Message: fmt.Sprintf("template render did not finish within %s", limits.Timeout)}
+	}
+	var limitErr *LimitError
+	if errors.As(err, &limitErr) {
+		return "", limitErr
+	}
+	if err != nil {
+		return "", &TemplateError{Name: "properties", Err: err}
+	}
+	return out.buf.String(), nil
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	backwoodsv1 "github.com/backwoods-devops/archimedes/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// ValuesKindNamespaced and ValuesKindCluster are the kinds of values a property may reference
+	ValuesKindNamespaced = "ArchimedesValues"
+	ValuesKindCluster    = "ClusterArchimedesValues"
+)
+
+// ValuesOptions configure the values merged into the values of a property
+type ValuesOptions struct {
+	// ClusterValues allows ClusterArchimedesValues to be inherited and referenced
+	ClusterValues bool
+}
+
+// valuesLayer is one document of values merged into the values of a property
+type valuesLayer struct {
+	name   string
+	values string
+}
+
+// ResolveValues merges the values a property inherits by selector and the values it
+// references in valuesFrom under its sourceConfig, reading them with a client. Cluster
+// values are merged first, then namespaced values, then valuesFrom in order and
+// sourceConfig last, so later layers override earlier ones. ClusterArchimedesValues are
+// only read when opts allow them. The names of the values merged are returned in order.
+func ResolveValues(ctx context.Context, c client.Reader, instance *backwoodsv1.ArchimedesProperty, opts ValuesOptions) (map[string]interface{}, []string, error) {
+	layers, err := inheritedValues(ctx, c, instance, opts.ClusterValues)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, ref := range instance.Spec.ValuesFrom {
+		switch ref.Kind {
+		case ValuesKindCluster:
+			if !opts.ClusterValues {
+				return nil, nil, &ValuesError{Source: ref.Kind + "/" + ref.Name, Read: true, Err: errors.New("cluster values are disabled")}
+			}
+			values := &backwoodsv1.ClusterArchimedesValues{}
+			err := c.Get(ctx, types.NamespacedName{Name: ref.Name}, values)
+			if err != nil {
+				return nil, nil, &ValuesError{Source: ValuesKindCluster + "/" + ref.Name, Read: true, Err: err}
+			}
+			layers = append(layers, valuesLayer{name: ValuesKindCluster + "/" + ref.Name, values: values.Spec.Values})
+		case ValuesKindNamespaced:
+			values := &backwoodsv1.ArchimedesValues{}
+			err := c.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: instance.Namespace}, values)
+			if err != nil {
+				return nil, nil, &ValuesError{Source: ValuesKindNamespaced + "/" + ref.Name, Read: true, Err: err}
+			}
+			layers = append(layers, valuesLayer{name: ValuesKindNamespaced + "/" + ref.Name, values: values.Spec.Values})
+		default:
+			return nil, nil, &ValuesError{Source: ref.Kind + "/" + ref.Name, Read: true, Err: fmt.Errorf("unknown values kind %q", ref.Kind)}
+		}
+	}
+
+	merged := map[string]interface{}{}
+	names := []string{}
+	for _, layer := range layers {
+		values, err := ParseValues(layer.values)
+		if err != nil {
+			return nil, nil, &ValuesError{Source: layer.name, Err: err}
+		}
+		MergeValues(merged, values)
+		names = append(names, layer.name)
+	}
+
+	values, err := ParseValues(instance.Spec.SourceConfig)
+	if err != nil {
+		return nil, nil, &ValuesError{Source: "sourceConfig", Err: err}
+	}
+	MergeValues(merged, values)
+	return merged, names, nil
+}
+
+// inheritedValues returns the cluster values selecting the namespace of a property and
+// the namespaced values selecting the property, each sorted by name
+func inheritedValues(ctx context.Context, c client.Reader, instance *backwoodsv1.ArchimedesProperty, clusterValues bool) ([]valuesLayer, error) {
+	layers := []valuesLayer{}
+
+	if clusterValues {
+		namespace := &corev1.Namespace{}
+		err := c.Get(ctx, types.NamespacedName{Name: instance.Namespace}, namespace)
+		if err != nil {
+			return nil, &ValuesError{Source: "Namespace/" + instance.Namespace, Read: true, Err: err}
+		}
+		list := &backwoodsv1.ClusterArchimedesValuesList{}
+		err = c.List(ctx, list)
+		if err != nil {
+			return nil, &ValuesError{Source: ValuesKindCluster, Read: true, Err: err}
+		}
+		sort.Slice(list.Items, func(i, j int) bool { return list.Items[i].Name < list.Items[j].Name })
+		for _, values := range list.Items {
+			ok, err := selects(values.Spec.NamespaceSelector, namespace.Labels)
+			if err != nil {
+				return nil, &ValuesError{Source: ValuesKindCluster + "/" + values.Name, Err: err}
+			}
+			if ok {
+				layers = append(layers, valuesLayer{name: ValuesKindCluster + "/" + values.Name, values: values.Spec.Values})
+			}
+		}
+	}
+
+	list := &backwoodsv1.ArchimedesValuesList{}
+	err := c.List(ctx, list, client.InNamespace(instance.Namespace))
+	if err != nil {
+		return nil, &ValuesError{Source: ValuesKindNamespaced, Read: true, Err: err}
+	}
+	sort.Slice(list.Items, func(i, j int) bool { return list.Items[i].Name < list.Items[j].Name })
+	for _, values := range list.Items {
+		ok, err := selects(values.Spec.PropertySelector, instance.Labels)
+		if err != nil {
+			return nil, &ValuesError{Source: ValuesKindNamespaced + "/" + values.Name, Err: err}
+		}
+		if ok {
+			layers = append(layers, valuesLayer{name: ValuesKindNamespaced + "/" + values.Name, values: values.Spec.Values})
+		}
+	}
+	return layers, nil
+}
+
+// selects reports whether a selector matches a set of labels, a nil selector matches nothing
+func selects(selector *metav1.LabelSelector, set map[string]string) (bool, error) {
+	if selector == nil {
+		return false, nil
+	}
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return false, err
+	}
+	return s.Matches(labels.Set(set)), nil
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	backwoodsv1 "github.com/backwoods-devops/archimedes/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newValuesClient(t *testing.T, objs ...client.Object) client.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := backwoodsv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func TestResolveValues(t *testing.T) {
+	forest := &metav1.LabelSelector{MatchLabels: map[string]string{"team": "forest"}}
+	trees := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "trees"}}
+	rocks := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "rocks"}}
+	c := newValuesClient(t,
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default", Labels: map[string]string{"team": "forest"}}},
+		&backwoodsv1.ClusterArchimedesValues{
+			ObjectMeta: metav1.ObjectMeta{Name: "b-region"},
+			Spec:       backwoodsv1.ClusterArchimedesValuesSpec{NamespaceSelector: forest, Values: "layer: b-region\nregion: north\n"},
+		},
+		&backwoodsv1.ClusterArchimedesValues{
+			ObjectMeta: metav1.ObjectMeta{Name: "a-base"},
+			Spec:       backwoodsv1.ClusterArchimedesValuesSpec{NamespaceSelector: forest, Values: "layer: a-base\ndb:\n  host: cluster\n  port: 5432\n"},
+		},
+		&backwoodsv1.ClusterArchimedesValues{
+			ObjectMeta: metav1.ObjectMeta{Name: "desert"},
+			Spec:       backwoodsv1.ClusterArchimedesValuesSpec{NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "desert"}}, Values: "layer: desert\n"},
+		},
+		&backwoodsv1.ClusterArchimedesValues{
+			ObjectMeta: metav1.ObjectMeta{Name: "global"},
+			Spec:       backwoodsv1.ClusterArchimedesValuesSpec{Values: "layer: global\nglobal: true\n"},
+		},
+		&backwoodsv1.ArchimedesValues{
+			ObjectMeta: metav1.ObjectMeta{Name: "team", Namespace: "default"},
+			Spec:       backwoodsv1.ArchimedesValuesSpec{PropertySelector: trees, Values: "layer: team\ndb:\n  host: team\n"},
+		},
+		&backwoodsv1.ArchimedesValues{
+			ObjectMeta: metav1.ObjectMeta{Name: "rocks", Namespace: "default"},
+			Spec:       backwoodsv1.ArchimedesValuesSpec{PropertySelector: rocks, Values: "layer: rocks\n"},
+		},
+		&backwoodsv1.ArchimedesValues{
+			ObjectMeta: metav1.ObjectMeta{Name: "team", Namespace: "other"},
+			Spec:       backwoodsv1.ArchimedesValuesSpec{PropertySelector: trees, Values: "layer: other\n"},
+		},
+		&backwoodsv1.ArchimedesValues{
+			ObjectMeta: metav1.ObjectMeta{Name: "explicit", Namespace: "default"},
+			Spec:       backwoodsv1.ArchimedesValuesSpec{Values: "layer: explicit\nexplicit: true\n"},
+		},
+	)
+	tests := []struct {
+		name       string
+		valuesFrom []backwoodsv1.ValuesReference
+		config     string
+		opts       ValuesOptions
+		want       map[string]interface{} // values by dotted path
+		wantNames  []string
+	}{
+		{
+			name: "namespaced values",
+			want: map[string]interface{}{
+				"layer":   "team",
+				"region":  nil,
+				"db.host": "team",
+			},
+			wantNames: []string{"ArchimedesValues/team"},
+		},
+		{
+			name: "cluster values",
+			opts: ValuesOptions{ClusterValues: true},
+			want: map[string]interface{}{
+				"layer":   "team",
+				"region":  "north",
+				"db.host": "team",
+				"db.port": 5432,
+			},
+			wantNames: []string{"ClusterArchimedesValues/a-base", "ClusterArchimedesValues/b-region", "ArchimedesValues/team"},
+		},
+		{
+			name: "values from",
+			valuesFrom: []backwoodsv1.ValuesReference{
+				{Kind: ValuesKindNamespaced, Name: "explicit"},
+				{Kind: ValuesKindCluster, Name: "global"},
+			},
+			opts: ValuesOptions{ClusterValues: true},
+			want: map[string]interface{}{
+				"layer":    "global",
+				"region":   "north",
+				"explicit": true,
+				"global":   true,
+				"db.host":  "team",
+				"db.port":  5432,
+			},
+			wantNames: []string{
+				"ClusterArchimedesValues/a-base", "ClusterArchimedesValues/b-region", "ArchimedesValues/team",
+				"ArchimedesValues/explicit", "ClusterArchimedesValues/global",
+			},
+		},
+		{
+			name:       "source config last",
+			valuesFrom: []backwoodsv1.ValuesReference{{Kind: ValuesKindNamespaced, Name: "explicit"}},
+			config:     "layer: property\ndb:\n  port: 6432\n",
+			want: map[string]interface{}{
+				"layer":    "property",
+				"explicit": true,
+				"db.host":  "team",
+				"db.port":  6432,
+			},
+			wantNames: []string{"ArchimedesValues/team", "ArchimedesValues/explicit"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &backwoodsv1.ArchimedesProperty{
+				ObjectMeta: metav1.ObjectMeta{Name: "trees", Namespace: "default", Labels: map[string]string{"app": "trees"}},
+				Spec:       backwoodsv1.ArchimedesPropertySpec{ValuesFrom: tt.valuesFrom, SourceConfig: tt.config},
+			}
+			values, names, err := ResolveValues(context.Background(), c, r, tt.opts)
+			if err != nil {
+				t.Fatalf("ResolveValues() error = %v", err)
+			}
+			for path, want := range tt.want {
+				if got, _ := ValueAt(values, strings.Split(path, ".")); got != want {
+					t.Errorf("ResolveValues() %s = %v, want %v", path, got, want)
+				}
+			}
+			if !reflect.DeepEqual(names, tt.wantNames) {
+				t.Errorf("ResolveValues() names = %v, want %v", names, tt.wantNames)
+			}
+		})
+	}
+}
+
+func TestResolveValuesErrors(t *testing.T) {
+	c := newValuesClient(t, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}})
+	tests := []struct {
+		name string
+		ref  backwoodsv1.ValuesReference
+		opts ValuesOptions
+	}{
+		{name: "missing values", ref: backwoodsv1.ValuesReference{Kind: ValuesKindNamespaced, Name: "shared"}},
+		{name: "missing cluster values", ref: backwoodsv1.ValuesReference{Kind: ValuesKindCluster, Name: "shared"}, opts: ValuesOptions{ClusterValues: true}},
+		{name: "cluster values disabled", ref: backwoodsv1.ValuesReference{Kind: ValuesKindCluster, Name: "shared"}},
+		{name: "unknown kind", ref: backwoodsv1.ValuesReference{Kind: "Values", Name: "shared"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &backwoodsv1.ArchimedesProperty{
+				ObjectMeta: metav1.ObjectMeta{Name: "trees", Namespace: "default"},
+				Spec:       backwoodsv1.ArchimedesPropertySpec{ValuesFrom: []backwoodsv1.ValuesReference{tt.ref}},
+			}
+			_, _, err := ResolveValues(context.Background(), c, r, tt.opts)
+			var valuesErr *ValuesError
+			if !errors.As(err, &valuesErr) || !valuesErr.Read || valuesErr.Source != tt.ref.Kind+"/"+tt.ref.Name {
+				t.Errorf("ResolveValues() error = %#v, want a ValuesError for %s/%s", err, tt.ref.Kind, tt.ref.Name)
+			}
+		})
+	}
+}